@@ -33,6 +33,12 @@ func New(i do.Injector) (API, error) {
 	updatesResource := do.MustInvoke[updatesResource](i)
 	settingsResource := do.MustInvoke[settingsResource](i)
 	favoritesResource := do.MustInvoke[favoritesResource](i)
+	syncDevicesResource := do.MustInvoke[syncDevicesResource](i)
+	directoryResource := do.MustInvoke[directoryResource](i)
+	podcastListsResource := do.MustInvoke[podcastListsResource](i)
+	dataResource := do.MustInvoke[dataResource](i)
+	tagsResource := do.MustInvoke[tagsResource](i)
+	nextcloudResource := do.MustInvoke[nextcloudResource](i)
 
 	router := chi.NewRouter()
 
@@ -40,6 +46,9 @@ func New(i do.Injector) (API, error) {
 		r.Mount("/", simpleResource.Routes())
 	})
 
+	router.Mount("/", directoryResource.Routes())
+	router.Mount("/index.php/apps/gpoddersync", nextcloudResource.Routes())
+
 	router.Route("/api/2", func(r chi.Router) {
 		r.Mount("/auth", authResource.Routes())
 		r.Mount("/devices", deviceResource.Routes())
@@ -48,6 +57,10 @@ func New(i do.Injector) (API, error) {
 		r.Mount("/updates", updatesResource.Routes())
 		r.Mount("/settings", settingsResource.Routes())
 		r.Mount("/favorites", favoritesResource.Routes())
+		r.Mount("/sync-devices", syncDevicesResource.Routes())
+		r.Mount("/lists", podcastListsResource.Routes())
+		r.Mount("/data", dataResource.Routes())
+		r.Mount("/", tagsResource.Routes())
 	})
 
 	return API{router}, nil
@@ -0,0 +1,143 @@
+package api
+
+// api_directory.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/formats"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+// maxSearchResults limit number of podcasts returned by search.
+const maxSearchResults = 100
+
+// directoryResource handle request to podcast directory api (search, toplist, suggestions).
+type directoryResource struct {
+	podcastsSrv *service.PodcastsSrv
+}
+
+func newDirectoryResource(i do.Injector) (directoryResource, error) {
+	return directoryResource{
+		podcastsSrv: do.MustInvoke[*service.PodcastsSrv](i),
+	}, nil
+}
+
+func (d directoryResource) Routes() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Get(`/search.{format}`, srvsupport.WrapNamed(d.search, "api_search"))
+	r.Get(`/toplist/{number:[0-9]+}.{format}`, srvsupport.WrapNamed(d.toplist, "api_toplist"))
+	r.Get(`/suggestions/{number:[0-9]+}.{format}`, srvsupport.WrapNamed(d.suggestions, "api_suggestions"))
+
+	return r
+}
+
+func (d directoryResource) search(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	text := r.URL.Query().Get("q")
+
+	podcasts, err := d.podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: text, Limit: maxSearchResults})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("DirectoryResource: search podcasts text=%q error=%q", text, err)
+
+		return
+	}
+
+	writePodcasts(w, r, logger, podcasts)
+}
+
+func (d directoryResource) toplist(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	number, _ := strconv.ParseUint(chi.URLParam(r, "number"), 10, 32)
+
+	podcasts, err := d.podcastsSrv.GetToplist(ctx, &query.GetToplistQuery{Limit: uint(number)})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("DirectoryResource: get toplist number=%d error=%q", number, err)
+
+		return
+	}
+
+	writePodcasts(w, r, logger, podcasts)
+}
+
+func (d directoryResource) suggestions(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	number, _ := strconv.ParseUint(chi.URLParam(r, "number"), 10, 32)
+
+	q := query.GetSuggestionsQuery{UserName: user, Limit: uint(number)}
+
+	podcasts, err := d.podcastsSrv.GetSuggestions(ctx, &q)
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("DirectoryResource: get suggestions user_name=%s number=%d error=%q", user, number, err)
+
+		return
+	}
+
+	writePodcasts(w, r, logger, podcasts)
+}
+
+// writePodcasts write list of podcasts in format requested by `format` url parameter.
+func writePodcasts(w http.ResponseWriter, r *http.Request, logger *zerolog.Logger, podcasts model.Podcasts) {
+	switch format := chi.URLParam(r, "format"); format {
+	case "opml":
+		o := formats.NewOPML("go-gpo")
+		for _, p := range podcasts {
+			o.AddRSS(p.URL, p.Title, p.Title)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.XML(w, r, &o)
+	case "json":
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, common.Map(podcasts, newPodcastFromModel))
+	case "jsonp":
+		w.WriteHeader(http.StatusOK)
+		render.JSON(newJSONPWriter(r, w), r, common.Map(podcasts, newPodcastFromModel))
+	case "txt":
+		w.WriteHeader(http.StatusOK)
+		render.PlainText(w, r, strings.Join(podcasts.ToURLs(), "\n"))
+	case "xml":
+		xmlpodcasts := formats.NewXMLPodcasts(podcasts)
+
+		w.WriteHeader(http.StatusOK)
+		render.XML(w, r, &xmlpodcasts)
+	default:
+		logger.Info().Msgf("DirectoryResource: unknown format=%q", format)
+		writeError(w, r, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,243 @@
+package api
+
+// api_nextcloud.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+// nextcloudResource handle request to api compatible with Nextcloud gPodder Sync app
+// (/index.php/apps/gpoddersync/). This api has no devices; all changes are applied to
+// user subscriptions and episodes.
+type nextcloudResource struct {
+	subsSrv     *service.SubscriptionsSrv
+	episodesSrv *service.EpisodesSrv
+}
+
+func newNextcloudResource(i do.Injector) (nextcloudResource, error) {
+	return nextcloudResource{
+		subsSrv:     do.MustInvoke[*service.SubscriptionsSrv](i),
+		episodesSrv: do.MustInvoke[*service.EpisodesSrv](i),
+	}, nil
+}
+
+func (n nextcloudResource) Routes() *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(sessionUserMiddleware)
+	r.Use(noDeviceTokenMiddleware)
+
+	r.Get(`/subscriptions`, srvsupport.WrapNamed(n.getSubscriptions, "api_nc_subs"))
+	r.Post(`/subscription_change/create`, srvsupport.WrapNamed(n.uploadSubscriptionChanges, "api_nc_subs_post"))
+	r.Get(`/episode_action`, srvsupport.WrapNamed(n.getEpisodeActions, "api_nc_episodes"))
+	r.Post(`/episode_action/create`, srvsupport.WrapNamed(n.uploadEpisodeActions, "api_nc_episodes_post"))
+
+	return r
+}
+
+func (n nextcloudResource) getSubscriptions(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	now := time.Now()
+
+	since, err := getSinceParameter(r)
+	if err != nil {
+		logger.Debug().Err(err).Msgf("NextcloudResource: parse since=%q to time error=%q",
+			r.URL.Query().Get("since"), err)
+		writeError(w, r, http.StatusBadRequest)
+
+		return
+	}
+
+	state, err := n.subsSrv.GetSubscriptionChanges(ctx,
+		&query.GetSubscriptionChangesQuery{UserName: user, Since: since})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("NextcloudResource: get user_name=%s subscriptions changes error=%q", user, err)
+
+		return
+	}
+
+	res := struct {
+		Add       []string `json:"add"`
+		Remove    []string `json:"remove"`
+		Timestamp int64    `json:"timestamp"`
+	}{
+		Add:       state.AddedURLs(),
+		Remove:    state.RemovedURLs(),
+		Timestamp: now.UTC().Unix(),
+	}
+
+	srvsupport.RenderJSON(w, r, &res)
+}
+
+func (n nextcloudResource) uploadSubscriptionChanges(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	now := time.Now()
+	changes := struct {
+		Add    []string `json:"add"`
+		Remove []string `json:"remove"`
+	}{}
+
+	if err := render.DecodeJSON(r.Body, &changes); err != nil {
+		logger.Debug().Err(err).Msgf("NextcloudResource: parse json error=%q", err)
+		writeError(w, r, http.StatusBadRequest)
+
+		return
+	}
+
+	cmd := command.ChangeSubscriptionsCmd{
+		UserName:  user,
+		Add:       changes.Add,
+		Remove:    changes.Remove,
+		Timestamp: now.UTC(),
+	}
+
+	if _, err := n.subsSrv.ChangeSubscriptions(ctx, &cmd); err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("NextcloudResource: update user_name=%s subscription changes error=%q", user, err)
+
+		return
+	}
+
+	srvsupport.RenderJSON(w, r, &nextcloudTimestamp{Timestamp: now.UTC().Unix()})
+}
+
+func (n nextcloudResource) getEpisodeActions(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	now := time.Now()
+
+	since, err := getSinceParameter(r)
+	if err != nil {
+		logger.Debug().Err(err).Msgf("NextcloudResource: parse since=%q to time error=%q",
+			r.URL.Query().Get("since"), err)
+		writeError(w, r, http.StatusBadRequest)
+
+		return
+	}
+
+	q := query.GetEpisodesQuery{UserName: user, Since: since, Limit: maxEpisodesInResult}
+
+	episodes, err := n.episodesSrv.GetEpisodes(ctx, &q)
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("NextcloudResource: get user_name=%s episodes actions error=%q", user, err)
+
+		return
+	}
+
+	resp := struct {
+		Actions   []episode `json:"actions"`
+		Timestamp int64     `json:"timestamp"`
+	}{
+		Actions:   common.Map(episodes, newNextcloudEpisodeFromModel),
+		Timestamp: now.UTC().Unix(),
+	}
+
+	srvsupport.RenderJSON(w, r, &resp)
+}
+
+func (n nextcloudResource) uploadEpisodeActions(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+
+	var reqData []episode
+
+	if err := render.DecodeJSON(r.Body, &reqData); err != nil {
+		logger.Debug().Err(err).Msgf("NextcloudResource: parse json error=%q", err)
+		writeError(w, r, http.StatusBadRequest)
+
+		return
+	}
+
+	actions := make([]model.Episode, 0, len(reqData))
+
+	for _, reqEpisode := range reqData {
+		// actions are sent in upper case; there is no devices in this api.
+		reqEpisode.Action = strings.ToLower(reqEpisode.Action)
+		reqEpisode.Device = ""
+		reqEpisode.sanitize()
+
+		if reqEpisode.Podcast == "" {
+			logger.Debug().Interface("req", reqEpisode).Msg("NextcloudResource: skipped episode - empty podcast")
+
+			continue
+		}
+
+		if err := reqEpisode.validate(); err != nil {
+			logger.Debug().Err(err).Interface("req", reqEpisode).
+				Msgf("NextcloudResource: validate episode error=%q", err)
+			writeError(w, r, http.StatusBadRequest)
+
+			return
+		}
+
+		action := reqEpisode.toModel()
+		action.Device = nil
+
+		actions = append(actions, action)
+	}
+
+	cmd := command.AddActionCmd{UserName: user, Actions: actions}
+	if err := n.episodesSrv.AddAction(ctx, &cmd); err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("NextcloudResource: save user_name=%s episodes error=%q", user, err)
+
+		return
+	}
+
+	srvsupport.RenderJSON(w, r, &nextcloudTimestamp{Timestamp: time.Now().UTC().Unix()})
+}
+
+//------------------------------------------------------------------------------
+
+type nextcloudTimestamp struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+func newNextcloudEpisodeFromModel(e *model.Episode) episode {
+	res := newEpisodesFromModel(e)
+	res.Action = strings.ToUpper(res.Action)
+	res.Device = ""
+
+	return res
+}
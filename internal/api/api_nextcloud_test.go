@@ -0,0 +1,160 @@
+package api
+
+//
+// api_nextcloud_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"gitlab.com/kabes/go-gpo/internal/assert"
+)
+
+type ncSubscriptions struct {
+	Add       []string `json:"add"`
+	Remove    []string `json:"remove"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+type ncEpisodeActions struct {
+	Actions []struct {
+		Podcast   string `json:"podcast"`
+		Episode   string `json:"episode"`
+		GUID      string `json:"guid"`
+		Action    string `json:"action"`
+		Timestamp string `json:"timestamp"`
+		Position  int    `json:"position"`
+		Started   int    `json:"started"`
+		Total     int    `json:"total"`
+		Device    string `json:"device"`
+	} `json:"actions"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+func prepareNextcloudServer(t *testing.T, username string) string {
+	t.Helper()
+
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	res, err := newNextcloudResource(i)
+	assert.NoErr(t, err)
+
+	srv := newTestServer(t, username, res.Routes())
+
+	return srv.URL
+}
+
+func TestNextcloudSubscriptions(t *testing.T) {
+	url := prepareNextcloudServer(t, "user1")
+
+	status, body := doRequest(t, http.MethodGet, url+"/subscriptions", "")
+	assert.Equal(t, status, http.StatusOK)
+
+	var subs ncSubscriptions
+	assert.NoErr(t, json.Unmarshal([]byte(body), &subs))
+	assert.Equal(t, len(subs.Add), 0)
+	assert.Equal(t, len(subs.Remove), 0)
+
+	// payload sent by AntennaPod
+	status, body = doRequest(t, http.MethodPost, url+"/subscription_change/create",
+		`{"add":["https://example.com/feed1.xml","https://example.com/feed2.xml"],"remove":[]}`)
+	assert.Equal(t, status, http.StatusOK)
+
+	var ts struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	assert.NoErr(t, json.Unmarshal([]byte(body), &ts))
+	assert.True(t, ts.Timestamp > 0)
+
+	status, body = doRequest(t, http.MethodPost, url+"/subscription_change/create",
+		`{"add":[],"remove":["https://example.com/feed2.xml"]}`)
+	assert.Equal(t, status, http.StatusOK)
+
+	status, body = doRequest(t, http.MethodGet, url+"/subscriptions?since=0", "")
+	assert.Equal(t, status, http.StatusOK)
+	assert.NoErr(t, json.Unmarshal([]byte(body), &subs))
+	assert.Equal(t, subs.Add, []string{"https://example.com/feed1.xml"})
+	assert.Equal(t, subs.Remove, []string{"https://example.com/feed2.xml"})
+	assert.True(t, subs.Timestamp > 0)
+
+	status, _ = doRequest(t, http.MethodGet, url+"/subscriptions?since=abc", "")
+	assert.Equal(t, status, http.StatusBadRequest)
+
+	status, _ = doRequest(t, http.MethodPost, url+"/subscription_change/create", `{"add":`)
+	assert.Equal(t, status, http.StatusBadRequest)
+}
+
+func TestNextcloudEpisodeActions(t *testing.T) {
+	url := prepareNextcloudServer(t, "user1")
+
+	// payload sent by AntennaPod / Kasts
+	status, body := doRequest(t, http.MethodPost, url+"/episode_action/create", `[
+		{
+			"podcast": "https://example.com/feed1.xml",
+			"episode": "https://example.com/ep1.mp3",
+			"guid": "ep1-guid",
+			"action": "PLAY",
+			"timestamp": "2025-10-07T13:27:14",
+			"started": 15,
+			"position": 120,
+			"total": 500
+		},
+		{
+			"podcast": "https://example.com/feed1.xml",
+			"episode": "https://example.com/ep2.mp3",
+			"action": "download",
+			"timestamp": "2025-10-07T13:28:00",
+			"started": -1,
+			"position": -1,
+			"total": -1
+		}
+	]`)
+	assert.Equal(t, status, http.StatusOK)
+
+	var ts struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	assert.NoErr(t, json.Unmarshal([]byte(body), &ts))
+	assert.True(t, ts.Timestamp > 0)
+
+	status, body = doRequest(t, http.MethodGet, url+"/episode_action?since=0", "")
+	assert.Equal(t, status, http.StatusOK)
+
+	var actions ncEpisodeActions
+	assert.NoErr(t, json.Unmarshal([]byte(body), &actions))
+	assert.Equal(t, len(actions.Actions), 2)
+	assert.True(t, actions.Timestamp > 0)
+
+	act := actions.Actions[0]
+	assert.Equal(t, act.Podcast, "https://example.com/feed1.xml")
+	assert.Equal(t, act.Episode, "https://example.com/ep1.mp3")
+	assert.Equal(t, act.GUID, "ep1-guid")
+	assert.Equal(t, act.Action, "PLAY")
+	assert.Equal(t, act.Timestamp, "2025-10-07T13:27:14")
+	assert.Equal(t, act.Started, 15)
+	assert.Equal(t, act.Position, 120)
+	assert.Equal(t, act.Total, 500)
+	assert.Equal(t, act.Device, "")
+	assert.Equal(t, actions.Actions[1].Action, "DOWNLOAD")
+
+	// unknown action is rejected
+	status, _ = doRequest(t, http.MethodPost, url+"/episode_action/create",
+		`[{"podcast": "https://example.com/feed1.xml", "episode": "https://example.com/ep3.mp3",
+		"action": "JUMP", "timestamp": "2025-10-07T13:28:00"}]`)
+	assert.Equal(t, status, http.StatusBadRequest)
+}
+
+func TestNextcloudUnauthenticated(t *testing.T) {
+	url := prepareNextcloudServer(t, "")
+
+	status, _ := doRequest(t, http.MethodGet, url+"/subscriptions", "")
+	assert.Equal(t, status, http.StatusForbidden)
+
+	status, _ = doRequest(t, http.MethodPost, url+"/episode_action/create", "[]")
+	assert.Equal(t, status, http.StatusForbidden)
+}
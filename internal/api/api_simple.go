@@ -40,7 +40,7 @@ func (s *simpleResource) Routes() *chi.Mux {
 
 	// base: /subscriptions/
 
-	router.With(checkUserMiddleware).
+	router.With(checkUserMiddleware, noDeviceTokenMiddleware).
 		Get(`/{user:[\w+.-]+}.{format}`, srvsupport.WrapNamed(s.downloadUserSubscriptions, "api_subs_user"))
 	router.With(checkUserMiddleware, checkDeviceMiddleware).
 		Get(`/{user:[\w+.-]+}/{devicename:[\w.-]+}.{format}`,
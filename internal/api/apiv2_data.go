@@ -0,0 +1,135 @@
+package api
+
+// apiv2_data.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+// dataResource handle request to /api/2/data resource (podcasts and episodes metadata).
+type dataResource struct {
+	podcastsSrv *service.PodcastsSrv
+	episodesSrv *service.EpisodesSrv
+	webroot     string
+}
+
+func newDataResource(i do.Injector) (dataResource, error) {
+	return dataResource{
+		podcastsSrv: do.MustInvoke[*service.PodcastsSrv](i),
+		episodesSrv: do.MustInvoke[*service.EpisodesSrv](i),
+		webroot:     do.MustInvokeNamed[string](i, "server.webroot"),
+	}, nil
+}
+
+func (d dataResource) Routes() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Get(`/podcast.json`, srvsupport.WrapNamed(d.podcastData, "api_data_podcast"))
+	r.Get(`/episode.json`, srvsupport.WrapNamed(d.episodeData, "api_data_episode"))
+
+	return r
+}
+
+func (d dataResource) podcastData(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	q := query.GetPodcastDataQuery{
+		UserName:   common.ContextUser(ctx),
+		PodcastURL: r.URL.Query().Get("url"),
+	}
+
+	podcast, err := d.podcastsSrv.GetPodcastData(ctx, &q)
+	if errors.Is(err, common.ErrUnknownPodcast) {
+		writeError(w, r, http.StatusNotFound)
+
+		return
+	} else if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("DataResource: get podcast data podcast_url=%q error=%q", q.PodcastURL, err)
+
+		return
+	}
+
+	res := newPodcastFromModel(podcast)
+	res.MygpoLink = mygpoLink(d.webroot, res.MygpoLink)
+
+	srvsupport.RenderJSON(w, r, &res)
+}
+
+func (d dataResource) episodeData(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	q := query.GetEpisodeDataQuery{
+		UserName:   common.ContextUser(ctx),
+		PodcastURL: r.URL.Query().Get("podcast"),
+		EpisodeURL: r.URL.Query().Get("url"),
+	}
+
+	episode, err := d.episodesSrv.GetEpisodeData(ctx, &q)
+	if errors.Is(err, common.ErrUnknownEpisode) {
+		writeError(w, r, http.StatusNotFound)
+
+		return
+	} else if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("DataResource: get episode data podcast_url=%q episode_url=%q error=%q",
+				q.PodcastURL, q.EpisodeURL, err)
+
+		return
+	}
+
+	res := newEpisodeDataFromModel(episode)
+	res.MygpoLink = mygpoLink(d.webroot, res.MygpoLink)
+
+	srvsupport.RenderJSON(w, r, &res)
+}
+
+//------------------------------------------------------------------------------
+
+type episodeData struct {
+	Released     time.Time `json:"released"`
+	Title        string    `json:"title"`
+	URL          string    `json:"url"`
+	PodcastTitle string    `json:"podcast_title"`
+	PodcastURL   string    `json:"podcast_url"`
+	Description  string    `json:"description"`
+	Website      string    `json:"website"`
+	MygpoLink    string    `json:"mygpo_link"`
+}
+
+func newEpisodeDataFromModel(e *model.EpisodeUpdate) episodeData {
+	return episodeData{
+		Title:        e.Title,
+		URL:          e.URL,
+		PodcastTitle: e.PodcastTitle,
+		PodcastURL:   e.PodcastURL,
+		Description:  e.Description,
+		Website:      e.Website,
+		MygpoLink:    e.MygpoLink,
+		Released:     e.Released,
+	}
+}
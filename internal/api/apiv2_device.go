@@ -37,7 +37,7 @@ func newDeviceResource(i do.Injector) (deviceResource, error) {
 func (d deviceResource) Routes() *chi.Mux {
 	r := chi.NewRouter()
 
-	r.With(checkUserMiddleware).
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
 		Get(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(d.listDevices, "api_dev_user"))
 	r.With(checkUserMiddleware, checkDeviceMiddleware).
 		Post(`/{user:[\w+.-]+}/{devicename:[\w.-]+}.json`, srvsupport.WrapNamed(d.updateDevice, "api_dev_user_put"))
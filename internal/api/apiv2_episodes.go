@@ -47,7 +47,7 @@ func (er episodesResource) Routes() *chi.Mux {
 
 	r.With(checkUserMiddleware).
 		Post(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(er.uploadEpisodeActions, "api_episodes_post"))
-	r.With(checkUserMiddleware).
+	r.With(checkUserMiddleware, tokenDeviceParamMiddleware).
 		Get(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(er.getEpisodeActions, "api_episodes_get"))
 
 	return r
@@ -67,8 +67,17 @@ func (er episodesResource) uploadEpisodeActions(
 
 	actions := make([]model.Episode, 0, len(reqData))
 	changedurls := make([][]string, 0)
+	tdevice := tokenDevice(r)
 
 	for _, reqEpisode := range reqData {
+		if tdevice != "" && reqEpisode.Device != tdevice {
+			logger.Info().Interface("req", reqEpisode).
+				Msgf("EpisodeResource: episode device not allowed for token device=%s", tdevice)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+			return
+		}
+
 		if curls := reqEpisode.sanitize(); len(curls) > 0 {
 			changedurls = append(changedurls, curls...)
 		}
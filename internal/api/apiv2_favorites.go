@@ -24,18 +24,20 @@ import (
 // favoritesResource handle request to /api/2/favorites/<user>.json.
 type favoritesResource struct {
 	episodesSrv *service.EpisodesSrv
+	webroot     string
 }
 
 func newFavoritesResource(i do.Injector) (favoritesResource, error) {
 	return favoritesResource{
 		episodesSrv: do.MustInvoke[*service.EpisodesSrv](i),
+		webroot:     do.MustInvokeNamed[string](i, "server.webroot"),
 	}, nil
 }
 
 func (u favoritesResource) Routes() *chi.Mux {
 	r := chi.NewRouter()
 
-	r.With(checkUserMiddleware).
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
 		Get(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(u.getFavorites, "api_favorites"))
 
 	return r
@@ -59,6 +61,10 @@ func (u favoritesResource) getFavorites(
 	}
 
 	resfavs := common.Map(favorites, newFavoriteFromModel)
+	for i := range resfavs {
+		resfavs[i].MygpoLink = mygpoLink(u.webroot, resfavs[i].MygpoLink)
+	}
+
 	srvsupport.RenderJSON(w, r, resfavs)
 }
 
@@ -0,0 +1,236 @@
+package api
+
+// apiv2_lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+// podcastListsResource handle request to /api/2/lists resource.
+type podcastListsResource struct {
+	listsSrv *service.PodcastListsSrv
+	webroot  string
+}
+
+func newPodcastListsResource(i do.Injector) (podcastListsResource, error) {
+	return podcastListsResource{
+		listsSrv: do.MustInvoke[*service.PodcastListsSrv](i),
+		webroot:  do.MustInvokeNamed[string](i, "server.webroot"),
+	}, nil
+}
+
+func (p podcastListsResource) Routes() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
+		Post(`/{user:[\w+.-]+}/create.{format}`, srvsupport.WrapNamed(p.createList, "api_lists_create"))
+	// lists are public - can be read by any user
+	r.Get(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(p.getLists, "api_lists_user"))
+	r.Get(`/{user:[\w+.-]+}/list/{name:[\w-]+}.{format}`, srvsupport.WrapNamed(p.getList, "api_lists_list"))
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
+		Put(`/{user:[\w+.-]+}/list/{name:[\w-]+}.{format}`,
+			srvsupport.WrapNamed(p.updateList, "api_lists_list_put"))
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
+		Delete(`/{user:[\w+.-]+}/list/{name:[\w-]+}.{format}`,
+			srvsupport.WrapNamed(p.deleteList, "api_lists_list_delete"))
+
+	return r
+}
+
+func (p podcastListsResource) createList(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	format := chi.URLParam(r, "format")
+
+	podcasts, ok := parseListPodcasts(w, r, logger, format)
+	if !ok {
+		return
+	}
+
+	cmd := command.CreatePodcastListCmd{
+		UserName: user,
+		Title:    r.URL.Query().Get("title"),
+		Podcasts: podcasts,
+	}
+
+	res, err := p.listsSrv.CreatePodcastList(ctx, &cmd)
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("PodcastListsResource: create list user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	w.Header().Set("Location", p.webroot+"/api/2/lists/"+user+"/list/"+res.Name+"."+format)
+	w.WriteHeader(http.StatusSeeOther)
+}
+
+func (p podcastListsResource) getLists(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := chi.URLParam(r, "user")
+
+	lists, err := p.listsSrv.GetPodcastLists(ctx, &query.GetPodcastListsQuery{UserName: user})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("PodcastListsResource: get lists user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	res := make([]podcastList, len(lists))
+	for i, l := range lists {
+		res[i] = podcastList{
+			Title: l.Title,
+			Name:  l.Name,
+			Web:   p.webroot + "/web/lists/" + user + "/" + l.Name,
+		}
+	}
+
+	srvsupport.RenderJSON(w, r, res)
+}
+
+func (p podcastListsResource) getList(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := chi.URLParam(r, "user")
+	name := chi.URLParam(r, "name")
+
+	list, err := p.listsSrv.GetPodcastList(ctx, &query.GetPodcastListQuery{UserName: user, ListName: name})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("PodcastListsResource: get list user_name=%s list_name=%s error=%q", user, name, err)
+
+		return
+	}
+
+	writePodcasts(w, r, logger, list.Podcasts)
+}
+
+func (p podcastListsResource) updateList(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	name := chi.URLParam(r, "name")
+
+	podcasts, ok := parseListPodcasts(w, r, logger, chi.URLParam(r, "format"))
+	if !ok {
+		return
+	}
+
+	cmd := command.UpdatePodcastListCmd{
+		UserName: user,
+		ListName: name,
+		Title:    r.URL.Query().Get("title"),
+		Podcasts: podcasts,
+	}
+
+	if err := p.listsSrv.UpdatePodcastList(ctx, &cmd); err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("PodcastListsResource: update list user_name=%s list_name=%s error=%q", user, name, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p podcastListsResource) deleteList(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+	name := chi.URLParam(r, "name")
+
+	cmd := command.DeletePodcastListCmd{UserName: user, ListName: name}
+	if err := p.listsSrv.DeletePodcastList(ctx, &cmd); err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("PodcastListsResource: delete list user_name=%s list_name=%s error=%q", user, name, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//------------------------------------------------------------------------------
+
+// parseListPodcasts parse podcasts urls from request body in given format. On error write response and
+// return false.
+func parseListPodcasts(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+	format string,
+) ([]string, bool) {
+	var (
+		podcasts []string
+		err      error
+	)
+
+	switch format {
+	case "opml":
+		podcasts, err = parseOPML(r.Body)
+	case "json", "jsonp":
+		err = render.DecodeJSON(r.Body, &podcasts)
+	case "txt":
+		podcasts, err = parseTextSubs(r.Body)
+	default:
+		logger.Debug().Msgf("PodcastListsResource: unknown format=%q", format)
+		writeError(w, r, http.StatusNotFound)
+
+		return nil, false
+	}
+
+	if err != nil {
+		logger.Debug().Err(err).Msgf("PodcastListsResource: parse format=%q error=%q", format, err)
+		writeError(w, r, http.StatusBadRequest)
+
+		return nil, false
+	}
+
+	return podcasts, true
+}
+
+//------------------------------------------------------------------------------
+
+type podcastList struct {
+	Title string `json:"title"`
+	Name  string `json:"name"`
+	Web   string `json:"web"`
+}
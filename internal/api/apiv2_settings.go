@@ -36,9 +36,9 @@ func newSettingsResource(i do.Injector) (settingsResource, error) {
 func (u settingsResource) Routes() *chi.Mux {
 	r := chi.NewRouter()
 
-	r.With(checkUserMiddleware).
+	r.With(checkUserMiddleware, tokenDeviceParamMiddleware).
 		Get(`/{user:[\w+.-]+}/{scope:[a-z]+}.json`, srvsupport.WrapNamed(u.getSettings, "api_sett_user"))
-	r.With(checkUserMiddleware).
+	r.With(checkUserMiddleware, tokenDeviceParamMiddleware).
 		Post(`/{user:[\w+.-]+}/{scope:[a-z]+}.json`, srvsupport.WrapNamed(u.postSettings, "api_sett_user_post"))
 
 	return r
@@ -0,0 +1,118 @@
+package api
+
+// apiv2_sync.go
+// Copyright (C) 2025 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+// syncDevicesResource handle request to /api/2/sync-devices resource.
+type syncDevicesResource struct {
+	subsSrv *service.SubscriptionsSrv
+}
+
+func newSyncDevicesResource(i do.Injector) (syncDevicesResource, error) {
+	return syncDevicesResource{
+		subsSrv: do.MustInvoke[*service.SubscriptionsSrv](i),
+	}, nil
+}
+
+func (s syncDevicesResource) Routes() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
+		Get(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(s.getSyncStatus, "api_sync_devices"))
+	r.With(checkUserMiddleware, noDeviceTokenMiddleware).
+		Post(`/{user:[\w+.-]+}.json`, srvsupport.WrapNamed(s.updateSyncStatus, "api_sync_devices_post"))
+
+	return r
+}
+
+func (s syncDevicesResource) getSyncStatus(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+
+	status, err := s.subsSrv.GetSyncStatus(ctx, &query.GetSyncStatusQuery{UserName: user})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("SyncDevicesResource: get sync status user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	srvsupport.RenderJSON(w, r, newSyncStatusFromModel(&status))
+}
+
+func (s syncDevicesResource) updateSyncStatus(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+
+	var reqData struct {
+		Synchronize     [][]string `json:"synchronize"`
+		StopSynchronize []string   `json:"stop-synchronize"`
+	}
+
+	if err := render.DecodeJSON(r.Body, &reqData); err != nil {
+		logger.Debug().Err(err).
+			Msgf("SyncDevicesResource: error decoding json payload user_name=%s error=%q", user, err)
+		writeError(w, r, http.StatusBadRequest)
+
+		return
+	}
+
+	cmd := command.UpdateSyncDevicesCmd{
+		UserName:        user,
+		Synchronize:     reqData.Synchronize,
+		StopSynchronize: reqData.StopSynchronize,
+	}
+
+	status, err := s.subsSrv.UpdateSyncStatus(ctx, &cmd)
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("SyncDevicesResource: update sync status user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	srvsupport.RenderJSON(w, r, newSyncStatusFromModel(&status))
+}
+
+//------------------------------------------------------------------------------
+
+type syncStatus struct {
+	Synchronized    [][]string `json:"synchronized"`
+	NotSynchronized []string   `json:"not-synchronized"`
+}
+
+func newSyncStatusFromModel(s *model.SyncStatus) syncStatus {
+	return syncStatus{
+		Synchronized:    s.Synchronized,
+		NotSynchronized: s.NotSynchronized,
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+// apiv2_tags.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+// tagsResource handle request to /api/2/tags and /api/2/tag resources.
+type tagsResource struct {
+	podcastsSrv *service.PodcastsSrv
+}
+
+func newTagsResource(i do.Injector) (tagsResource, error) {
+	return tagsResource{
+		podcastsSrv: do.MustInvoke[*service.PodcastsSrv](i),
+	}, nil
+}
+
+func (t tagsResource) Routes() *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Get(`/tags/{number:[0-9]+}.json`, srvsupport.WrapNamed(t.tags, "api_tags"))
+	r.Get(`/tag/{tag:[\w-]+}/{number:[0-9]+}.json`, srvsupport.WrapNamed(t.tagPodcasts, "api_tag_podcasts"))
+
+	return r
+}
+
+func (t tagsResource) tags(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	number, _ := strconv.ParseUint(chi.URLParam(r, "number"), 10, 32)
+
+	tags, err := t.podcastsSrv.GetTags(ctx, &query.GetTagsQuery{Limit: uint(number)})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("TagsResource: get tags number=%d error=%q", number, err)
+
+		return
+	}
+
+	srvsupport.RenderJSON(w, r, common.Map(tags, newTagFromModel))
+}
+
+func (t tagsResource) tagPodcasts(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	tag := chi.URLParam(r, "tag")
+	number, _ := strconv.ParseUint(chi.URLParam(r, "number"), 10, 32)
+
+	podcasts, err := t.podcastsSrv.GetTagPodcasts(ctx, &query.GetTagPodcastsQuery{Tag: tag, Limit: uint(number)})
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("TagsResource: get tag podcasts tag=%q number=%d error=%q", tag, number, err)
+
+		return
+	}
+
+	srvsupport.RenderJSON(w, r, common.Map(podcasts, newPodcastFromModel))
+}
+
+//------------------------------------------------------------------------------
+
+type tag struct {
+	Title string `json:"title"`
+	Tag   string `json:"tag"`
+	Usage int    `json:"usage"`
+}
+
+func newTagFromModel(t *model.PodcastTag) tag {
+	return tag{
+		Title: t.Title,
+		Tag:   t.Tag,
+		Usage: t.Usage,
+	}
+}
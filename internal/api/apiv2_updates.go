@@ -7,6 +7,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"slices"
 	"time"
 
 	"gitlab.com/kabes/go-gpo/internal/aerr"
@@ -25,12 +26,14 @@ import (
 type updatesResource struct {
 	subsSrv     *service.SubscriptionsSrv
 	episodesSrv *service.EpisodesSrv
+	webroot     string
 }
 
 func newUpdatesResource(i do.Injector) (updatesResource, error) {
 	return updatesResource{
 		subsSrv:     do.MustInvoke[*service.SubscriptionsSrv](i),
 		episodesSrv: do.MustInvoke[*service.EpisodesSrv](i),
+		webroot:     do.MustInvokeNamed[string](i, "server.webroot"),
 	}, nil
 }
 
@@ -75,11 +78,23 @@ func (u updatesResource) getUpdates(
 		return
 	}
 
+	// podcasts visible for device; synchronized devices see only podcasts of its group.
+	pq := query.GetSubscriptionsQuery{UserName: user, DeviceName: devicename}
+
+	podcasts, err := u.subsSrv.GetDevicePodcasts(ctx, &pq)
+	if err != nil {
+		checkAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("UpdatesResource: get device podcasts user_name=%s devicename=%s error=%q", user, devicename, err)
+
+		return
+	}
+
 	query := query.GetEpisodeUpdatesQuery{
 		UserName:       user,
 		Since:          since,
 		IncludeActions: includeActions,
-		DeviceName:     "", // device is ignored; all devices have the same subscriptions
+		DeviceName:     "", // episodes actions are shared by all devices
 	}
 
 	updates, err := u.episodesSrv.GetUpdates(ctx, &query)
@@ -91,6 +106,17 @@ func (u updatesResource) getUpdates(
 		return
 	}
 
+	updates = slices.DeleteFunc(updates, func(eu model.EpisodeUpdate) bool {
+		_, ok := podcasts.FindPodcastByURL(eu.PodcastURL)
+
+		return !ok
+	})
+
+	resupdates := common.Map(updates, newEpisodeUpdateFromModel)
+	for i := range resupdates {
+		resupdates[i].MygpoLink = mygpoLink(u.webroot, resupdates[i].MygpoLink)
+	}
+
 	result := struct {
 		Add        []podcast       `json:"add"`
 		Remove     []string        `json:"remove"`
@@ -99,7 +125,7 @@ func (u updatesResource) getUpdates(
 	}{
 		Add:        common.Map(state.Added, newPodcastFromModel),
 		Remove:     state.RemovedURLs(),
-		Updates:    common.Map(updates, newEpisodeUpdateFromModel),
+		Updates:    resupdates,
 		Timestamps: now.UTC().Unix(),
 	}
 
@@ -115,6 +141,7 @@ type episodeUpdate struct {
 	URL          string    `json:"url"`
 	PodcastTitle string    `json:"podcast_title"`
 	PodcastURL   string    `json:"podcast_url"`
+	Description  string    `json:"description"`
 	Website      string    `json:"website"`
 	MygpoLink    string    `json:"mygpo_link"`
 	Status       string    `json:"status"`
@@ -126,6 +153,7 @@ func newEpisodeUpdateFromModel(eup *model.EpisodeUpdate) episodeUpdate {
 		URL:          eup.URL,
 		PodcastTitle: eup.PodcastTitle,
 		PodcastURL:   eup.PodcastURL,
+		Description:  eup.Description,
 		Website:      eup.Website,
 		MygpoLink:    eup.MygpoLink,
 		Released:     eup.Released,
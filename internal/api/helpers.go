@@ -39,9 +39,12 @@ func checkAndWriteError(w http.ResponseWriter, r *http.Request, err error) {
 	status := http.StatusInternalServerError
 
 	switch {
-	case errors.Is(err, common.ErrUnknownDevice):
+	case errors.Is(err, common.ErrUnknownDevice), errors.Is(err, common.ErrUnknownPodcastList):
 		status = http.StatusNotFound
 
+	case errors.Is(err, common.ErrPodcastListExists):
+		status = http.StatusConflict
+
 	case aerr.HasTag(err, aerr.InternalError):
 		status = http.StatusInternalServerError
 
@@ -55,6 +58,15 @@ func checkAndWriteError(w http.ResponseWriter, r *http.Request, err error) {
 	writeError(w, r, status)
 }
 
+// mygpoLink create link to page in web interface from path relative to webroot.
+func mygpoLink(webroot, path string) string {
+	if path == "" {
+		return ""
+	}
+
+	return webroot + path
+}
+
 func writeError(w http.ResponseWriter, r *http.Request, status int) {
 	msg := http.StatusText(status)
 	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
@@ -58,6 +58,15 @@ func checkDeviceMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		// app token may be restricted to one device
+		if tdevice := tokenDevice(req); tdevice != "" && tdevice != devicename {
+			hlog.FromRequest(req).Warn().
+				Msgf("api.CheckDevice: device_name=%s not allowed for token device=%s", devicename, tdevice)
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
 		ctx := common.ContextWithDevice(req.Context(), devicename)
 		logger := hlog.FromRequest(req).With().Str(common.LogKeyDeviceID, devicename).Logger()
 		ctx = logger.WithContext(ctx)
@@ -65,3 +74,58 @@ func checkDeviceMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, req.WithContext(ctx))
 	})
 }
+
+// sessionUserMiddleware put authenticated user into context. Used by resources without user in url.
+func sessionUserMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		user := srvsupport.SessionUser(session.GetSession(req))
+		if user == "" {
+			hlog.FromRequest(req).Warn().Msg("api.SessionUser: missing authentication")
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		ctx := common.ContextWithUser(req.Context(), user)
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// noDeviceTokenMiddleware reject requests authenticated by app token restricted to device. Used by
+// resources that not support devices.
+func noDeviceTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if tdevice := tokenDevice(req); tdevice != "" {
+			hlog.FromRequest(req).Warn().Msgf("api.NoDeviceToken: token restricted to device=%s", tdevice)
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// tokenDeviceParamMiddleware reject requests with `device` query parameter other than device that app
+// token used to authenticate request is restricted to.
+func tokenDeviceParamMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		devicename := req.URL.Query().Get("device")
+		if tdevice := tokenDevice(req); tdevice != "" && devicename != "" && devicename != tdevice {
+			hlog.FromRequest(req).Warn().
+				Msgf("api.TokenDeviceParam: device_name=%s not allowed for token device=%s", devicename, tdevice)
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// tokenDevice return name of device that app token used to authenticate request is restricted to.
+func tokenDevice(req *http.Request) string {
+	_, devicename, _ := srvsupport.SessionAppToken(session.GetSession(req))
+
+	return devicename
+}
@@ -0,0 +1,102 @@
+package api
+
+//
+// middlewares_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func TestDeviceTokenRestrictions(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+	do.ProvideNamedValue(i, "server.webroot", "")
+
+	simpleRes, err := newSimpleResource(i)
+	assert.NoErr(t, err)
+	settingsRes, err := newSettingsResource(i)
+	assert.NoErr(t, err)
+	episodesRes, err := newEpisodesResource(i)
+	assert.NoErr(t, err)
+	syncRes, err := newSyncDevicesResource(i)
+	assert.NoErr(t, err)
+	listsRes, err := newPodcastListsResource(i)
+	assert.NoErr(t, err)
+	deviceRes, err := newDeviceResource(i)
+	assert.NoErr(t, err)
+	favoritesRes, err := newFavoritesResource(i)
+	assert.NoErr(t, err)
+
+	router := chi.NewRouter()
+	router.Mount("/subscriptions", simpleRes.Routes())
+	router.Route("/api/2", func(r chi.Router) {
+		r.Mount("/settings", settingsRes.Routes())
+		r.Mount("/episodes", episodesRes.Routes())
+		r.Mount("/sync-devices", syncRes.Routes())
+		r.Mount("/lists", listsRes.Routes())
+		r.Mount("/devices", deviceRes.Routes())
+		r.Mount("/favorites", favoritesRes.Routes())
+	})
+
+	token := &model.AppToken{Name: "phone", DeviceName: "phone"}
+	url := newTokenTestServer(t, "user1", token, router).URL
+
+	forbidden := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/api/2/sync-devices/user1.json", ""},
+		{http.MethodPost, "/api/2/sync-devices/user1.json", `{"synchronize":[["phone","tablet"]]}`},
+		{http.MethodGet, "/api/2/settings/user1/device.json?device=tablet", ""},
+		{http.MethodPost, "/api/2/settings/user1/device.json?device=tablet", `{"set":{"k":"v"}}`},
+		{http.MethodGet, "/subscriptions/user1.json", ""},
+		{http.MethodGet, "/subscriptions/user1/tablet.json", ""},
+		{http.MethodGet, "/api/2/episodes/user1.json?device=tablet", ""},
+		{http.MethodPost, "/api/2/episodes/user1.json", `[{"podcast":"http://example.com/p1",` +
+			`"episode":"http://example.com/e1","action":"play","device":"tablet"}]`},
+		{http.MethodPost, "/api/2/lists/user1/create.json?title=list1", `["http://example.com/p1"]`},
+		{http.MethodPut, "/api/2/lists/user1/list/list1.json", `["http://example.com/p1"]`},
+		{http.MethodDelete, "/api/2/lists/user1/list/list1.json", ""},
+		{http.MethodGet, "/api/2/devices/user1.json", ""},
+		{http.MethodGet, "/api/2/favorites/user1.json", ""},
+	}
+
+	for _, c := range forbidden {
+		status, _ := doRequest(t, c.method, url+c.path, c.body)
+		if status != http.StatusForbidden {
+			t.Errorf("%s %s: status=%d, expected: %d", c.method, c.path, status, http.StatusForbidden)
+		}
+	}
+
+	// resources of token device are available
+	allowed := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPut, "/subscriptions/user1/phone.json", `["http://example.com/p1"]`},
+		{http.MethodGet, "/subscriptions/user1/phone.json", ""},
+		{http.MethodPost, "/api/2/settings/user1/device.json?device=phone", `{"set":{"k":"v"}}`},
+		{http.MethodGet, "/api/2/settings/user1/device.json?device=phone", ""},
+		{http.MethodGet, "/api/2/episodes/user1.json?device=phone", ""},
+		{http.MethodGet, "/api/2/lists/user1.json", ""},
+		{http.MethodPost, "/api/2/devices/user1/phone.json", `{"caption":"my phone","type":"mobile"}`},
+	}
+
+	for _, c := range allowed {
+		status, _ := doRequest(t, c.method, url+c.path, c.body)
+		if status != http.StatusOK {
+			t.Errorf("%s %s: status=%d, expected: %d", c.method, c.path, status, http.StatusOK)
+		}
+	}
+}
@@ -20,4 +20,10 @@ var Package = do.Package(
 	do.Lazy(newSubscriptionsResource),
 	do.Lazy(newUpdatesResource),
 	do.Lazy(newFavoritesResource),
+	do.Lazy(newSyncDevicesResource),
+	do.Lazy(newDirectoryResource),
+	do.Lazy(newPodcastListsResource),
+	do.Lazy(newDataResource),
+	do.Lazy(newTagsResource),
+	do.Lazy(newNextcloudResource),
 )
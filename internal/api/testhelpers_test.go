@@ -0,0 +1,132 @@
+package api
+
+//
+// testhelpers_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitea.com/go-chi/session"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/infra"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func prepareTests(t *testing.T) (context.Context, *do.RootScope) {
+	t.Helper()
+
+	ctx := log.Logger.WithContext(context.Background())
+	i := do.New(service.Package, db.Package, infra.Package)
+	do.ProvideValue(i, config.NewDBConfig("sqlite3", ":memory:"))
+
+	rdb := do.MustInvoke[repository.Database](i)
+	if _, err := rdb.Open(ctx); err != nil {
+		t.Fatalf("connect to db error: %#+v", err)
+	}
+
+	if err := rdb.Migrate(ctx); err != nil {
+		t.Fatalf("prepare db error: %#+v", err)
+	}
+
+	if err := rdb.Clear(ctx); err != nil {
+		t.Fatalf("clear db error: %#+v", err)
+	}
+
+	return ctx, i
+}
+
+func prepareTestUser(ctx context.Context, t *testing.T, i do.Injector, name string) {
+	t.Helper()
+
+	usersSrv := do.MustInvoke[*service.UsersSrv](i)
+	newuser := command.NewUserCmd{
+		UserName: name,
+		Password: name + "123",
+		Email:    name + "@example.com",
+		Name:     "test user " + name,
+	}
+
+	if _, err := usersSrv.AddUser(ctx, &newuser); err != nil {
+		t.Fatalf("create test user failed: %#+v", err)
+	}
+}
+
+// newTestServer create server that serve `handler` for authenticated `username`.
+func newTestServer(t *testing.T, username string, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	return newTokenTestServer(t, username, nil, handler)
+}
+
+// newTokenTestServer create server that serve `handler` for `username` authenticated by app `token`.
+func newTokenTestServer(t *testing.T, username string, token *model.AppToken, handler http.Handler,
+) *httptest.Server {
+	t.Helper()
+
+	sess, err := session.Sessioner(session.Options{Provider: "memory"})
+	if err != nil {
+		t.Fatalf("start session manager failed: %#+v", err)
+	}
+
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if username != "" {
+				_ = session.GetSession(r).Set("user", username)
+			}
+
+			if token != nil {
+				srvsupport.SetSessionAppToken(session.GetSession(r), token)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	srv := httptest.NewServer(hlog.NewHandler(log.Logger)(sess(auth(handler))))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// doRequest send request to test server and return response status and body.
+func doRequest(t *testing.T, method, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("create request failed: %#+v", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+	defer resp.Body.Close()
+
+	respbody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response failed: %#+v", err)
+	}
+
+	return resp.StatusCode, string(respbody)
+}
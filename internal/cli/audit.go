@@ -0,0 +1,122 @@
+package cli
+
+//
+// audit.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/do/v2"
+	"github.com/urfave/cli/v3"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func auditSubCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "browse and prune security audit log",
+		Commands: []*cli.Command{
+			newListAuditCmd(),
+			newPruneAuditCmd(),
+		},
+	}
+}
+
+//---------------------------------------------------------------------
+
+func newListAuditCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "list audit log events from newest",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Aliases: []string{"u"}, Usage: "show only events for user"},
+			&cli.StringFlag{
+				Name:    "action",
+				Aliases: []string{"a"},
+				Usage:   "show only given action (" + strings.Join(model.AuditActions, ", ") + ")",
+			},
+			&cli.DurationFlag{Name: "since", Aliases: []string{"s"}, Usage: "show only events from given time"},
+			&cli.UintFlag{
+				Name:    "limit",
+				Aliases: []string{"l"},
+				Usage:   "max number of events; 0 - no limit",
+				Value:   100, //nolint:mnd
+			},
+		},
+		Action: wrap(listAuditCmd),
+	}
+}
+
+//nolint:forbidigo
+func listAuditCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	auditsrv := do.MustInvoke[*service.AuditSrv](injector)
+
+	q := query.GetAuditEventsQuery{
+		UserName: clicmd.String("username"),
+		Action:   clicmd.String("action"),
+		Limit:    clicmd.Uint("limit"),
+	}
+
+	if since := clicmd.Duration("since"); since > 0 {
+		q.Since = time.Now().UTC().Add(-since)
+	}
+
+	events, err := auditsrv.ListEvents(ctx, &q)
+	if err != nil {
+		return fmt.Errorf("get audit events error: %w", err)
+	}
+
+	fmt.Printf("%-20s | %-20s | %-16s | %-40s | %-16s | %s\n", "Time", "User", "Action", "Details", "IP",
+		"User agent")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, e := range events {
+		fmt.Printf("%-20s | %-20s | %-16s | %-40s | %-16s | %s\n", e.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			e.UserName, e.Action, e.Details, e.IP, e.UserAgent)
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newPruneAuditCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "prune",
+		Usage: "delete old audit log events",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:    "older-than",
+				Aliases: []string{"o"},
+				Usage:   "delete events older than given time",
+				Value:   90 * 24 * time.Hour, //nolint:mnd
+			},
+		},
+		Action: wrap(pruneAuditCmd),
+	}
+}
+
+func pruneAuditCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	auditsrv := do.MustInvoke[*service.AuditSrv](injector)
+
+	deleted, err := auditsrv.PruneEvents(ctx, clicmd.Duration("older-than"))
+	if err != nil {
+		return fmt.Errorf("prune audit log error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Deleted %d events\n", deleted)
+
+	return nil
+}
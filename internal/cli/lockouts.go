@@ -0,0 +1,108 @@
+package cli
+
+//
+// lockouts.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/do/v2"
+	"github.com/urfave/cli/v3"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func newLockoutsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "lockout",
+		Usage: "manage failed logins and lockouts",
+		Commands: []*cli.Command{
+			newListLockoutsCmd(),
+			newClearLockoutsCmd(),
+		},
+	}
+}
+
+//---------------------------------------------------------------------
+
+func newListLockoutsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "list failed logins per user and ip address",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "locked-only", Aliases: []string{"l"}, Usage: "show only locked"},
+		},
+		Action: wrap(listLockoutsCmd),
+	}
+}
+
+//nolint:forbidigo
+func listLockoutsCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	guardsrv := do.MustInvoke[*service.LoginGuardSrv](injector)
+
+	failures, err := guardsrv.ListLoginFailures(ctx)
+	if err != nil {
+		return fmt.Errorf("get login failures error: %w", err)
+	}
+
+	lockedOnly := clicmd.Bool("locked-only")
+	now := time.Now()
+
+	fmt.Printf("%-4s | %-40s | %-8s | %-20s | %s\n", "Kind", "User / IP", "Failures", "Last failure", "Locked until")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, f := range failures {
+		locked := ""
+		if f.IsLocked(now) {
+			locked = f.LockedUntil.Local().Format("2006-01-02 15:04:05")
+		} else if lockedOnly {
+			continue
+		}
+
+		fmt.Printf("%-4s | %-40s | %-8d | %-20s | %s\n", f.Kind, f.Subject, f.Failures,
+			f.LastFailureAt.Local().Format("2006-01-02 15:04:05"), locked)
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newClearLockoutsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "clear",
+		Usage: "clear failed logins and unlock user or ip address",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "kind", Aliases: []string{"k"}, Usage: "user or ip; empty for both"},
+			&cli.StringFlag{Name: "subject", Aliases: []string{"s"}, Usage: "user name or ip address"},
+			&cli.BoolFlag{Name: "all", Usage: "clear all failed logins"},
+		},
+		Action: wrap(clearLockoutsCmd),
+	}
+}
+
+func clearLockoutsCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	guardsrv := do.MustInvoke[*service.LoginGuardSrv](injector)
+	kind, subject := clicmd.String("kind"), clicmd.String("subject")
+
+	if subject == "" && !clicmd.Bool("all") {
+		return aerr.ErrValidation.WithUserMsg("subject or --all flag is required")
+	}
+
+	if err := guardsrv.ClearLoginFailures(ctx, kind, subject); err != nil {
+		return fmt.Errorf("clear login failures error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Println("Failed logins cleared")
+
+	return nil
+}
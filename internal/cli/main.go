@@ -72,6 +72,7 @@ func Main() {
 			usersSubCmd(),
 			devicesSubCmd(),
 			podcastSubCmd(),
+			auditSubCmd(),
 		},
 	}
 
@@ -98,6 +99,12 @@ func usersSubCmd() *cli.Command {
 			newListUsersCmd(),
 			newLockUserCmd(),
 			newChangeUserPasswordCmd(),
+			newSetAdminCmd(),
+			newResetTOTPCmd(),
+			newLockoutsCmd(),
+			newInviteCodesCmd(),
+			newRegistrationsCmd(),
+			newAppTokensCmd(),
 		},
 	}
 }
@@ -133,6 +140,8 @@ func podcastSubCmd() *cli.Command {
 		Usage: "manage podcasts",
 		Commands: []*cli.Command{
 			newDownloadPodcastsInfoCmd(),
+			newPodcastHealthCmd(),
+			newPodcastScheduleCmd(),
 		},
 	}
 }
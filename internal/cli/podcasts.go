@@ -10,6 +10,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/samber/do/v2"
@@ -36,6 +37,10 @@ func newDownloadPodcastsInfoCmd() *cli.Command {
 				Name:  "load-only-missing",
 				Usage: "Download podcast info only for podcasts without title (do not update).",
 			},
+			&cli.BoolFlag{
+				Name:  "scheduled",
+				Usage: "Download only feeds which time of next check passed.",
+			},
 		},
 		Action: wrap(downloadPodcastsInfoCmd),
 	}
@@ -51,8 +56,9 @@ func downloadPodcastsInfoCmd(ctx context.Context, clicmd *cli.Command, injector
 
 	loadepisodes := clicmd.Bool("load-episodes")
 	missingonly := clicmd.Bool("load-only-missing")
+	scheduled := clicmd.Bool("scheduled")
 
-	if err := podcastSrv.DownloadPodcastsInfo(ctx, maxAge, loadepisodes, missingonly); err != nil {
+	if err := podcastSrv.DownloadPodcastsInfo(ctx, maxAge, loadepisodes, missingonly, scheduled); err != nil {
 		return fmt.Errorf("download podcast info failed: %w", err)
 	}
 
@@ -61,3 +67,132 @@ func downloadPodcastsInfoCmd(ctx context.Context, clicmd *cli.Command, injector
 
 	return nil
 }
+
+//---------------------------------------------------------------------
+
+func newPodcastHealthCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "health",
+		Usage: "show state of fetching podcasts feeds",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "failing-only", Aliases: []string{"f"}, Usage: "show only failing and dead feeds"},
+			&cli.StringFlag{
+				Name:  "reset",
+				Usage: "clear failures of feed with given url; dead feed will be fetched again",
+			},
+		},
+		Action: wrap(podcastHealthCmd),
+	}
+}
+
+//nolint:forbidigo
+func podcastHealthCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	podcastSrv := do.MustInvoke[*service.PodcastsSrv](injector)
+
+	if url := clicmd.String("reset"); url != "" {
+		if err := podcastSrv.ResetFeedState(ctx, url); err != nil {
+			return fmt.Errorf("reset feed state error: %w", err)
+		}
+
+		fmt.Println("Feed state cleared")
+
+		return nil
+	}
+
+	states, err := podcastSrv.GetFeedsHealth(ctx, clicmd.Bool("failing-only"))
+	if err != nil {
+		return fmt.Errorf("get feeds state error: %w", err)
+	}
+
+	fmt.Printf("%-7s | %-60s | %-6s | %-8s | %-19s | %-19s | %s\n", "Health", "URL", "Status", "Failures",
+		"Last fetch", "Next retry", "Last error")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, s := range states {
+		fmt.Printf("%-7s | %-60s | %-6d | %-8d | %-19s | %-19s | %s\n", s.Health(), s.URL, s.LastStatus,
+			s.Failures, formatTime(s.LastFetchAt), formatTime(s.NextRetryAt), s.LastError)
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newPodcastScheduleCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "schedule",
+		Usage: "show or change schedule of checking podcasts feeds",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "url",
+				Usage: "url of feed to change check interval",
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "check interval of feed given by --url; 0 restore computed interval",
+			},
+		},
+		Action: wrap(podcastScheduleCmd),
+	}
+}
+
+//nolint:forbidigo
+func podcastScheduleCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	podcastSrv := do.MustInvoke[*service.PodcastsSrv](injector)
+
+	if url := clicmd.String("url"); url != "" {
+		if err := podcastSrv.SetFeedCheckInterval(ctx, url, clicmd.Duration("interval")); err != nil {
+			return fmt.Errorf("set feed check interval error: %w", err)
+		}
+
+		fmt.Println("Feed check interval changed")
+
+		return nil
+	}
+
+	states, err := podcastSrv.GetFeedsSchedule(ctx)
+	if err != nil {
+		return fmt.Errorf("get feeds schedule error: %w", err)
+	}
+
+	fmt.Printf("%-60s | %-8s | %-13s | %-19s | %-19s\n", "URL", "Interval", "Source", "Last fetch", "Next check")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, s := range states {
+		interval, source := s.Interval()
+		fmt.Printf("%-60s | %-8s | %-13s | %-19s | %-19s\n", s.URL, formatInterval(interval), source,
+			formatTime(s.LastFetchAt), formatTime(s.NextCheckAt))
+	}
+
+	return nil
+}
+
+// formatInterval return duration without trailing zero units (1h instead of 1h0m0s).
+func formatInterval(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+
+	return s
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Local().Format("2006-01-02 15:04:05")
+}
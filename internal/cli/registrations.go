@@ -0,0 +1,228 @@
+package cli
+
+//
+// registrations.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samber/do/v2"
+	"github.com/urfave/cli/v3"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func newInviteCodesCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "invite",
+		Usage: "manage invite codes for registration",
+		Commands: []*cli.Command{
+			newAddInviteCodeCmd(),
+			newListInviteCodesCmd(),
+			newDeleteInviteCodeCmd(),
+		},
+	}
+}
+
+func newRegistrationsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "registration",
+		Usage: "manage registrations waiting for approval",
+		Commands: []*cli.Command{
+			newListRegistrationsCmd(),
+			newApproveRegistrationCmd(),
+			newRejectRegistrationCmd(),
+		},
+	}
+}
+
+//---------------------------------------------------------------------
+
+func newAddInviteCodeCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "add",
+		Usage: "create new invite code",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:    "valid-for",
+				Aliases: []string{"v"},
+				Usage:   "how long code can be used; 0 - no limit",
+				Value:   7 * 24 * time.Hour, //nolint:mnd
+			},
+		},
+		Action: wrap(addInviteCodeCmd),
+	}
+}
+
+func addInviteCodeCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	regsrv := do.MustInvoke[*service.RegistrationSrv](injector)
+
+	res, err := regsrv.CreateInviteCode(ctx, &command.CreateInviteCodeCmd{
+		CreatedBy: "cli",
+		ValidFor:  clicmd.Duration("valid-for"),
+	})
+	if err != nil {
+		return fmt.Errorf("create invite code error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Invite code: %s\n", res.Code)
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newListInviteCodesCmd() *cli.Command {
+	return &cli.Command{
+		Name:   "list",
+		Usage:  "list invite codes",
+		Action: wrap(listInviteCodesCmd),
+	}
+}
+
+//nolint:forbidigo
+func listInviteCodesCmd(ctx context.Context, _ *cli.Command, injector do.Injector) error {
+	regsrv := do.MustInvoke[*service.RegistrationSrv](injector)
+
+	codes, err := regsrv.ListInviteCodes(ctx)
+	if err != nil {
+		return fmt.Errorf("get invite codes error: %w", err)
+	}
+
+	fmt.Printf("%-16s | %-20s | %-20s | %-20s | %s\n", "Code", "Created by", "Created", "Expires", "Used by")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, c := range codes {
+		expires := ""
+		if !c.ExpiresAt.IsZero() {
+			expires = c.ExpiresAt.Local().Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Printf("%-16s | %-20s | %-20s | %-20s | %s\n", c.Code, c.CreatedBy,
+			c.CreatedAt.Local().Format("2006-01-02 15:04:05"), expires, c.UsedBy)
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newDeleteInviteCodeCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "delete",
+		Usage: "delete invite code",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "code", Required: true, Aliases: []string{"c"}},
+		},
+		Action: wrap(deleteInviteCodeCmd),
+	}
+}
+
+func deleteInviteCodeCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	regsrv := do.MustInvoke[*service.RegistrationSrv](injector)
+	code := clicmd.String("code")
+
+	if err := regsrv.DeleteInviteCode(ctx, code); err != nil {
+		return fmt.Errorf("delete invite code error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Invite code %s deleted\n", code)
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newListRegistrationsCmd() *cli.Command {
+	return &cli.Command{
+		Name:   "list",
+		Usage:  "list registrations waiting for approval",
+		Action: wrap(listRegistrationsCmd),
+	}
+}
+
+//nolint:forbidigo
+func listRegistrationsCmd(ctx context.Context, _ *cli.Command, injector do.Injector) error {
+	regsrv := do.MustInvoke[*service.RegistrationSrv](injector)
+
+	registrations, err := regsrv.ListRegistrations(ctx)
+	if err != nil {
+		return fmt.Errorf("get registrations error: %w", err)
+	}
+
+	fmt.Printf("%-20s | %-30s | %-30s | %s\n", "User name", "Name", "Email", "Registered")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, r := range registrations {
+		fmt.Printf("%-20s | %-30s | %-30s | %s\n", r.User.UserName, r.User.Name, r.User.Email,
+			r.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newApproveRegistrationCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "approve",
+		Usage: "approve registration and unlock account",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+		},
+		Action: wrap(approveRegistrationCmd),
+	}
+}
+
+func approveRegistrationCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	regsrv := do.MustInvoke[*service.RegistrationSrv](injector)
+	username := clicmd.String("username")
+
+	if err := regsrv.ApproveRegistration(ctx, username); err != nil {
+		return fmt.Errorf("approve registration error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Registration of %s approved\n", username)
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newRejectRegistrationCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "reject",
+		Usage: "reject registration and delete account",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+		},
+		Action: wrap(rejectRegistrationCmd),
+	}
+}
+
+func rejectRegistrationCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	regsrv := do.MustInvoke[*service.RegistrationSrv](injector)
+	username := clicmd.String("username")
+
+	if err := regsrv.RejectRegistration(ctx, username); err != nil {
+		return fmt.Errorf("reject registration error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Registration of %s rejected\n", username)
+
+	return nil
+}
@@ -33,6 +33,9 @@ import (
 func newStartServerCmd() *cli.Command { //nolint:funlen
 	const (
 		proxyCategory      = "Proxy settings"
+		oidcCategory       = "OpenID Connect"
+		ldapCategory       = "LDAP"
+		smtpCategory       = "Email"
 		workersCategory    = "Background jobs"
 		managementCategory = "Management"
 		securityCategory   = "Security"
@@ -92,7 +95,7 @@ func newStartServerCmd() *cli.Command { //nolint:funlen
 			},
 			&cli.DurationFlag{
 				Name:     "podcast-load-interval",
-				Usage:    "Enable background worker that download podcast information in given intervals.",
+				Usage:    "Enable background worker that check podcasts feeds due by its schedule in given intervals.",
 				Category: workersCategory,
 				Sources:  cli.EnvVars("GOGPO_SERVER_PODCAST_LOAD_INTERVAL"),
 				Value:    0,
@@ -143,11 +146,26 @@ func newStartServerCmd() *cli.Command { //nolint:funlen
 			&cli.StringFlag{
 				Name:     "auth-method",
 				Value:    "basic",
-				Usage:    "User authentication method (basic, proxy).",
+				Usage:    "User authentication method (basic, proxy, oidc, ldap).",
 				Category: securityCategory,
 				Sources:  cli.EnvVars("GOGPO_AUTH_METHOD"),
 				Config:   cli.StringConfig{TrimSpace: true},
 			},
+			&cli.StringFlag{
+				Name:     "registration",
+				Value:    "disabled",
+				Usage:    "Users self-registration mode (disabled, invite, approval); only for basic auth-method.",
+				Category: securityCategory,
+				Sources:  cli.EnvVars("GOGPO_REGISTRATION"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.DurationFlag{
+				Name:     "audit-retention",
+				Usage:    "Delete audit log events older than given time during daily maintenance; 0 - keep all.",
+				Category: securityCategory,
+				Sources:  cli.EnvVars("GOGPO_AUDIT_RETENTION"),
+				Value:    0,
+			},
 			&cli.StringFlag{
 				Name:     "auth-proxy-user-header",
 				Value:    "X-PROXY-USER",
@@ -165,6 +183,162 @@ func newStartServerCmd() *cli.Command { //nolint:funlen
 				Sources:  cli.EnvVars("GOGPO_PROXY_LIST"),
 				Config:   cli.StringConfig{TrimSpace: true},
 			},
+			&cli.StringFlag{
+				Name:     "oidc-issuer",
+				Usage:    "OpenID Connect provider (issuer) url; required for oidc auth-method.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_ISSUER"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "oidc-client-id",
+				Usage:    "OpenID Connect client id; required for oidc auth-method.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_CLIENT_ID"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "oidc-client-secret",
+				Usage:    "OpenID Connect client secret.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_CLIENT_SECRET"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "oidc-redirect-url",
+				Usage:    "External url of go-gpo oidc callback (https://<host>/<web-root>/oidc/callback); required.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_REDIRECT_URL"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "oidc-username-claim",
+				Value:    "preferred_username",
+				Usage:    "ID token claim used as user name.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_USERNAME_CLAIM"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "oidc-scopes",
+				Value:    "profile email",
+				Usage:    "Additional scopes requested from provider separated by space or ','.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_SCOPES"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.BoolFlag{
+				Name:     "oidc-auto-provision",
+				Usage:    "Create local account for users authenticated by provider on first login.",
+				Category: oidcCategory,
+				Sources:  cli.EnvVars("GOGPO_OIDC_AUTO_PROVISION"),
+			},
+			&cli.StringFlag{
+				Name:     "ldap-url",
+				Usage:    "LDAP server url (ldap://host:389 or ldaps://host:636); required for ldap auth-method.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_URL"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.BoolFlag{
+				Name:     "ldap-start-tls",
+				Usage:    "Use StartTLS for ldap connection.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_START_TLS"),
+			},
+			&cli.StringFlag{
+				Name:     "ldap-user-dn",
+				Usage:    "User dn template, i.e. 'uid={username},ou=people,dc=example,dc=com'; required.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_USER_DN"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "ldap-group-base-dn",
+				Usage:    "Base dn for searching user groups.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_GROUP_BASE_DN"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "ldap-group-filter",
+				Value:    "(|(member={userdn})(uniqueMember={userdn}))",
+				Usage:    "Filter for searching user groups.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_GROUP_FILTER"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "ldap-access-group",
+				Usage:    "Dn of group which members are allowed to login; empty allow all users.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_ACCESS_GROUP"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "ldap-admin-group",
+				Usage:    "Dn of group which members are administrators.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_ADMIN_GROUP"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "ldap-email-attr",
+				Value:    "mail",
+				Usage:    "User attribute with email.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_EMAIL_ATTR"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "ldap-name-attr",
+				Value:    "cn",
+				Usage:    "User attribute with name.",
+				Category: ldapCategory,
+				Sources:  cli.EnvVars("GOGPO_LDAP_NAME_ATTR"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "public-url",
+				Usage:    "External url of go-gpo web root (https://<host>/<web-root>); required for sending emails.",
+				Category: smtpCategory,
+				Sources:  cli.EnvVars("GOGPO_PUBLIC_URL"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "smtp-address",
+				Usage:    "SMTP server address (host:port); enable password reset by email.",
+				Category: smtpCategory,
+				Sources:  cli.EnvVars("GOGPO_SMTP_ADDRESS"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "smtp-from",
+				Usage:    "Sender email address.",
+				Category: smtpCategory,
+				Sources:  cli.EnvVars("GOGPO_SMTP_FROM"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "smtp-username",
+				Usage:    "SMTP user name; empty disable authentication.",
+				Category: smtpCategory,
+				Sources:  cli.EnvVars("GOGPO_SMTP_USERNAME"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.StringFlag{
+				Name:     "smtp-password",
+				Usage:    "SMTP password.",
+				Category: smtpCategory,
+				Sources:  cli.EnvVars("GOGPO_SMTP_PASSWORD"),
+				Config:   cli.StringConfig{TrimSpace: true},
+			},
+			&cli.BoolFlag{
+				Name:     "smtp-tls",
+				Usage:    "Use implicit TLS (smtps); otherwise STARTTLS is used when supported by server.",
+				Category: smtpCategory,
+				Sources:  cli.EnvVars("GOGPO_SMTP_TLS"),
+			},
 		},
 		Action: wrap(startServerCmd),
 	}
@@ -198,6 +372,35 @@ func startServerCmd(ctx context.Context, clicmd *cli.Command, rootInjector do.In
 		AuthMethod:      clicmd.String("auth-method"),
 		ProxyUserHeader: clicmd.String("auth-proxy-user-header"),
 		ProxyAccessList: clicmd.String("proxy-list"),
+		OIDC: config.OIDCConf{
+			IssuerURL:     clicmd.String("oidc-issuer"),
+			ClientID:      clicmd.String("oidc-client-id"),
+			ClientSecret:  clicmd.String("oidc-client-secret"),
+			RedirectURL:   clicmd.String("oidc-redirect-url"),
+			UsernameClaim: clicmd.String("oidc-username-claim"),
+			Scopes:        clicmd.String("oidc-scopes"),
+			AutoProvision: clicmd.Bool("oidc-auto-provision"),
+		},
+		LDAP: config.LDAPConf{
+			URL:         clicmd.String("ldap-url"),
+			StartTLS:    clicmd.Bool("ldap-start-tls"),
+			UserDN:      clicmd.String("ldap-user-dn"),
+			GroupBaseDN: clicmd.String("ldap-group-base-dn"),
+			GroupFilter: clicmd.String("ldap-group-filter"),
+			AccessGroup: clicmd.String("ldap-access-group"),
+			AdminGroup:  clicmd.String("ldap-admin-group"),
+			EmailAttr:   clicmd.String("ldap-email-attr"),
+			NameAttr:    clicmd.String("ldap-name-attr"),
+		},
+		Registration: clicmd.String("registration"),
+		PublicURL:    clicmd.String("public-url"),
+		SMTP: config.SMTPConf{
+			Address:  clicmd.String("smtp-address"),
+			From:     clicmd.String("smtp-from"),
+			Username: clicmd.String("smtp-username"),
+			Password: clicmd.String("smtp-password"),
+			TLS:      clicmd.Bool("smtp-tls"),
+		},
 	}
 
 	if err := serverConf.Validate(); err != nil {
@@ -249,8 +452,7 @@ func (s *Server) start(ctx context.Context, injector do.Injector, cfg *config.Se
 		}
 	}
 
-	maintSrv := do.MustInvoke[*service.MaintenanceSrv](injector)
-	go s.runBackgroundMaintenance(ctx, maintSrv)
+	go s.runBackgroundMaintenance(ctx, injector, clicmd.Duration("audit-retention"))
 
 	if i := clicmd.Duration("podcast-load-interval"); i > 0 {
 		go s.podcastDownloadTask(ctx, injector, i, clicmd.Bool("podcast-load-episodes"),
@@ -282,7 +484,6 @@ func (s *Server) podcastDownloadTask(ctx context.Context, injector do.Injector,
 	logger.Info().Msgf("PodcastDownloader: start background podcast downloader; interval=%s", interval)
 
 	podcastSrv := do.MustInvoke[*service.PodcastsSrv](injector)
-	since := time.Now().Add(-24 * time.Hour).UTC()
 
 	eventlog := common.NewEventLog("download podcast info", "worker")
 	defer eventlog.Close()
@@ -296,26 +497,27 @@ func (s *Server) podcastDownloadTask(ctx context.Context, injector do.Injector,
 		case <-time.After(interval):
 		}
 
-		start := time.Now()
-
 		eventlog.Printf("start processing")
 
-		if err := podcastSrv.DownloadPodcastsInfo(ctx, since, loadepisodes, missingonly); err != nil {
+		// feeds are checked according to its schedule
+		since := time.Now().UTC()
+		if err := podcastSrv.DownloadPodcastsInfo(ctx, since, loadepisodes, missingonly, true); err != nil {
 			logger.Error().Err(err).Msgf("PodcastDownloader: download podcast info job error=%q", err)
 			eventlog.Errorf("processing error=%q", err)
 		} else {
 			eventlog.Printf("processing finished")
 		}
-
-		since = start
 	}
 }
 
-func (s *Server) runBackgroundMaintenance(ctx context.Context, maintSrv *service.MaintenanceSrv) {
+func (s *Server) runBackgroundMaintenance(ctx context.Context, injector do.Injector, auditRetention time.Duration) {
 	const startHour = 4
 
 	logger := log.Ctx(ctx)
-	logger.Info().Msg("Maintenance: start background maintenance task")
+	logger.Info().Msgf("Maintenance: start background maintenance task; audit_retention=%s", auditRetention)
+
+	maintSrv := do.MustInvoke[*service.MaintenanceSrv](injector)
+	auditSrv := do.MustInvoke[*service.AuditSrv](injector)
 
 	eventlog := common.NewEventLog("db maintenance", "worker")
 	defer eventlog.Close()
@@ -343,6 +545,13 @@ func (s *Server) runBackgroundMaintenance(ctx context.Context, maintSrv *service
 			llog := logger.With().Str("task_id", taskid.String()).Logger() //nolint:nilaway
 			eventlog.Printf("start maintenance task_id=%s", taskid.String())
 
+			if auditRetention > 0 {
+				if _, err := auditSrv.PruneEvents(hlog.CtxWithID(ctx, taskid), auditRetention); err != nil {
+					llog.Error().Err(err).Msgf("Maintenance: prune audit log error=%q", err)
+					eventlog.Errorf("prune audit log error task_id=%s error=%q", taskid.String(), err)
+				}
+			}
+
 			if err := maintSrv.MaintainDatabase(hlog.CtxWithID(ctx, taskid)); err != nil {
 				llog.Error().Err(err).Msgf("Maintenance: run database maintenance task error=%q", err)
 				eventlog.Errorf("maintenance error task_id=%s error=%q", taskid.String(), err)
@@ -0,0 +1,136 @@
+package cli
+
+//
+// tokens.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/do/v2"
+	"github.com/urfave/cli/v3"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func newAppTokensCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "token",
+		Usage: "manage user app tokens (application passwords)",
+		Commands: []*cli.Command{
+			newAddAppTokenCmd(),
+			newListAppTokensCmd(),
+			newDeleteAppTokenCmd(),
+		},
+	}
+}
+
+//---------------------------------------------------------------------
+
+func newAddAppTokenCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "add",
+		Usage: "create new app token",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+			&cli.StringFlag{Name: "name", Required: true, Aliases: []string{"n"}, Usage: "token name"},
+			&cli.StringFlag{Name: "device", Aliases: []string{"d"}, Usage: "allow use token only for this device"},
+			&cli.BoolFlag{Name: "read-only", Aliases: []string{"r"}, Usage: "allow only read data"},
+		},
+		Action: wrap(addAppTokenCmd),
+	}
+}
+
+//nolint:forbidigo
+func addAppTokenCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	tokensrv := do.MustInvoke[*service.AppTokensSrv](injector)
+	cmd := command.CreateAppTokenCmd{
+		UserName:   clicmd.String("username"),
+		Name:       clicmd.String("name"),
+		DeviceName: clicmd.String("device"),
+		ReadOnly:   clicmd.Bool("read-only"),
+	}
+
+	res, err := tokensrv.CreateAppToken(ctx, &cmd)
+	if err != nil {
+		return fmt.Errorf("create token error: %w", err)
+	}
+
+	fmt.Printf("Token %q created: %s\n", cmd.Name, res.Token)
+	fmt.Println("Token is not stored and can't be displayed again.")
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newListAppTokensCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "list user app tokens",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+		},
+		Action: wrap(listAppTokensCmd),
+	}
+}
+
+//nolint:forbidigo
+func listAppTokensCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	tokensrv := do.MustInvoke[*service.AppTokensSrv](injector)
+
+	tokens, err := tokensrv.GetAppTokens(ctx, &query.GetAppTokensQuery{UserName: clicmd.String("username")})
+	if err != nil {
+		return fmt.Errorf("get tokens error: %w", err)
+	}
+
+	fmt.Printf("%-30s | %-20s | %-9s | %-20s | %s\n", "Name", "Device", "Read-only", "Created", "Last used")
+	fmt.Println(
+		"---------------------------------------------------------------------------------------------------------",
+	)
+
+	for _, t := range tokens {
+		lastused := ""
+		if !t.LastUsedAt.IsZero() {
+			lastused = t.LastUsedAt.Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Printf("%-30s | %-20s | %-9v | %-20s | %s\n", t.Name, t.DeviceName, t.ReadOnly,
+			t.CreatedAt.Format("2006-01-02 15:04:05"), lastused)
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+func newDeleteAppTokenCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "delete",
+		Usage: "revoke app token",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+			&cli.StringFlag{Name: "name", Required: true, Aliases: []string{"n"}, Usage: "token name"},
+		},
+		Action: wrap(deleteAppTokenCmd),
+	}
+}
+
+func deleteAppTokenCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	tokensrv := do.MustInvoke[*service.AppTokensSrv](injector)
+	cmd := command.DeleteAppTokenCmd{UserName: clicmd.String("username"), Name: clicmd.String("name")}
+
+	if err := tokensrv.DeleteAppToken(ctx, &cmd); err != nil {
+		return fmt.Errorf("delete token error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Token %s deleted\n", cmd.Name)
+
+	return nil
+}
@@ -28,6 +28,7 @@ func newAddUserCmd() *cli.Command {
 			&cli.StringFlag{Name: "password", Required: true, Aliases: []string{"p"}},
 			&cli.StringFlag{Name: "email", Aliases: []string{"e"}},
 			&cli.StringFlag{Name: "name", Aliases: []string{"n"}},
+			&cli.BoolFlag{Name: "admin", Usage: "grant administrator privileges"},
 		},
 		Action: wrap(addUserCmd),
 	}
@@ -43,6 +44,7 @@ func addUserCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector)
 		Password: clicmd.String("password"),
 		Email:    clicmd.String("email"),
 		Name:     clicmd.String("name"),
+		Admin:    clicmd.Bool("admin"),
 	}
 
 	res, err := usersrv.AddUser(ctx, &cmd)
@@ -89,6 +91,8 @@ func listUsersCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector
 		status := ""
 		if u.Locked {
 			status = "LOCKED"
+		} else if u.Admin {
+			status = "ADMIN"
 		}
 
 		fmt.Printf("%-30s | %-30s | %-30s | %s \n", u.UserName, u.Name, u.Email, status)
@@ -124,3 +128,65 @@ func deleteUserCmd(ctx context.Context, clicmd *cli.Command, injector do.Injecto
 
 	return nil
 }
+
+// ---------------------------------------------------------------------
+
+func newSetAdminCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "set-admin",
+		Usage: "grant or revoke administrator privileges",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+			&cli.BoolFlag{Name: "revoke", Usage: "revoke privileges"},
+		},
+		Action: wrap(setAdminCmd),
+	}
+}
+
+//nolint:forbidigo
+func setAdminCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	username := clicmd.String("username")
+	usersrv := do.MustInvoke[*service.UsersSrv](injector)
+	admin := !clicmd.Bool("revoke")
+
+	err := usersrv.SetAdmin(ctx, &command.SetUserAdminCmd{UserName: username, Admin: admin})
+	if err != nil {
+		return fmt.Errorf("set admin error: %w", err)
+	}
+
+	if admin {
+		fmt.Printf("User %s is now administrator\n", username)
+	} else {
+		fmt.Printf("Administrator privileges revoked for user %s\n", username)
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------
+
+func newResetTOTPCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "reset-2fa",
+		Usage: "disable two-factor authentication for user (i.e. when authenticator was lost)",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Required: true, Aliases: []string{"u"}},
+		},
+		Action: wrap(resetTOTPCmd),
+	}
+}
+
+func resetTOTPCmd(ctx context.Context, clicmd *cli.Command, injector do.Injector) error {
+	username := clicmd.String("username")
+	totpsrv := do.MustInvoke[*service.TOTPSrv](injector)
+
+	err := totpsrv.DisableTOTP(ctx, &command.DisableTOTPCmd{UserName: username})
+	if err != nil {
+		return fmt.Errorf("reset two-factor authentication error: %w", err)
+	}
+
+	//nolint:forbidigo
+	fmt.Printf("Two-factor authentication for user %s disabled\n", username)
+
+	return nil
+}
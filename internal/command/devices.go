@@ -82,3 +82,60 @@ func (u *DeleteDeviceCmd) MarshalZerologObject(event *zerolog.Event) {
 		Str("username", u.UserName).
 		Str("device_name", u.DeviceName)
 }
+
+// ------------------------------------------------------
+
+// UpdateSyncDevicesCmd define groups of devices to synchronize and devices that should
+// stop synchronization.
+type UpdateSyncDevicesCmd struct {
+	UserName        string
+	Synchronize     [][]string
+	StopSynchronize []string
+}
+
+func (u *UpdateSyncDevicesCmd) Validate() error {
+	if !validators.IsValidUserName(u.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	seen := make(map[string]struct{})
+
+	for _, group := range u.Synchronize {
+		if len(group) < 2 { //nolint:mnd
+			return aerr.ErrValidation.WithUserMsg("synchronize group must contain at least two devices")
+		}
+
+		for _, dev := range group {
+			if !validators.IsValidDevName(dev) {
+				return common.ErrInvalidDevice.WithUserMsg("invalid device name %q", dev)
+			}
+
+			if _, ok := seen[dev]; ok {
+				return aerr.ErrValidation.WithUserMsg("device %q used more than once", dev)
+			}
+
+			seen[dev] = struct{}{}
+		}
+	}
+
+	for _, dev := range u.StopSynchronize {
+		if !validators.IsValidDevName(dev) {
+			return common.ErrInvalidDevice.WithUserMsg("invalid device name %q", dev)
+		}
+
+		if _, ok := seen[dev]; ok {
+			return aerr.ErrValidation.WithUserMsg("device %q used more than once", dev)
+		}
+
+		seen[dev] = struct{}{}
+	}
+
+	return nil
+}
+
+func (u *UpdateSyncDevicesCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.
+		Str("username", u.UserName).
+		Interface("synchronize", u.Synchronize).
+		Strs("stop_synchronize", u.StopSynchronize)
+}
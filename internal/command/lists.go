@@ -0,0 +1,130 @@
+package command
+
+//
+// lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+// CreatePodcastListCmd create new podcast list; name of list is created from title.
+type CreatePodcastListCmd struct {
+	UserName string
+	Title    string
+	Podcasts []string
+}
+
+// Sanitize remove invalid and duplicated podcasts urls.
+func (c *CreatePodcastListCmd) Sanitize() {
+	c.Podcasts = sanitizeListURLs(c.Podcasts)
+}
+
+func (c *CreatePodcastListCmd) Validate() error {
+	if !validators.IsValidUserName(c.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if strings.TrimSpace(c.Title) == "" {
+		return aerr.ErrValidation.WithUserMsg("missing list title")
+	}
+
+	if model.PodcastListName(c.Title) == "" {
+		return aerr.ErrValidation.WithUserMsg("invalid list title")
+	}
+
+	return nil
+}
+
+func (c *CreatePodcastListCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", c.UserName).
+		Str("title", c.Title).
+		Strs("podcasts", c.Podcasts)
+}
+
+type CreatePodcastListCmdResult struct {
+	Name string
+}
+
+//------------------------------------------------------------------------------
+
+// UpdatePodcastListCmd replace podcasts in list. Title is changed when not empty.
+type UpdatePodcastListCmd struct {
+	UserName string
+	ListName string
+	Title    string
+	Podcasts []string
+}
+
+// Sanitize remove invalid and duplicated podcasts urls.
+func (c *UpdatePodcastListCmd) Sanitize() {
+	c.Podcasts = sanitizeListURLs(c.Podcasts)
+}
+
+func (c *UpdatePodcastListCmd) Validate() error {
+	if !validators.IsValidUserName(c.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if c.ListName == "" {
+		return aerr.ErrValidation.WithUserMsg("missing list name")
+	}
+
+	return nil
+}
+
+func (c *UpdatePodcastListCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", c.UserName).
+		Str("list_name", c.ListName).
+		Str("title", c.Title).
+		Strs("podcasts", c.Podcasts)
+}
+
+//------------------------------------------------------------------------------
+
+type DeletePodcastListCmd struct {
+	UserName string
+	ListName string
+}
+
+func (c *DeletePodcastListCmd) Validate() error {
+	if !validators.IsValidUserName(c.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if c.ListName == "" {
+		return aerr.ErrValidation.WithUserMsg("missing list name")
+	}
+
+	return nil
+}
+
+func (c *DeletePodcastListCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", c.UserName).
+		Str("list_name", c.ListName)
+}
+
+//------------------------------------------------------------------------------
+
+func sanitizeListURLs(urls []string) []string {
+	urls, _ = validators.SanitizeURLs(urls)
+	res := make([]string, 0, len(urls))
+
+	for _, u := range urls {
+		if !slices.Contains(res, u) {
+			res = append(res, u)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,96 @@
+package command
+
+//
+// registrations.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+// RegisterUserCmd create account for user registered by web form.
+type RegisterUserCmd struct {
+	UserName   string
+	Password   string
+	Email      string
+	Name       string
+	InviteCode string
+	// RequireInvite - registration is allowed only with valid InviteCode.
+	RequireInvite bool
+	// RequireApproval - account stay locked until admin approve it.
+	RequireApproval bool
+}
+
+func (r *RegisterUserCmd) Sanitize() {
+	r.UserName = strings.TrimSpace(r.UserName)
+	r.Email = strings.TrimSpace(r.Email)
+	r.Name = strings.TrimSpace(r.Name)
+	r.InviteCode = strings.TrimSpace(r.InviteCode)
+}
+
+func (r *RegisterUserCmd) Validate() error {
+	if !validators.IsValidUserName(r.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if r.Password == "" {
+		return aerr.ErrValidation.WithUserMsg("password can't be empty")
+	}
+
+	if r.Email == "" {
+		return aerr.ErrValidation.WithUserMsg("email can't be empty")
+	}
+
+	if r.RequireInvite && r.InviteCode == "" {
+		return aerr.ErrValidation.WithUserMsg("invite code is required")
+	}
+
+	return nil
+}
+
+func (r *RegisterUserCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("user_name", r.UserName).
+		Str("email", r.Email).
+		Str("name", r.Name).
+		Bool("require_invite", r.RequireInvite).
+		Bool("require_approval", r.RequireApproval)
+}
+
+// RegisterUserCmdResult is result of RegisterUserCmd.
+type RegisterUserCmdResult struct {
+	UserID int64
+	// PendingApproval is true when account is locked until approval.
+	PendingApproval bool
+}
+
+//---------------------------------------------------------------------
+
+// CreateInviteCodeCmd create new invite code.
+type CreateInviteCodeCmd struct {
+	// CreatedBy is name of admin creating code.
+	CreatedBy string
+	// ValidFor limit time when code can be used; 0 = no limit.
+	ValidFor time.Duration
+}
+
+func (c *CreateInviteCodeCmd) Validate() error {
+	if c.ValidFor < 0 {
+		return aerr.ErrValidation.WithUserMsg("invalid validity time")
+	}
+
+	return nil
+}
+
+// CreateInviteCodeCmdResult is result of CreateInviteCodeCmd.
+type CreateInviteCodeCmdResult struct {
+	Code string
+}
@@ -0,0 +1,83 @@
+package command
+
+//
+// tokens.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+// CreateAppTokenCmd create new app token for user. When DeviceName is not empty, token can be used
+// only with this device.
+type CreateAppTokenCmd struct {
+	UserName   string
+	Name       string
+	DeviceName string
+	ReadOnly   bool
+}
+
+func (c *CreateAppTokenCmd) Sanitize() {
+	c.Name = strings.TrimSpace(c.Name)
+	c.DeviceName = strings.TrimSpace(c.DeviceName)
+}
+
+func (c *CreateAppTokenCmd) Validate() error {
+	if !validators.IsValidUserName(c.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if c.Name == "" {
+		return aerr.ErrValidation.WithUserMsg("token name can't be empty")
+	}
+
+	if c.DeviceName != "" && !validators.IsValidDevName(c.DeviceName) {
+		return common.ErrInvalidDevice.WithUserMsg("invalid device name")
+	}
+
+	return nil
+}
+
+func (c *CreateAppTokenCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", c.UserName).
+		Str("name", c.Name).
+		Str("device_name", c.DeviceName).
+		Bool("read_only", c.ReadOnly)
+}
+
+// CreateAppTokenCmdResult contains created token in plain text; it is not available later.
+type CreateAppTokenCmdResult struct {
+	Token string
+}
+
+//------------------------------------------------------------------------------
+
+type DeleteAppTokenCmd struct {
+	UserName string
+	Name     string
+}
+
+func (c *DeleteAppTokenCmd) Validate() error {
+	if !validators.IsValidUserName(c.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if c.Name == "" {
+		return aerr.ErrValidation.WithUserMsg("missing token name")
+	}
+
+	return nil
+}
+
+func (c *DeleteAppTokenCmd) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", c.UserName).
+		Str("name", c.Name)
+}
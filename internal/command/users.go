@@ -21,6 +21,7 @@ type NewUserCmd struct {
 	Password string
 	Email    string
 	Name     string
+	Admin    bool
 }
 
 func (n *NewUserCmd) Validate() error {
@@ -75,6 +76,26 @@ func (c *ChangeUserPasswordCmd) Validate() error {
 
 //---------------------------------------------------------------------
 
+// ResetPasswordCmd set new password for user identified by password reset token.
+type ResetPasswordCmd struct {
+	Token    string
+	Password string
+}
+
+func (r *ResetPasswordCmd) Validate() error {
+	if r.Token == "" {
+		return common.ErrInvalidResetToken
+	}
+
+	if r.Password == "" {
+		return aerr.ErrValidation.WithUserMsg("password can't be empty")
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
 // LockAccountCmd is user account to lock.
 type LockAccountCmd struct {
 	UserName string
@@ -90,6 +111,37 @@ func (l *LockAccountCmd) Validate() error {
 
 //---------------------------------------------------------------------
 
+// UnlockAccountCmd is locked user account to unlock with password from before lock.
+type UnlockAccountCmd struct {
+	UserName string
+}
+
+func (u *UnlockAccountCmd) Validate() error {
+	if !validators.IsValidUserName(u.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+// SetUserAdminCmd grant or revoke administrator privileges.
+type SetUserAdminCmd struct {
+	UserName string
+	Admin    bool
+}
+
+func (s *SetUserAdminCmd) Validate() error {
+	if !validators.IsValidUserName(s.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
 // DeleteUserCmd delete user and all related data.
 type DeleteUserCmd struct {
 	UserName string
@@ -102,3 +154,74 @@ func (d *DeleteUserCmd) Validate() error {
 
 	return nil
 }
+
+//---------------------------------------------------------------------
+
+// ProvisionUserCmd create or update user authenticated by external identity provider.
+type ProvisionUserCmd struct {
+	UserName string
+	Email    string
+	Name     string
+	Admin    bool
+}
+
+func (p *ProvisionUserCmd) Validate() error {
+	if !validators.IsValidUserName(p.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+// EnableTOTPCmd enable two-factor authentication for user. Code must be valid for secret.
+type EnableTOTPCmd struct {
+	UserName string
+	Secret   string
+	Code     string
+}
+
+func (e *EnableTOTPCmd) Validate() error {
+	if !validators.IsValidUserName(e.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if e.Secret == "" {
+		return aerr.ErrValidation.WithUserMsg("missing secret")
+	}
+
+	if e.Code == "" {
+		return aerr.ErrValidation.WithUserMsg("verification code can't be empty")
+	}
+
+	return nil
+}
+
+// EnableTOTPCmdResult is result of EnableTOTPCmd.
+type EnableTOTPCmdResult struct {
+	// RecoveryCodes are one-time codes that can be used instead of totp code; displayed only once.
+	RecoveryCodes []string
+}
+
+//---------------------------------------------------------------------
+
+// DisableTOTPCmd disable two-factor authentication for user. When CheckCode is set, Code must be
+// valid totp or recovery code.
+type DisableTOTPCmd struct {
+	UserName  string
+	Code      string
+	CheckCode bool
+}
+
+func (d *DisableTOTPCmd) Validate() error {
+	if !validators.IsValidUserName(d.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if d.CheckCode && d.Code == "" {
+		return aerr.ErrValidation.WithUserMsg("verification code can't be empty")
+	}
+
+	return nil
+}
@@ -43,3 +43,46 @@ func ContextWithDevice(ctx context.Context, devicename string) context.Context {
 }
 
 // ------------------------------------------------------
+
+//nolint:gochecknoglobals
+var ctxCSRFTokenKey = any("ctxCSRFTokenKey")
+
+// ContextCSRFToken return csrf token of current session from context.
+func ContextCSRFToken(ctx context.Context) string {
+	value, ok := ctx.Value(ctxCSRFTokenKey).(string)
+	if ok {
+		return value
+	}
+
+	return ""
+}
+
+// ContextWithCSRFToken create context with csrf token.
+func ContextWithCSRFToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, ctxCSRFTokenKey, token)
+}
+
+// ------------------------------------------------------
+
+//nolint:gochecknoglobals
+var ctxClientInfoKey = any("ctxClientInfoKey")
+
+type clientInfo struct {
+	ip        string
+	userAgent string
+}
+
+// ContextClientInfo return client ip address and user agent from context.
+func ContextClientInfo(ctx context.Context) (string, string) {
+	value, ok := ctx.Value(ctxClientInfoKey).(clientInfo)
+	if ok {
+		return value.ip, value.userAgent
+	}
+
+	return "", ""
+}
+
+// ContextWithClientInfo create context with client ip address and user agent.
+func ContextWithClientInfo(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, ctxClientInfoKey, clientInfo{ip, userAgent})
+}
@@ -21,6 +21,8 @@ var (
 	ErrUnauthorized      = aerr.New("unauthorized").WithUserMsg("authorization failed").WithTag(AuthenticationError)
 	ErrUserAccountLocked = aerr.New("locked account").WithUserMsg("account is locked").WithTag(AuthenticationError)
 	ErrUserNotFound      = aerr.New("user not found").WithUserMsg("user not found").WithTag(AuthenticationError)
+	ErrInvalidTOTPCode   = aerr.New("invalid totp code").WithUserMsg("invalid verification code").
+				WithTag(AuthenticationError)
 )
 
 // Validation errors.
@@ -35,6 +37,27 @@ var (
 	ErrInvalidDevice  = aerr.New("invalid device").WithTag(aerr.ValidationError)
 	ErrInvalidPodcast = aerr.New("invalid podcast").WithTag(aerr.ValidationError)
 	ErrInvalidEpisode = aerr.New("invalid episode").WithTag(aerr.ValidationError)
+
+	ErrUnknownPodcastList = aerr.New("unknown podcast list").WithTag(aerr.ValidationError)
+	ErrPodcastListExists  = aerr.New("podcast list exists").WithUserMsg("podcast list already exists").
+				WithTag(aerr.ValidationError)
+
+	ErrUnknownAppToken = aerr.New("unknown app token").WithTag(aerr.ValidationError)
+	ErrAppTokenExists  = aerr.New("app token exists").WithUserMsg("token with this name already exists").
+				WithTag(aerr.ValidationError)
+
+	ErrTOTPEnabled = aerr.New("totp already enabled").WithUserMsg("two-factor authentication is already enabled").
+			WithTag(aerr.ValidationError)
+
+	ErrInvalidInviteCode = aerr.New("invalid invite code").WithUserMsg("invalid or expired invite code").
+				WithTag(aerr.ValidationError)
+	ErrUnknownInviteCode = aerr.New("unknown invite code").WithTag(aerr.ValidationError)
+	ErrNoRegistration    = aerr.New("no pending registration").WithUserMsg("user has no pending registration").
+				WithTag(aerr.ValidationError)
+
+	ErrInvalidResetToken = aerr.New("invalid password reset token").
+				WithUserMsg("invalid or expired password reset link").WithTag(aerr.ValidationError)
+	ErrUserNoEmail = aerr.New("user has no email").WithTag(aerr.ValidationError)
 )
 
 var ErrNoData = errors.New("no result")
@@ -0,0 +1,73 @@
+package config
+
+//
+// ldap.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+)
+
+const (
+	defaultLDAPGroupFilter = "(|(member={userdn})(uniqueMember={userdn}))"
+	defaultLDAPEmailAttr   = "mail"
+	defaultLDAPNameAttr    = "cn"
+)
+
+// LDAPConf configure authentication users by ldap directory.
+type LDAPConf struct {
+	// URL is ldap server address (ldap://host:port or ldaps://host:port).
+	URL      string
+	StartTLS bool
+	// UserDN is template of user dn; `{username}` is replaced by user name.
+	UserDN string
+	// GroupBaseDN is base dn for searching groups that user belong to.
+	GroupBaseDN string
+	// GroupFilter is filter used for searching user groups; `{userdn}` is replaced by user dn.
+	GroupFilter string
+	// AccessGroup is dn of group which members are allowed to login; empty allow all users.
+	AccessGroup string
+	// AdminGroup is dn of group which members are administrators.
+	AdminGroup string
+	EmailAttr  string
+	NameAttr   string
+}
+
+func (c *LDAPConf) Validate() error {
+	if c.URL == "" {
+		return aerr.ErrValidation.WithUserMsg("missing ldap url")
+	}
+
+	if !strings.Contains(c.UserDN, "{username}") {
+		return aerr.ErrValidation.WithUserMsg("ldap user dn must contain {username} placeholder")
+	}
+
+	if (c.AccessGroup != "" || c.AdminGroup != "") && c.GroupBaseDN == "" {
+		return aerr.ErrValidation.WithUserMsg("missing ldap group base dn")
+	}
+
+	c.GroupFilter = cmp.Or(c.GroupFilter, defaultLDAPGroupFilter)
+	c.EmailAttr = cmp.Or(c.EmailAttr, defaultLDAPEmailAttr)
+	c.NameAttr = cmp.Or(c.NameAttr, defaultLDAPNameAttr)
+
+	return nil
+}
+
+func (c *LDAPConf) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("url", c.URL).
+		Bool("start_tls", c.StartTLS).
+		Str("user_dn", c.UserDN).
+		Str("group_base_dn", c.GroupBaseDN).
+		Str("group_filter", c.GroupFilter).
+		Str("access_group", c.AccessGroup).
+		Str("admin_group", c.AdminGroup).
+		Str("email_attr", c.EmailAttr).
+		Str("name_attr", c.NameAttr)
+}
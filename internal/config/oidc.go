@@ -0,0 +1,80 @@
+package config
+
+//
+// oidc.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+)
+
+const defaultOIDCUsernameClaim = "preferred_username"
+
+// OIDCConf configure login to web gui by OpenID Connect provider.
+type OIDCConf struct {
+	// IssuerURL is provider url used for discovery.
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is full, external url of `/oidc/callback` endpoint.
+	RedirectURL string
+	// UsernameClaim is name of id token claim used as user name.
+	UsernameClaim string
+	// Scopes are additional (other than `openid`) requested scopes separated by space or comma.
+	Scopes string
+	// AutoProvision enable creating local account for unknown users.
+	AutoProvision bool
+}
+
+func (c *OIDCConf) Validate() error {
+	if c.IssuerURL == "" {
+		return aerr.ErrValidation.WithUserMsg("missing oidc issuer url")
+	}
+
+	if c.ClientID == "" {
+		return aerr.ErrValidation.WithUserMsg("missing oidc client id")
+	}
+
+	if c.RedirectURL == "" {
+		return aerr.ErrValidation.WithUserMsg("missing oidc redirect url")
+	}
+
+	if u, err := url.Parse(c.RedirectURL); err != nil || !u.IsAbs() {
+		return aerr.ErrValidation.WithUserMsg("oidc redirect url must be absolute url")
+	}
+
+	if c.UsernameClaim == "" {
+		c.UsernameClaim = defaultOIDCUsernameClaim
+	}
+
+	return nil
+}
+
+// ScopesList return all scopes to request, including `openid`.
+func (c *OIDCConf) ScopesList() []string {
+	scopes := []string{"openid"}
+
+	for s := range strings.FieldsFuncSeq(c.Scopes, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return scopes
+}
+
+func (c *OIDCConf) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("issuer", c.IssuerURL).
+		Str("client_id", c.ClientID).
+		Str("redirect_url", c.RedirectURL).
+		Str("username_claim", c.UsernameClaim).
+		Strs("scopes", c.ScopesList()).
+		Bool("auto_provision", c.AutoProvision)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/rs/zerolog"
@@ -56,6 +57,16 @@ func (c *ListenConf) MarshalZerologObject(event *zerolog.Event) {
 
 //-------------------------------------------------------------
 
+// Self-registration modes.
+const (
+	// RegistrationDisabled - accounts are created only by admin.
+	RegistrationDisabled = "disabled"
+	// RegistrationInvite - registration require invite code.
+	RegistrationInvite = "invite"
+	// RegistrationApproval - registered account is locked until admin approve it.
+	RegistrationApproval = "approval"
+)
+
 // ServerConf configure all web/api/mgmt servers.
 type ServerConf struct {
 	MainServer ListenConf
@@ -71,6 +82,14 @@ type ServerConf struct {
 	AuthMethod      string
 	ProxyUserHeader string
 	ProxyAccessList string
+	OIDC            OIDCConf
+	LDAP            LDAPConf
+
+	// Registration is self-registration mode (disabled, invite, approval).
+	Registration string
+	// PublicURL is external address of web root used in links sent by email.
+	PublicURL string
+	SMTP      SMTPConf
 
 	mgmtAccessList  *AccessList
 	proxyAccessList *AccessList
@@ -118,9 +137,45 @@ func (c *ServerConf) Validate() error { //nolint:cyclop
 		return err
 	}
 
+	switch c.Registration {
+	case "":
+		c.Registration = RegistrationDisabled
+	case RegistrationDisabled:
+		// ok
+	case RegistrationInvite, RegistrationApproval:
+		if c.AuthMethod != "basic" {
+			return aerr.ErrValidation.WithUserMsg("registration is available only for basic auth-method")
+		}
+	default:
+		return aerr.ErrValidation.WithUserMsg("invalid registration mode: %q", c.Registration)
+	}
+
+	if c.SMTP.Enabled() {
+		if err := c.SMTP.Validate(); err != nil {
+			return fmt.Errorf("validate smtp configuration failed: %w", err)
+		}
+
+		if u, err := url.Parse(c.PublicURL); err != nil || u.Host == "" ||
+			(u.Scheme != "http" && u.Scheme != "https") {
+			return aerr.ErrValidation.WithUserMsg("public url (http or https) is required for sending emails")
+		}
+
+		c.PublicURL = strings.TrimSuffix(c.PublicURL, "/")
+	}
+
 	return nil
 }
 
+// RegistrationEnabled return true when users can register accounts.
+func (c *ServerConf) RegistrationEnabled() bool {
+	return c.Registration == RegistrationInvite || c.Registration == RegistrationApproval
+}
+
+// PasswordResetEnabled return true when users can reset forgotten password by email.
+func (c *ServerConf) PasswordResetEnabled() bool {
+	return c.SMTP.Enabled() && c.AuthMethod == "basic"
+}
+
 func (c *ServerConf) SeparateMgmtEnabled() bool {
 	return c.MgmtServer.Address != "" && c.MgmtServer.Address != c.MainServer.Address
 }
@@ -135,6 +190,11 @@ func (c *ServerConf) MarshalZerologObject(event *zerolog.Event) {
 		Object("proxy_list", c.proxyAccessList).
 		Str("auth_method", c.AuthMethod).
 		Str("proxy_user_header", c.ProxyUserHeader).
+		Object("oidc", &c.OIDC).
+		Object("ldap", &c.LDAP).
+		Str("registration", c.Registration).
+		Str("public_url", c.PublicURL).
+		Object("smtp", &c.SMTP).
 		Bool("sec_headers", c.SetSecurityHeaders).
 		Str("session_store", c.SessionStore).
 		Object("main_server", &c.MainServer).
@@ -204,6 +264,14 @@ func (c *ServerConf) validateAuth() error {
 		if c.proxyAccessList == nil || c.proxyAccessList.Len() == 0 {
 			return aerr.ErrValidation.WithUserMsg("missing proxy list")
 		}
+	case "oidc":
+		if err := c.OIDC.Validate(); err != nil {
+			return fmt.Errorf("validate oidc configuration failed: %w", err)
+		}
+	case "ldap":
+		if err := c.LDAP.Validate(); err != nil {
+			return fmt.Errorf("validate ldap configuration failed: %w", err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,63 @@
+package config
+
+//
+// smtp.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"net"
+	"net/mail"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+)
+
+// SMTPConf configure sending emails (i.e. password reset links).
+type SMTPConf struct {
+	// Address is smtp server address (host:port); empty disable sending emails.
+	Address string
+	// From is sender address.
+	From     string
+	Username string
+	Password string
+	// TLS enable implicit tls (smtps, usually port 465); otherwise STARTTLS is used when server
+	// support it.
+	TLS bool
+}
+
+// Enabled return true when sending emails is configured.
+func (c *SMTPConf) Enabled() bool {
+	return c.Address != ""
+}
+
+func (c *SMTPConf) Validate() error {
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		return aerr.ErrValidation.WithUserMsg("invalid smtp address; expected host:port")
+	}
+
+	if c.From == "" {
+		return aerr.ErrValidation.WithUserMsg("missing smtp sender address")
+	}
+
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return aerr.ErrValidation.WithUserMsg("invalid smtp sender address")
+	}
+
+	return nil
+}
+
+func (c *SMTPConf) MarshalZerologObject(event *zerolog.Event) {
+	pass := ""
+	if c.Password != "" {
+		pass = "***"
+	}
+
+	event.Str("address", c.Address).
+		Str("from", c.From).
+		Str("username", c.Username).
+		Str("password", pass).
+		Bool("tls", c.TLS)
+}
@@ -42,8 +42,8 @@ func NewXMLPodcasts(podcasts []model.Podcast) XMLPodcasts {
 			MygpoLink:     p.MygpoLink,
 			Author:        "",
 			Description:   p.Description,
-			Subscribers:   0,
-			LogoURL:       "",
+			Subscribers:   p.Subscribers,
+			LogoURL:       p.LogoURL,
 			ScaledLogoURL: "",
 		}
 	}
@@ -53,6 +53,76 @@ var Package = do.Package(
 			return nil, ErrInvalidDBInfra
 		}
 	}),
+	do.Lazy(func(i do.Injector) (repository.Subscriptions, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
+	do.Lazy(func(i do.Injector) (repository.AppTokens, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
+	do.Lazy(func(i do.Injector) (repository.RememberTokens, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
+	do.Lazy(func(i do.Injector) (repository.LoginFailures, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
+	do.Lazy(func(i do.Injector) (repository.Registrations, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
+	do.Lazy(func(i do.Injector) (repository.AuditLog, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
+	do.Lazy(func(i do.Injector) (repository.PodcastLists, error) {
+		switch getDriverName(i) {
+		case "sqlite3":
+			return &sqlite.Repository{}, nil
+		case "postgres":
+			return &pg.Repository{}, nil
+		default:
+			return nil, ErrInvalidDBInfra
+		}
+	}),
 	do.Lazy(func(i do.Injector) (repository.Devices, error) {
 		switch getDriverName(i) {
 		case "sqlite3":
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE devices ADD COLUMN sync_group INTEGER;
+
+CREATE TABLE subscriptions_hist (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	podcast_id INT8 NOT NULL,
+	device_id INT8 NULL,
+	"action" VARCHAR NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT subscriptions_hist_device_id_fkey FOREIGN KEY (device_id)
+		REFERENCES devices(id)
+		ON DELETE CASCADE ON UPDATE CASCADE,
+	CONSTRAINT subscriptions_hist_podcast_id_fkey FOREIGN KEY (podcast_id)
+		REFERENCES podcasts(id)
+		ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- current state of subscriptions is shared by all devices
+INSERT INTO subscriptions_hist (podcast_id, device_id, "action", created_at)
+SELECT id, NULL, CASE WHEN subscribed THEN 'subscribe' ELSE 'unsubscribe' END, updated_at
+FROM podcasts;
+
+CREATE INDEX subscriptions_hist_podcast_id_idx ON subscriptions_hist(podcast_id, created_at);
+CREATE INDEX subscriptions_hist_device_id_idx ON subscriptions_hist(device_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE subscriptions_hist;
+
+ALTER TABLE devices DROP COLUMN sync_group;
+-- +goose StatementEnd
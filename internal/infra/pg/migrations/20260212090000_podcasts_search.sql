@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE podcasts ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(description, '')), 'B') ||
+	setweight(to_tsvector('simple', url), 'C')
+) STORED;
+
+CREATE INDEX podcasts_search_idx ON podcasts USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX podcasts_search_idx;
+ALTER TABLE podcasts DROP COLUMN search_vector;
+-- +goose StatementEnd
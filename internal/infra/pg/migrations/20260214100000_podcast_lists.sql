@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE podcast_lists (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id INT8 NOT NULL,
+	name VARCHAR NOT NULL,
+	title VARCHAR NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT podcast_lists_user_id_fkey FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX podcast_lists_user_id_name_idx ON podcast_lists(user_id, name);
+
+CREATE TABLE podcast_lists_items (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	list_id INT8 NOT NULL,
+	url VARCHAR NOT NULL,
+	position INTEGER NOT NULL,
+	CONSTRAINT podcast_lists_items_list_id_fkey FOREIGN KEY (list_id)
+		REFERENCES podcast_lists(id)
+		ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX podcast_lists_items_list_id_idx ON podcast_lists_items(list_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE podcast_lists_items;
+DROP TABLE podcast_lists;
+-- +goose StatementEnd
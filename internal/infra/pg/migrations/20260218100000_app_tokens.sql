@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE app_tokens (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id INT8 NOT NULL,
+	name VARCHAR NOT NULL,
+	token VARCHAR NOT NULL,
+	device_name VARCHAR NOT NULL DEFAULT '',
+	read_only BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	last_used_at TIMESTAMP WITH TIME ZONE,
+	CONSTRAINT app_tokens_user_id_fkey FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX app_tokens_user_id_name_idx ON app_tokens(user_id, name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE app_tokens;
+-- +goose StatementEnd
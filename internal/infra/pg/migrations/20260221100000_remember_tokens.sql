@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE remember_tokens (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	user_id INT8 NOT NULL,
+	token VARCHAR NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	CONSTRAINT remember_tokens_user_id_fkey FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX remember_tokens_token_idx ON remember_tokens(token);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE remember_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN totp_secret VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN totp_recovery_codes VARCHAR NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN totp_recovery_codes;
+ALTER TABLE users DROP COLUMN totp_secret;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE login_failures (
+	kind VARCHAR NOT NULL,
+	subject VARCHAR NOT NULL,
+	failures INT NOT NULL DEFAULT 0,
+	last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	locked_until TIMESTAMP WITH TIME ZONE,
+	PRIMARY KEY (kind, subject)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE login_failures;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE feeds (
+	id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	url VARCHAR NOT NULL UNIQUE,
+	title VARCHAR NOT NULL,
+	description TEXT,
+	website TEXT,
+	logo_url TEXT,
+	metadata_updated_at TIMESTAMP WITH TIME ZONE,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+	search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(description, '')), 'B') ||
+		setweight(to_tsvector('simple', url), 'C')
+	) STORED
+);
+
+CREATE INDEX feeds_meta_updated_at_idx ON feeds (metadata_updated_at);
+CREATE INDEX feeds_search_idx ON feeds USING GIN (search_vector);
+
+-- for each url use the most recently updated podcast
+INSERT INTO feeds (url, title, description, website, logo_url, metadata_updated_at, created_at, updated_at)
+SELECT DISTINCT ON (url) url, title, description, website, logo_url, metadata_updated_at, created_at, updated_at
+FROM podcasts
+ORDER BY url, metadata_updated_at DESC NULLS LAST, id;
+
+DROP INDEX podcasts_search_idx;
+ALTER TABLE podcasts
+	DROP COLUMN search_vector,
+	DROP COLUMN title,
+	DROP COLUMN description,
+	DROP COLUMN website,
+	DROP COLUMN logo_url,
+	DROP COLUMN metadata_updated_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE podcasts
+	ADD title VARCHAR NOT NULL DEFAULT '',
+	ADD description TEXT,
+	ADD website TEXT,
+	ADD logo_url TEXT,
+	ADD metadata_updated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE podcasts p
+SET title = f.title, description = f.description, website = f.website, logo_url = f.logo_url,
+	metadata_updated_at = f.metadata_updated_at
+FROM feeds f
+WHERE f.url = p.url;
+
+CREATE INDEX podcasts_meta_updated_at ON podcasts (metadata_updated_at);
+
+ALTER TABLE podcasts ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+	setweight(to_tsvector('simple', coalesce(description, '')), 'B') ||
+	setweight(to_tsvector('simple', url), 'C')
+) STORED;
+
+CREATE INDEX podcasts_search_idx ON podcasts USING GIN (search_vector);
+
+DROP TABLE feeds;
+-- +goose StatementEnd
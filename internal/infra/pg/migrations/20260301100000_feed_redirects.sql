@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE feed_redirects (
+	old_url VARCHAR NOT NULL PRIMARY KEY,
+	new_url VARCHAR NOT NULL,
+	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX feed_redirects_new_url_idx ON feed_redirects (new_url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE feed_redirects;
+-- +goose StatementEnd
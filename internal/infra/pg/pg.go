@@ -167,7 +167,20 @@ func (d *Database) Clear(ctx context.Context) error {
 		"DELETE FROM settings;",
 		"DELETE FROM episodes_hist;",
 		"DELETE FROM episodes;",
+		"DELETE FROM subscriptions_hist;",
+		"DELETE FROM app_tokens;",
+		"DELETE FROM remember_tokens;",
+		"DELETE FROM login_failures;",
+		"DELETE FROM audit_log;",
+		"DELETE FROM user_registrations;",
+		"DELETE FROM invite_codes;",
+		"DELETE FROM podcast_lists_items;",
+		"DELETE FROM podcast_lists;",
+		"DELETE FROM podcasts_tags;",
 		"DELETE FROM podcasts;",
+		"DELETE FROM feed_items;",
+		"DELETE FROM feed_redirects;",
+		"DELETE FROM feeds;",
 		"DELETE FROM devices;",
 		"DELETE FROM users;",
 		"DELETE FROM sessions;",
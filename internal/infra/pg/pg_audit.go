@@ -0,0 +1,87 @@
+package pg
+
+//
+// pg_audit.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func (Repository) SaveAuditEvent(ctx context.Context, event *model.AuditEvent) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Object("event", event).
+		Msgf("pg.Repository: save audit event user_name=%s action=%s", event.UserName, event.Action)
+
+	dbctx := db.MustCtx(ctx)
+
+	_, err := dbctx.ExecContext(ctx,
+		"INSERT INTO audit_log (created_at, user_name, action, ip, user_agent, details) "+
+			"VALUES($1, $2, $3, $4, $5, $6)",
+		event.CreatedAt.UTC(), event.UserName, event.Action, event.IP, event.UserAgent, event.Details)
+	if err != nil {
+		return aerr.Wrapf(err, "insert audit event failed").
+			WithMeta("user_name", event.UserName, "action", event.Action)
+	}
+
+	return nil
+}
+
+func (Repository) ListAuditEvents(ctx context.Context, username, action string, since time.Time, limit uint,
+) ([]model.AuditEvent, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: list audit events user_name=%s action=%s since=%s limit=%d",
+		username, action, since, limit)
+
+	dbctx := db.MustCtx(ctx)
+	res := []AuditEventDB{}
+
+	query := "SELECT id, created_at, user_name, action, ip, user_agent, details FROM audit_log " +
+		"WHERE ($1::VARCHAR='' OR user_name=$1) AND ($2::VARCHAR='' OR action=$2) AND created_at >= $3 " +
+		"ORDER BY created_at DESC, id DESC"
+
+	if limit > 0 {
+		query += " LIMIT " + strconv.FormatUint(uint64(limit), 10)
+	}
+
+	if err := dbctx.SelectContext(ctx, &res, query, username, action, since.UTC()); err != nil {
+		return nil, aerr.Wrapf(err, "select audit events failed").
+			WithMeta("user_name", username, "action", action)
+	}
+
+	events := make([]model.AuditEvent, len(res))
+	for i, e := range res {
+		events[i] = e.toModel()
+	}
+
+	return events, nil
+}
+
+func (Repository) DeleteAuditEvents(ctx context.Context, before time.Time) (int64, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: delete audit events before=%s", before)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx, "DELETE FROM audit_log WHERE created_at < $1", before.UTC())
+	if err != nil {
+		return 0, aerr.Wrapf(err, "delete audit events failed").WithMeta("before", before)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, aerr.Wrapf(err, "get deleted audit events count failed")
+	}
+
+	return deleted, nil
+}
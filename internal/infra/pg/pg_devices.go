@@ -33,7 +33,7 @@ func (s Repository) GetDevice(
 
 	device := DeviceDB{}
 	err := dbctx.GetContext(ctx, &device, `
-		SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, d.created_at, d.updated_at,
+		SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, d.sync_group, d.created_at, d.updated_at,
 				u.id AS "user.id", u.name AS "user.name", u.username AS "user.username"
 		FROM devices d
 		JOIN users u ON u.ID = d.user_id
@@ -79,10 +79,10 @@ func (s Repository) SaveDevice(ctx context.Context, device *model.Device) (int64
 		var id int64
 
 		err := dbctx.GetContext(ctx, &id, `
-			INSERT INTO devices (user_id, name, dev_type, caption, updated_at, created_at)
-			VALUES($1, $2, $3, $4, $5, $6)
+			INSERT INTO devices (user_id, name, dev_type, caption, sync_group, updated_at, created_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7)
 			RETURNING id`,
-			device.User.ID, device.Name, device.DevType, device.Caption, now, now)
+			device.User.ID, device.Name, device.DevType, device.Caption, syncGroupToDB(device.SyncGroup), now, now)
 		if err != nil {
 			return 0, aerr.Wrapf(err, "insert device failed")
 		}
@@ -94,8 +94,8 @@ func (s Repository) SaveDevice(ctx context.Context, device *model.Device) (int64
 	logger.Debug().Object("device", device).Msgf("pg.Repository: update device device_name=%s", device.Name)
 
 	_, err := dbctx.ExecContext(ctx,
-		"UPDATE devices SET dev_type=$1, caption=$2, updated_at=$3 WHERE id=$4",
-		device.DevType, device.Caption, time.Now().UTC(), device.ID)
+		"UPDATE devices SET dev_type=$1, caption=$2, sync_group=$3, updated_at=$4 WHERE id=$5",
+		device.DevType, device.Caption, syncGroupToDB(device.SyncGroup), time.Now().UTC(), device.ID)
 	if err != nil {
 		return device.ID, aerr.Wrapf(err, "update device failed").WithMeta("device_id", device.ID)
 	}
@@ -124,7 +124,7 @@ func (s Repository) ListDevices(ctx context.Context, userid int64) ([]model.Devi
 	devices := []DeviceDB{}
 
 	err = dbctx.SelectContext(ctx, &devices, `
-			SELECT d.id, d.user_id, d.name, d.dev_type, d.caption,
+			SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, d.sync_group,
 				d.created_at, d.updated_at,
 				u.id as "user.id", u.name as "user.name", u.username as "user.username"
 			FROM devices d
@@ -150,6 +150,11 @@ func (s Repository) DeleteDevice(ctx context.Context, deviceid int64) error {
 		return aerr.Wrapf(err, "delete device failed")
 	}
 
+	_, err = dbctx.ExecContext(ctx, "DELETE FROM subscriptions_hist WHERE device_id=$1", deviceid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete device subscriptions failed")
+	}
+
 	_, err = dbctx.ExecContext(ctx, "DELETE FROM devices where id=$1", deviceid)
 	if err != nil {
 		return aerr.Wrapf(err, "delete device failed")
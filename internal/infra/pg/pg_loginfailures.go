@@ -0,0 +1,126 @@
+package pg
+
+//
+// pg_loginfailures.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func (Repository) GetLoginFailure(ctx context.Context, kind, subject string) (*model.LoginFailure, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: get login failure kind=%s subject=%s", kind, subject)
+
+	dbctx := db.MustCtx(ctx)
+	res := LoginFailureDB{}
+
+	err := dbctx.GetContext(ctx, &res,
+		"SELECT kind, subject, failures, last_failure_at, locked_until "+
+			"FROM login_failures WHERE kind=$1 AND subject=$2",
+		kind, subject)
+
+	switch {
+	case err == nil:
+		failure := res.toModel()
+
+		return &failure, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, common.ErrNoData
+	default:
+		return nil, aerr.Wrapf(err, "select login failure failed").WithMeta("kind", kind, "subject", subject)
+	}
+}
+
+func (Repository) SaveLoginFailure(ctx context.Context, failure *model.LoginFailure) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Object("failure", failure).
+		Msgf("pg.Repository: save login failure kind=%s subject=%s", failure.Kind, failure.Subject)
+
+	dbctx := db.MustCtx(ctx)
+
+	_, err := dbctx.ExecContext(ctx,
+		"INSERT INTO login_failures (kind, subject, failures, last_failure_at, locked_until) "+
+			"VALUES($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (kind, subject) DO UPDATE SET failures=excluded.failures, "+
+			"last_failure_at=excluded.last_failure_at, locked_until=excluded.locked_until",
+		failure.Kind, failure.Subject, failure.Failures, failure.LastFailureAt.UTC(),
+		nullTimeToDB(failure.LockedUntil))
+	if err != nil {
+		return aerr.Wrapf(err, "save login failure failed").
+			WithMeta("kind", failure.Kind, "subject", failure.Subject)
+	}
+
+	return nil
+}
+
+func (Repository) ListLoginFailures(ctx context.Context) ([]model.LoginFailure, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("pg.Repository: list login failures")
+
+	dbctx := db.MustCtx(ctx)
+	res := []LoginFailureDB{}
+
+	err := dbctx.SelectContext(ctx, &res,
+		"SELECT kind, subject, failures, last_failure_at, locked_until "+
+			"FROM login_failures ORDER BY kind, subject")
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select login failures failed")
+	}
+
+	failures := make([]model.LoginFailure, len(res))
+	for i, f := range res {
+		failures[i] = f.toModel()
+	}
+
+	return failures, nil
+}
+
+func (Repository) DeleteLoginFailures(ctx context.Context, kind, subject string) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: delete login failures kind=%s subject=%s", kind, subject)
+
+	dbctx := db.MustCtx(ctx)
+
+	_, err := dbctx.ExecContext(ctx,
+		"DELETE FROM login_failures WHERE ($1::VARCHAR='' OR kind=$1) AND ($2::VARCHAR='' OR subject=$2)",
+		kind, subject)
+	if err != nil {
+		return aerr.Wrapf(err, "delete login failures failed").WithMeta("kind", kind, "subject", subject)
+	}
+
+	return nil
+}
+
+func (Repository) DeleteExpiredLoginFailures(ctx context.Context, before time.Time) (int64, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: delete expired login failures before=%s", before)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"DELETE FROM login_failures WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < $2)",
+		before.UTC(), time.Now().UTC())
+	if err != nil {
+		return 0, aerr.Wrapf(err, "delete expired login failures failed").WithMeta("before", before)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, aerr.Wrapf(err, "get deleted login failures count failed")
+	}
+
+	return deleted, nil
+}
@@ -75,4 +75,12 @@ var maintScripts = []string{
 			WHERE eh.episode_id  = e.episode_id AND eh.action = 'play' AND eh.updated_at > e.updated_at
 		);
 	`,
+	// delete expired remember me tokens
+	`DELETE FROM remember_tokens WHERE expires_at < now();`,
+	// delete feeds not used by any user nor podcast list
+	`DELETE FROM feeds
+		WHERE url NOT IN (SELECT url FROM podcasts)
+		AND url NOT IN (SELECT url FROM podcast_lists_items);`,
+	`DELETE FROM podcasts_tags WHERE url NOT IN (SELECT url FROM feeds);`,
+	`DELETE FROM feed_items WHERE feed_id NOT IN (SELECT id FROM feeds);`,
 }
@@ -31,9 +31,12 @@ func (s Repository) ListSubscribedPodcasts(ctx context.Context, userid int64, si
 	dbctx := db.MustCtx(ctx)
 
 	query := `
-		SELECT p.id, p.user_id, p.url, p.title, p.subscribed, p.created_at, p.updated_at, p.metadata_updated_at,
-		coalesce(p.description, '') AS description, coalesce(p.website, '') AS website
+		SELECT p.id, p.user_id, p.url, coalesce(f.title, '') AS title, p.subscribed, p.created_at, p.updated_at,
+		f.metadata_updated_at, coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+		coalesce(f.logo_url, '') AS logo_url,
+		(SELECT count(*) FROM podcasts ps WHERE ps.url = p.url AND ps.subscribed) AS subscribers
 		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
 		WHERE p.user_id = $1 AND subscribed `
 	args := []any{userid}
 
@@ -42,7 +45,7 @@ func (s Repository) ListSubscribedPodcasts(ctx context.Context, userid int64, si
 		args = append(args, since) //nolint:wsl_v5
 	}
 
-	query += " ORDER BY p.title, p.url"
+	query += " ORDER BY f.title, p.url"
 
 	err := dbctx.SelectContext(ctx, &res, query, args...)
 	if err != nil {
@@ -61,9 +64,12 @@ func (s Repository) ListPodcasts(ctx context.Context, userid int64, since time.T
 	dbctx := db.MustCtx(ctx)
 
 	query := `
-		SELECT p.id, p.user_id, p.url, p.title, p.subscribed, p.created_at, p.updated_at, p.metadata_updated_at,
-		coalesce(p.description, '') AS description, coalesce(p.website, '') AS website
+		SELECT p.id, p.user_id, p.url, coalesce(f.title, '') AS title, p.subscribed, p.created_at, p.updated_at,
+		f.metadata_updated_at, coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+		coalesce(f.logo_url, '') AS logo_url,
+		(SELECT count(*) FROM podcasts ps WHERE ps.url = p.url AND ps.subscribed) AS subscribers
 		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
 		WHERE p.user_id=$1`
 	args := []any{userid}
 
@@ -72,7 +78,7 @@ func (s Repository) ListPodcasts(ctx context.Context, userid int64, since time.T
 		args = append(args, since) //nolint:wsl_v5
 	}
 
-	query += " ORDER BY p.title, p.url"
+	query += " ORDER BY f.title, p.url"
 
 	err := dbctx.SelectContext(ctx, &res, query, args...)
 	if err != nil {
@@ -94,9 +100,12 @@ func (s Repository) GetPodcastByID(
 	podcast := PodcastDB{}
 
 	err := dbctx.GetContext(ctx, &podcast, `
-		SELECT p.id, p.user_id, p.url, p.title, p.subscribed, p.created_at, p.updated_at, p.metadata_updated_at,
-			coalesce(p.description, '') AS description, coalesce(p.website, '') AS website
+		SELECT p.id, p.user_id, p.url, coalesce(f.title, '') AS title, p.subscribed, p.created_at, p.updated_at,
+			f.metadata_updated_at, coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			(SELECT count(*) FROM podcasts ps WHERE ps.url = p.url AND ps.subscribed) AS subscribers
 		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
 		WHERE p.user_id=$1 AND p.id = $2`,
 		userid, podcastid)
 	switch {
@@ -122,9 +131,12 @@ func (s Repository) GetPodcast(
 	podcast := PodcastDB{}
 
 	err := dbctx.GetContext(ctx, &podcast, `
-		SELECT p.id, p.user_id, p.url, p.title, p.subscribed, p.created_at, p.updated_at, p.metadata_updated_at,
-			coalesce(p.description, '') AS description, coalesce(p.website, '') AS website
+		SELECT p.id, p.user_id, p.url, coalesce(f.title, '') AS title, p.subscribed, p.created_at, p.updated_at,
+			f.metadata_updated_at, coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			(SELECT count(*) FROM podcasts ps WHERE ps.url = p.url AND ps.subscribed) AS subscribers
 		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
 		WHERE p.user_id=$1 AND p.url = $2`,
 		userid, podcasturl)
 	switch {
@@ -141,15 +153,14 @@ func (s Repository) SavePodcast(ctx context.Context, podcast *model.Podcast) (in
 	logger := log.Ctx(ctx)
 	dbctx := db.MustCtx(ctx)
 
-	metaupdatedat := sql.NullTime{}
-	if !podcast.MetaUpdatedAt.IsZero() {
-		metaupdatedat = sql.NullTime{Time: podcast.MetaUpdatedAt, Valid: true}
-	}
-
 	if podcast.UpdatedAt.IsZero() {
 		podcast.UpdatedAt = time.Now().UTC()
 	}
 
+	if err := saveFeed(ctx, podcast); err != nil {
+		return 0, err
+	}
+
 	if podcast.ID == 0 {
 		logger.Debug().Object("podcast", podcast).
 			Msgf("pg.Repository: insert podcast user_id=%d podcast_url=%q", podcast.User.ID, podcast.URL)
@@ -158,19 +169,14 @@ func (s Repository) SavePodcast(ctx context.Context, podcast *model.Podcast) (in
 
 		err := dbctx.GetContext(
 			ctx, &podcastid, `
-			INSERT INTO podcasts
-				(user_id, title, url, subscribed, created_at, updated_at, metadata_updated_at, website, description)
-			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			INSERT INTO podcasts (user_id, url, subscribed, created_at, updated_at)
+			VALUES($1, $2, $3, $4, $5)
 			RETURNING id`,
 			podcast.User.ID,
-			podcast.Title,
 			podcast.URL,
 			podcast.Subscribed,
 			time.Now().UTC(),
 			podcast.UpdatedAt,
-			metaupdatedat,
-			podcast.Website,
-			podcast.Description,
 		)
 		if err != nil {
 			return 0, aerr.Wrapf(err, "insert podcast failed").WithMeta("podcast_url", podcast.URL)
@@ -189,8 +195,8 @@ func (s Repository) SavePodcast(ctx context.Context, podcast *model.Podcast) (in
 			podcast.User.ID, podcast.ID, podcast.URL)
 
 	_, err := dbctx.ExecContext(ctx,
-		"UPDATE podcasts SET subscribed=$1, title=$2, url=$3, updated_at=$4 WHERE id=$5",
-		podcast.Subscribed, podcast.Title, podcast.URL, podcast.UpdatedAt, podcast.ID)
+		"UPDATE podcasts SET subscribed=$1, url=$2, updated_at=$3 WHERE id=$4",
+		podcast.Subscribed, podcast.URL, podcast.UpdatedAt, podcast.ID)
 	if err != nil {
 		return 0, aerr.Wrapf(err, "update podcast failed").
 			WithMeta("podcast_id", podcast.ID, "podcast_url", podcast.URL)
@@ -199,18 +205,53 @@ func (s Repository) SavePodcast(ctx context.Context, podcast *model.Podcast) (in
 	return podcast.ID, nil
 }
 
-func (s Repository) ListPodcastsToUpdate(ctx context.Context, since time.Time) ([]model.PodcastToUpdate, error) {
+// saveFeed insert feed for podcast url into shared catalogue if not exists. Metadata of existing
+// feed are not changed; only missing title is filled.
+func saveFeed(ctx context.Context, podcast *model.Podcast) error {
 	dbctx := db.MustCtx(ctx)
 
+	metaupdatedat := sql.NullTime{}
+	if !podcast.MetaUpdatedAt.IsZero() {
+		metaupdatedat = sql.NullTime{Time: podcast.MetaUpdatedAt, Valid: true}
+	}
+
+	now := time.Now().UTC()
+
+	_, err := dbctx.ExecContext(ctx, `
+		INSERT INTO feeds (url, title, description, website, logo_url, metadata_updated_at, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (url) DO UPDATE SET title=excluded.title WHERE feeds.title = ''`,
+		podcast.URL, podcast.Title, podcast.Description, podcast.Website, podcast.LogoURL, metaupdatedat, now, now)
+	if err != nil {
+		return aerr.Wrapf(err, "insert feed failed").WithMeta("podcast_url", podcast.URL)
+	}
+
+	return nil
+}
+
+func (s Repository) ListPodcastsToUpdate(ctx context.Context, since time.Time, scheduled bool,
+) ([]model.PodcastToUpdate, error) {
+	dbctx := db.MustCtx(ctx)
+	now := time.Now().UTC()
+
 	res := []PodcastToUpdate{}
 
-	// for some reason metadata_updated_at is string, even after datetime function
-	err := dbctx.SelectContext(ctx, &res, `
-		SELECT p.url AS url, min(metadata_updated_at) AS metadata_updated_at
-		FROM podcasts p
-		WHERE p.subscribed AND (metadata_updated_at IS NULL OR metadata_updated_at < $1)
-		GROUP by p.url`,
-		since)
+	// only feeds subscribed by any user are updated
+	query := `
+		SELECT f.url, f.metadata_updated_at, f.etag, f.last_modified, f.failures, f.check_interval,
+			f.check_interval_source, f.interval_override
+		FROM feeds f
+		WHERE (f.metadata_updated_at IS NULL OR f.metadata_updated_at < $1)
+			AND NOT f.dead AND (f.next_retry_at IS NULL OR f.next_retry_at <= $2)
+			AND EXISTS (SELECT NULL FROM podcasts p WHERE p.url = f.url AND p.subscribed)`
+	args := []any{since, now}
+
+	if scheduled {
+		query += " AND (f.next_check_at IS NULL OR f.next_check_at <= $3)"
+		args = append(args, now) //nolint:wsl_v5
+	}
+
+	err := dbctx.SelectContext(ctx, &res, query, args...)
 	if err != nil {
 		return nil, aerr.Wrapf(err, "get list podcasts to update failed")
 	}
@@ -233,21 +274,309 @@ func (s Repository) UpdatePodcastsInfo(ctx context.Context, update *model.Podcas
 
 	if update.NotModified {
 		_, err = dbctx.ExecContext(ctx,
-			"UPDATE podcasts SET metadata_updated_at=$1 WHERE url=$2",
+			"UPDATE feeds SET metadata_updated_at=$1 WHERE url=$2",
 			update.MetaUpdatedAt, update.URL)
 	} else {
 		_, err = dbctx.ExecContext(ctx,
-			`UPDATE podcasts SET title=$1, description=$2, website=$3, metadata_updated_at=$4 WHERE url=$5`,
-			update.Title, update.Description, update.Website, update.MetaUpdatedAt, update.URL)
+			`UPDATE feeds SET title=$1, description=$2, website=$3, logo_url=$4, metadata_updated_at=$5, updated_at=$6
+			WHERE url=$7`,
+			update.Title, update.Description, update.Website, update.LogoURL, update.MetaUpdatedAt,
+			time.Now().UTC(), update.URL)
+	}
+
+	if err != nil {
+		return aerr.Wrapf(err, "update feed failed").WithMeta("podcast_update", update)
+	}
+
+	if update.NotModified {
+		return nil
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM podcasts_tags WHERE url=$1", update.URL); err != nil {
+		return aerr.Wrapf(err, "delete podcast tags failed").WithMeta("podcast_url", update.URL)
+	}
+
+	for _, tag := range update.Tags {
+		_, err := dbctx.ExecContext(ctx, "INSERT INTO podcasts_tags (url, tag, title) VALUES($1, $2, $3)",
+			update.URL, tag.Tag, tag.Title)
+		if err != nil {
+			return aerr.Wrapf(err, "insert podcast tag failed").WithMeta("podcast_url", update.URL, "tag", tag.Tag)
+		}
+	}
+
+	return nil
+}
+
+func (s Repository) GetFeedState(ctx context.Context, podcasturl string) (*model.FeedState, error) {
+	dbctx := db.MustCtx(ctx)
+
+	var res FeedStateDB
+
+	err := dbctx.GetContext(ctx, &res, `
+		SELECT url, title, etag, last_modified, last_fetch_at, last_status, last_error, failures,
+			next_retry_at, dead, next_check_at, check_interval, check_interval_source, interval_override
+		FROM feeds
+		WHERE url = $1`,
+		podcasturl)
+
+	switch {
+	case err == nil:
+		state := res.toModel()
+
+		return &state, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, common.ErrNoData
+	default:
+		return nil, aerr.Wrapf(err, "query feed state failed").WithMeta("podcast_url", podcasturl)
+	}
+}
+
+func (s Repository) SaveFeedState(ctx context.Context, state *model.FeedState) error {
+	dbctx := db.MustCtx(ctx)
+	log.Ctx(ctx).Debug().Object("state", state).Msgf("pg.Repository: save feed state podcast_url=%q", state.URL)
+
+	_, err := dbctx.ExecContext(ctx, `
+		UPDATE feeds
+		SET etag=$1, last_modified=$2, last_fetch_at=$3, last_status=$4, last_error=$5, failures=$6,
+			next_retry_at=$7, dead=$8, next_check_at=$9, check_interval=$10, check_interval_source=$11,
+			interval_override=$12
+		WHERE url=$13`,
+		state.ETag, state.LastModified, nullTimeToDB(state.LastFetchAt), state.LastStatus, state.LastError,
+		state.Failures, nullTimeToDB(state.NextRetryAt), state.Dead, nullTimeToDB(state.NextCheckAt),
+		int64(state.CheckInterval.Seconds()), state.CheckIntervalSource, int64(state.IntervalOverride.Seconds()),
+		state.URL)
+	if err != nil {
+		return aerr.Wrapf(err, "update feed state failed").WithMeta("feed_state", state)
+	}
+
+	return nil
+}
+
+func (s Repository) ListFeedStates(ctx context.Context, failingOnly bool) ([]model.FeedState, error) {
+	dbctx := db.MustCtx(ctx)
+
+	query := `
+		SELECT f.url, f.title, f.etag, f.last_modified, f.last_fetch_at, f.last_status, f.last_error, f.failures,
+			f.next_retry_at, f.dead, f.next_check_at, f.check_interval, f.check_interval_source,
+			f.interval_override
+		FROM feeds f
+		WHERE EXISTS (SELECT NULL FROM podcasts p WHERE p.url = f.url AND p.subscribed)`
+
+	if failingOnly {
+		query += " AND (f.failures > 0 OR f.dead)"
+	}
+
+	query += " ORDER BY f.dead DESC, f.failures DESC, f.url"
+
+	res := []FeedStateDB{}
+
+	if err := dbctx.SelectContext(ctx, &res, query); err != nil {
+		return nil, aerr.Wrapf(err, "query feeds state failed")
+	}
+
+	states := make([]model.FeedState, len(res))
+	for i, r := range res {
+		states[i] = r.toModel()
 	}
 
+	return states, nil
+}
+
+func (s Repository) ListPodcastsByURL(ctx context.Context, podcasturl string) (model.Podcasts, error) {
+	dbctx := db.MustCtx(ctx)
+	res := []PodcastDB{}
+
+	err := dbctx.SelectContext(ctx, &res,
+		`SELECT p.id, p.user_id, p.url, coalesce(f.title, '') AS title, p.subscribed, p.created_at, p.updated_at,
+			f.metadata_updated_at, coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url
+		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
+		WHERE p.url = $1
+		ORDER BY p.user_id`,
+		podcasturl)
 	if err != nil {
-		return aerr.Wrapf(err, "update podcasts failed").WithMeta("podcast_update", update)
+		return nil, aerr.Wrapf(err, "query podcasts by url failed").WithMeta("podcast_url", podcasturl)
+	}
+
+	return podcastsFromDB(res), nil
+}
+
+func (s Repository) MovePodcastData(ctx context.Context, fromid, toid int64, timestamp time.Time) error {
+	dbctx := db.MustCtx(ctx)
+	log.Ctx(ctx).Debug().Msgf("pg.Repository: move podcast data from podcast_id=%d to podcast_id=%d", fromid, toid)
+
+	stmts := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{
+			// history of episodes known in both podcasts go to episode of target podcast
+			"move duplicated episodes history",
+			`UPDATE episodes_hist
+			SET episode_id = (
+				SELECT n.id FROM episodes n JOIN episodes o ON o.url = n.url
+				WHERE o.id = episodes_hist.episode_id AND n.podcast_id = $1
+				ORDER BY n.id LIMIT 1)
+			WHERE episode_id IN (
+				SELECT o.id FROM episodes o
+				WHERE o.podcast_id = $2
+					AND EXISTS (SELECT NULL FROM episodes n WHERE n.podcast_id = $3 AND n.url = o.url))`,
+			[]any{toid, fromid, toid},
+		},
+		{
+			"delete duplicated episodes",
+			`DELETE FROM episodes
+			WHERE podcast_id = $1
+				AND EXISTS (SELECT NULL FROM episodes n WHERE n.podcast_id = $2 AND n.url = episodes.url)`,
+			[]any{fromid, toid},
+		},
+		{
+			"move episodes",
+			"UPDATE episodes SET podcast_id = $1 WHERE podcast_id = $2",
+			[]any{toid, fromid},
+		},
+		{
+			"move settings",
+			`UPDATE settings SET podcast_id = $1
+			WHERE podcast_id = $2
+				AND NOT EXISTS (
+					SELECT NULL FROM settings s
+					WHERE s.podcast_id = $3 AND s.user_id = settings.user_id AND s.scope = settings.scope
+						AND s.episode_id IS NOT DISTINCT FROM settings.episode_id AND s.device_id IS NOT DISTINCT FROM settings.device_id
+						AND s.key = settings.key)`,
+			[]any{toid, fromid, toid},
+		},
+		{
+			// devices subscribing source podcast subscribe target podcast
+			"continue subscriptions",
+			`INSERT INTO subscriptions_hist (podcast_id, device_id, "action", created_at)
+			SELECT $1, sh.device_id, 'subscribe', $2
+			FROM subscriptions_hist sh
+			WHERE sh.podcast_id = $3 AND sh."action" = 'subscribe'
+				AND sh.id = (
+					SELECT max(sh2.id) FROM subscriptions_hist sh2
+					WHERE sh2.podcast_id = sh.podcast_id AND sh2.device_id IS NOT DISTINCT FROM sh.device_id)`,
+			[]any{toid, timestamp, fromid},
+		},
+		{
+			"end subscriptions",
+			`INSERT INTO subscriptions_hist (podcast_id, device_id, "action", created_at)
+			SELECT sh.podcast_id, sh.device_id, 'unsubscribe', $1
+			FROM subscriptions_hist sh
+			WHERE sh.podcast_id = $2 AND sh."action" = 'subscribe'
+				AND sh.id = (
+					SELECT max(sh2.id) FROM subscriptions_hist sh2
+					WHERE sh2.podcast_id = sh.podcast_id AND sh2.device_id IS NOT DISTINCT FROM sh.device_id)`,
+			[]any{timestamp, fromid},
+		},
+	}
+
+	for _, stmt := range stmts {
+		if _, err := dbctx.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return aerr.Wrapf(err, "%s failed", stmt.name).WithMeta("from_podcast_id", fromid, "to_podcast_id", toid)
+		}
+	}
+
+	return nil
+}
+
+func (s Repository) MoveFeed(ctx context.Context, oldurl, newurl string) error {
+	dbctx := db.MustCtx(ctx)
+	log.Ctx(ctx).Debug().Msgf("pg.Repository: move feed old_url=%q new_url=%q", oldurl, newurl)
+
+	stmts := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{
+			// target feed never loaded is replaced by old one
+			"delete empty target feed",
+			`DELETE FROM feeds
+			WHERE url = $1 AND metadata_updated_at IS NULL AND EXISTS (SELECT NULL FROM feeds WHERE url = $2)`,
+			[]any{newurl, oldurl},
+		},
+		{
+			"rename feed",
+			"UPDATE feeds SET url = $1 WHERE url = $2 AND NOT EXISTS (SELECT NULL FROM feeds f WHERE f.url = $3)",
+			[]any{newurl, oldurl, newurl},
+		},
+		{
+			"merge feed items",
+			`UPDATE feed_items SET feed_id = (SELECT id FROM feeds WHERE url = $1)
+			WHERE feed_id = (SELECT id FROM feeds WHERE url = $2)
+				AND url NOT IN (
+					SELECT fi.url FROM feed_items fi JOIN feeds f ON f.id = fi.feed_id WHERE f.url = $3)`,
+			[]any{newurl, oldurl, newurl},
+		},
+		{
+			"delete old feed",
+			"DELETE FROM feeds WHERE url = $1",
+			[]any{oldurl},
+		},
+		{
+			"move tags",
+			`UPDATE podcasts_tags SET url = $1
+			WHERE url = $2 AND NOT EXISTS (SELECT NULL FROM podcasts_tags t WHERE t.url = $3 AND t.tag = podcasts_tags.tag)`,
+			[]any{newurl, oldurl, newurl},
+		},
+		{
+			"delete old tags",
+			"DELETE FROM podcasts_tags WHERE url = $1",
+			[]any{oldurl},
+		},
+		{
+			"update lists",
+			"UPDATE podcast_lists_items SET url = $1 WHERE url = $2",
+			[]any{newurl, oldurl},
+		},
+		{
+			// redirects to old url point to new one; redirect loop is not allowed
+			"update redirects",
+			"UPDATE feed_redirects SET new_url = $1 WHERE new_url = $2",
+			[]any{newurl, oldurl},
+		},
+		{
+			"delete reverted redirect",
+			"DELETE FROM feed_redirects WHERE old_url = $1",
+			[]any{newurl},
+		},
+		{
+			"insert redirect",
+			`INSERT INTO feed_redirects (old_url, new_url, created_at) VALUES ($1, $2, $3)
+			ON CONFLICT (old_url) DO UPDATE SET new_url = excluded.new_url, created_at = excluded.created_at`,
+			[]any{oldurl, newurl, time.Now().UTC()},
+		},
+	}
+
+	for _, stmt := range stmts {
+		if _, err := dbctx.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return aerr.Wrapf(err, "%s failed", stmt.name).WithMeta("old_url", oldurl, "new_url", newurl)
+		}
 	}
 
 	return nil
 }
 
+func (s Repository) GetFeedRedirect(ctx context.Context, podcasturl string) (string, error) {
+	dbctx := db.MustCtx(ctx)
+
+	var newurl string
+
+	err := dbctx.GetContext(ctx, &newurl, "SELECT new_url FROM feed_redirects WHERE old_url = $1", podcasturl)
+
+	switch {
+	case err == nil:
+		return newurl, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return "", common.ErrNoData
+	default:
+		return "", aerr.Wrapf(err, "query feed redirect failed").WithMeta("podcast_url", podcasturl)
+	}
+}
+
 func (s Repository) DeletePodcast(ctx context.Context, podcastid int64) error {
 	dbctx := db.MustCtx(ctx)
 	logger := log.Ctx(ctx)
@@ -261,3 +590,193 @@ func (s Repository) DeletePodcast(ctx context.Context, podcastid int64) error {
 
 	return nil
 }
+
+func (s Repository) SearchPodcasts(ctx context.Context, text string, limit uint) (model.Podcasts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: search podcasts text=%q limit=%d", text, limit)
+
+	dbctx := db.MustCtx(ctx)
+	res := []CatalogPodcastDB{}
+
+	query := `
+		SELECT f.url, f.title,
+			coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			(SELECT count(*) FROM podcasts p WHERE p.url = f.url AND p.subscribed) AS subscribers
+		FROM feeds f
+		WHERE f.search_vector @@ plainto_tsquery('simple', $1)
+		ORDER BY subscribers DESC, f.title, f.url`
+	args := []any{text}
+
+	if limit > 0 {
+		query += " LIMIT $2"
+		args = append(args, limit) //nolint:wsl_v5
+	}
+
+	if err := dbctx.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, aerr.Wrapf(err, "search podcasts failed").WithMeta("text", text)
+	}
+
+	return catalogPodcastsFromDB(res), nil
+}
+
+func (s Repository) ListTopPodcasts(ctx context.Context, limit uint) (model.Podcasts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: list top podcasts limit=%d", limit)
+
+	dbctx := db.MustCtx(ctx)
+	res := []CatalogPodcastDB{}
+
+	err := dbctx.SelectContext(ctx, &res, `
+		SELECT f.url, f.title,
+			coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			count(*) AS subscribers
+		FROM feeds f
+		JOIN podcasts p ON p.url = f.url
+		WHERE p.subscribed
+		GROUP BY f.id
+		ORDER BY subscribers DESC, f.title, f.url
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query top podcasts failed")
+	}
+
+	return catalogPodcastsFromDB(res), nil
+}
+
+func (s Repository) ListSuggestedPodcasts(ctx context.Context, userid int64, limit uint) (model.Podcasts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).
+		Msgf("pg.Repository: list suggested podcasts user_id=%d limit=%d", userid, limit)
+
+	dbctx := db.MustCtx(ctx)
+	res := []CatalogPodcastDB{}
+
+	// podcasts are ranked by number of users that share any subscription with user.
+	err := dbctx.SelectContext(ctx, &res, `
+		WITH mine AS (
+			SELECT url FROM podcasts WHERE user_id = $1 AND subscribed
+		), similar AS (
+			SELECT DISTINCT user_id FROM podcasts
+			WHERE subscribed AND user_id != $1 AND url IN (SELECT url FROM mine)
+		)
+		SELECT f.url, f.title,
+			coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			count(*) AS subscribers
+		FROM feeds f
+		JOIN podcasts p ON p.url = f.url
+		WHERE p.subscribed AND f.url NOT IN (SELECT url FROM mine)
+		GROUP BY f.id
+		HAVING count(*) FILTER (WHERE p.user_id IN (SELECT user_id FROM similar)) > 0
+		ORDER BY count(*) FILTER (WHERE p.user_id IN (SELECT user_id FROM similar)) DESC,
+			subscribers DESC, f.title, f.url
+		LIMIT $2`, userid, limit)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query suggested podcasts failed").WithMeta("user_id", userid)
+	}
+
+	return catalogPodcastsFromDB(res), nil
+}
+
+func (s Repository) GetCatalogPodcast(ctx context.Context, podcasturl string) (*model.Podcast, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Str("podcast_url", podcasturl).
+		Msgf("pg.Repository: get catalog podcast podcast_url=%q", podcasturl)
+
+	dbctx := db.MustCtx(ctx)
+	res := CatalogPodcastDB{}
+
+	err := dbctx.GetContext(ctx, &res, `
+		SELECT f.url, f.title,
+			coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			(SELECT count(*) FROM podcasts p WHERE p.url = f.url AND p.subscribed) AS subscribers
+		FROM feeds f
+		WHERE f.url = $1`, podcasturl)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, common.ErrNoData
+	} else if err != nil {
+		return nil, aerr.Wrapf(err, "query catalog podcast failed").WithMeta("podcast_url", podcasturl)
+	}
+
+	podcast := res.toModel()
+
+	return &podcast, nil
+}
+
+func (s Repository) ListTags(ctx context.Context, limit uint) ([]model.PodcastTag, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("pg.Repository: list tags limit=%d", limit)
+
+	dbctx := db.MustCtx(ctx)
+	res := []PodcastTagDB{}
+
+	err := dbctx.SelectContext(ctx, &res, `
+		SELECT t.tag, max(t.title) AS title, count(DISTINCT t.url) AS usage
+		FROM podcasts_tags t
+		WHERE t.url IN (SELECT url FROM podcasts WHERE subscribed)
+		GROUP BY t.tag
+		ORDER BY count(DISTINCT t.url) DESC, t.tag
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query tags failed")
+	}
+
+	tags := make([]model.PodcastTag, len(res))
+	for i, t := range res {
+		tags[i] = t.toModel()
+	}
+
+	return tags, nil
+}
+
+func (s Repository) ListPodcastsByTag(ctx context.Context, tag string, limit uint) (model.Podcasts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Str("tag", tag).Msgf("pg.Repository: list podcasts by tag=%q limit=%d", tag, limit)
+
+	dbctx := db.MustCtx(ctx)
+	res := []CatalogPodcastDB{}
+
+	err := dbctx.SelectContext(ctx, &res, `
+		SELECT f.url, f.title,
+			coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			count(*) AS subscribers
+		FROM feeds f
+		JOIN podcasts p ON p.url = f.url
+		WHERE p.subscribed AND f.url IN (SELECT url FROM podcasts_tags WHERE tag = $1)
+		GROUP BY f.id
+		ORDER BY subscribers DESC, f.title, f.url
+		LIMIT $2`, tag, limit)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query podcasts by tag failed").WithMeta("tag", tag)
+	}
+
+	return catalogPodcastsFromDB(res), nil
+}
+
+func (s Repository) ListPodcastsTags(ctx context.Context, userid int64) (map[string]model.PodcastTags, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("pg.Repository: list podcasts tags user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+	res := []PodcastTagDB{}
+
+	err := dbctx.SelectContext(ctx, &res, `
+		SELECT t.url, t.tag, t.title
+		FROM podcasts_tags t
+		WHERE t.url IN (SELECT url FROM podcasts WHERE user_id = $1)
+		ORDER BY t.url, t.title`, userid)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query podcasts tags failed").WithMeta("user_id", userid)
+	}
+
+	tags := make(map[string]model.PodcastTags)
+	for _, t := range res {
+		tags[t.URL] = append(tags[t.URL], t.toModel())
+	}
+
+	return tags, nil
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -28,7 +29,8 @@ func (s Repository) GetUser(ctx context.Context, username string) (*model.User,
 	user := UserDB{}
 
 	err := dbctx.GetContext(ctx, &user, `
-		SELECT id, username, password, email, name, created_at, updated_at
+		SELECT id, username, password, email, name, admin, totp_secret, totp_recovery_codes, totp_last_step,
+			created_at, updated_at
 		FROM users
 		WHERE username=$1`,
 		username)
@@ -53,10 +55,10 @@ func (s Repository) SaveUser(ctx context.Context, user *model.User) (int64, erro
 		var id int64
 
 		err := dbctx.GetContext(ctx, &id, `
-			INSERT INTO users (username, password, email, name, created_at, updated_at)
-				VALUES($1, $2, $3, $4, $5, $6)
+			INSERT INTO users (username, password, email, name, admin, created_at, updated_at)
+				VALUES($1, $2, $3, $4, $5, $6, $7)
 			RETURNING id`,
-			user.UserName, user.Password, user.Email, user.Name, time.Now().UTC(), time.Now().UTC())
+			user.UserName, user.Password, user.Email, user.Name, user.Admin, time.Now().UTC(), time.Now().UTC())
 		if err != nil {
 			return 0, aerr.Wrapf(err, "insert user failed").WithTag(aerr.InternalError)
 		}
@@ -68,8 +70,10 @@ func (s Repository) SaveUser(ctx context.Context, user *model.User) (int64, erro
 	logger.Debug().Object("user", user).Msgf("pg.Repository: update user user_name=%s", user.UserName)
 
 	_, err := dbctx.ExecContext(ctx,
-		"UPDATE users SET password=$1, email=$2, name=$3, updated_at=$4 WHERE id=$5",
-		user.Password, user.Email, user.Name, time.Now().UTC(), user.ID)
+		"UPDATE users SET password=$1, email=$2, name=$3, admin=$4, totp_secret=$5, totp_recovery_codes=$6, "+
+			"updated_at=$7 WHERE id=$8",
+		user.Password, user.Email, user.Name, user.Admin, user.TOTPSecret, strings.Join(user.TOTPRecoveryCodes, " "),
+		time.Now().UTC(), user.ID)
 	if err != nil {
 		return 0, aerr.Wrapf(err, "update user failed").WithTag(aerr.InternalError)
 	}
@@ -84,7 +88,8 @@ func (s Repository) ListUsers(ctx context.Context, activeOnly bool) ([]model.Use
 
 	var users []UserDB
 
-	sql := "SELECT id, username, password, email, name, created_at, updated_at FROM users"
+	sql := "SELECT id, username, password, email, name, admin, totp_secret, totp_recovery_codes, totp_last_step, " +
+		"created_at, updated_at FROM users"
 	if activeOnly {
 		sql += " WHERE password != 'LOCKED'"
 	}
@@ -101,6 +106,33 @@ func (s Repository) ListUsers(ctx context.Context, activeOnly bool) ([]model.Use
 	return usersFromDB(users), nil
 }
 
+// ListUsersWithCounts get all users with number of devices and subscribed podcasts.
+func (s Repository) ListUsersWithCounts(ctx context.Context) ([]model.UserWithCounts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("pg.Repository: list users with counts")
+
+	var users []UserWithCountsDB
+
+	dbctx := db.MustCtx(ctx)
+
+	err := dbctx.SelectContext(ctx, &users,
+		"SELECT u.id, u.username, u.password, u.email, u.name, u.admin, u.totp_secret, u.totp_recovery_codes, "+
+			"u.totp_last_step, u.created_at, u.updated_at, "+
+			"(SELECT count(*) FROM devices d WHERE d.user_id=u.id) AS devices, "+
+			"(SELECT count(*) FROM podcasts p WHERE p.user_id=u.id AND p.subscribed) AS subscriptions "+
+			"FROM users u ORDER BY u.username")
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select users failed").WithTag(aerr.InternalError)
+	}
+
+	res := make([]model.UserWithCounts, len(users))
+	for i, u := range users {
+		res[i] = u.toModel()
+	}
+
+	return res, nil
+}
+
 // DeleteUser and all related objects.
 func (s Repository) DeleteUser(ctx context.Context, userid int64) error {
 	logger := log.Ctx(ctx)
@@ -124,6 +156,40 @@ func (s Repository) DeleteUser(ctx context.Context, userid int64) error {
 		return aerr.Wrapf(err, "delete episodes failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
 	}
 
+	_, err = dbctx.ExecContext(ctx,
+		"DELETE FROM subscriptions_hist WHERE podcast_id IN (SELECT id FROM podcasts WHERE user_id=$1)",
+		userid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete subscriptions_hist failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	_, err = dbctx.ExecContext(ctx,
+		"DELETE FROM podcast_lists_items WHERE list_id IN (SELECT id FROM podcast_lists WHERE user_id=$1)",
+		userid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete podcast_lists_items failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM podcast_lists WHERE user_id=$1", userid); err != nil {
+		return aerr.Wrapf(err, "delete podcast_lists failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM app_tokens WHERE user_id=$1", userid); err != nil {
+		return aerr.Wrapf(err, "delete app_tokens failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM remember_tokens WHERE user_id=$1", userid); err != nil {
+		return aerr.Wrapf(err, "delete remember_tokens failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM user_registrations WHERE user_id=$1", userid); err != nil {
+		return aerr.Wrapf(err, "delete user_registrations failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
 	if _, err := dbctx.ExecContext(ctx, "DELETE FROM settings WHERE user_id=$1", userid); err != nil {
 		return aerr.Wrapf(err, "delete settings failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
 	}
@@ -142,3 +208,68 @@ func (s Repository) DeleteUser(ctx context.Context, userid int64) error {
 
 	return nil
 }
+
+func (s Repository) UpdateTOTPLastStep(ctx context.Context, userid, step int64) (bool, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).
+		Msgf("pg.Repository: update totp last step user_id=%d step=%d", userid, step)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"UPDATE users SET totp_last_step=$1 WHERE id=$2 AND totp_last_step < $3",
+		step, userid, step)
+	if err != nil {
+		return false, aerr.Wrapf(err, "update totp last step failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, aerr.Wrapf(err, "update totp last step failed get affected rows").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	return cnt == 1, nil
+}
+
+func (s Repository) LockUser(ctx context.Context, userid int64) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("pg.Repository: lock user user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+
+	// password of already locked account is kept
+	_, err := dbctx.ExecContext(ctx,
+		"UPDATE users SET locked_password=password, password=$1, updated_at=$2 "+
+			"WHERE id=$3 AND password <> $4",
+		model.UserLockedPassword, time.Now().UTC(), userid, model.UserLockedPassword)
+	if err != nil {
+		return aerr.Wrapf(err, "lock user failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	return nil
+}
+
+func (s Repository) UnlockUser(ctx context.Context, userid int64) (bool, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("pg.Repository: unlock user user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"UPDATE users SET password=locked_password, locked_password='', updated_at=$1 "+
+			"WHERE id=$2 AND password=$3 AND locked_password <> ''",
+		time.Now().UTC(), userid, model.UserLockedPassword)
+	if err != nil {
+		return false, aerr.Wrapf(err, "unlock user failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, aerr.Wrapf(err, "unlock user failed get affected rows").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	return cnt == 1, nil
+}
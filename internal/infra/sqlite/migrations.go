@@ -0,0 +1,212 @@
+package sqlite
+
+//
+// migrations.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// goMigrations return migrations that depend on features of sqlite library and can't be
+// written as plain sql scripts.
+func goMigrations() []*goose.Migration {
+	return []*goose.Migration{
+		goose.NewGoMigration(20260212090000,
+			&goose.GoFunc{RunTx: upPodcastsSearch},
+			&goose.GoFunc{RunTx: downPodcastsSearch}),
+		goose.NewGoMigration(20260226100000,
+			&goose.GoFunc{RunTx: upFeeds},
+			&goose.GoFunc{RunTx: downFeeds}),
+	}
+}
+
+// upPodcastsSearch create full text index for podcasts.
+func upPodcastsSearch(ctx context.Context, tx *sql.Tx) error {
+	return createSearchIndex(ctx, tx, "podcasts")
+}
+
+func downPodcastsSearch(ctx context.Context, tx *sql.Tx) error {
+	return dropSearchIndex(ctx, tx, "podcasts")
+}
+
+// upFeeds move podcasts metadata into global, shared between users `feeds` table. Full text
+// index is moved to feeds.
+func upFeeds(ctx context.Context, tx *sql.Tx) error {
+	if err := dropSearchIndex(ctx, tx, "podcasts"); err != nil {
+		return err
+	}
+
+	stmts := []string{
+		`CREATE TABLE feeds (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url VARCHAR NOT NULL UNIQUE,
+			title VARCHAR NOT NULL,
+			description TEXT,
+			website TEXT,
+			logo_url TEXT,
+			metadata_updated_at TIMESTAMP,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX feeds_meta_updated_at ON feeds (metadata_updated_at)`,
+		// for each url use the most recently updated podcast
+		`INSERT INTO feeds (url, title, description, website, logo_url, metadata_updated_at, created_at, updated_at)
+		SELECT p.url, p.title, p.description, p.website, p.logo_url, p.metadata_updated_at, p.created_at, p.updated_at
+		FROM podcasts p
+		WHERE p.id = (
+			SELECT p2.id FROM podcasts p2 WHERE p2.url = p.url
+			ORDER BY p2.metadata_updated_at IS NULL, p2.metadata_updated_at DESC, p2.id
+			LIMIT 1
+		)`,
+		`DROP INDEX IF EXISTS podcasts_meta_updated_at`,
+		`ALTER TABLE podcasts DROP COLUMN title`,
+		`ALTER TABLE podcasts DROP COLUMN description`,
+		`ALTER TABLE podcasts DROP COLUMN website`,
+		`ALTER TABLE podcasts DROP COLUMN logo_url`,
+		`ALTER TABLE podcasts DROP COLUMN metadata_updated_at`,
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create feeds error: %w", err)
+		}
+	}
+
+	return createSearchIndex(ctx, tx, "feeds")
+}
+
+func downFeeds(ctx context.Context, tx *sql.Tx) error {
+	if err := dropSearchIndex(ctx, tx, "feeds"); err != nil {
+		return err
+	}
+
+	stmts := []string{
+		`ALTER TABLE podcasts ADD title VARCHAR NOT NULL DEFAULT ''`,
+		`ALTER TABLE podcasts ADD description TEXT`,
+		`ALTER TABLE podcasts ADD website TEXT`,
+		`ALTER TABLE podcasts ADD logo_url TEXT`,
+		`ALTER TABLE podcasts ADD metadata_updated_at TIMESTAMP`,
+		`UPDATE podcasts
+		SET (title, description, website, logo_url, metadata_updated_at) = (
+			SELECT f.title, f.description, f.website, f.logo_url, f.metadata_updated_at
+			FROM feeds f WHERE f.url = podcasts.url
+		)
+		WHERE url IN (SELECT url FROM feeds)`,
+		`CREATE INDEX podcasts_meta_updated_at ON podcasts (metadata_updated_at)`,
+		`DROP TABLE feeds`,
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("drop feeds error: %w", err)
+		}
+	}
+
+	return createSearchIndex(ctx, tx, "podcasts")
+}
+
+//------------------------------------------------------------------------------
+
+// createSearchIndex create full text index `<table>_fts` for title, description and url of
+// `table`. FTS5 is available only when go-sqlite3 is build with `sqlite_fts5` tag; otherwise
+// FTS4 is used (see README).
+func createSearchIndex(ctx context.Context, tx *sql.Tx, table string) error {
+	var fts5 bool
+
+	err := tx.QueryRowContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5)
+	if err != nil {
+		return fmt.Errorf("check sqlite compile options error: %w", err)
+	}
+
+	if !fts5 {
+		log.Ctx(ctx).Warn().
+			Msgf("sqlite: FTS5 is not available (binary build without sqlite_fts5 tag); using FTS4 for %s", table)
+	}
+
+	return createSearchIndexFTS(ctx, tx, table, fts5)
+}
+
+// createSearchIndexFTS create full text index `<table>_fts` using FTS5 (when `fts5` is true) or FTS4.
+func createSearchIndexFTS(ctx context.Context, tx *sql.Tx, table string, fts5 bool) error {
+	var stmts []string
+
+	if fts5 {
+		stmts = []string{
+			`CREATE VIRTUAL TABLE {t}_fts USING fts5(title, description, url,
+				content='{t}', content_rowid='id', tokenize='unicode61 remove_diacritics 2')`,
+			`CREATE TRIGGER {t}_fts_ai AFTER INSERT ON {t} BEGIN
+				INSERT INTO {t}_fts(rowid, title, description, url)
+				VALUES (new.id, new.title, new.description, new.url);
+			END`,
+			`CREATE TRIGGER {t}_fts_ad AFTER DELETE ON {t} BEGIN
+				INSERT INTO {t}_fts({t}_fts, rowid, title, description, url)
+				VALUES ('delete', old.id, old.title, old.description, old.url);
+			END`,
+			`CREATE TRIGGER {t}_fts_au AFTER UPDATE OF title, description, url ON {t} BEGIN
+				INSERT INTO {t}_fts({t}_fts, rowid, title, description, url)
+				VALUES ('delete', old.id, old.title, old.description, old.url);
+				INSERT INTO {t}_fts(rowid, title, description, url)
+				VALUES (new.id, new.title, new.description, new.url);
+			END`,
+		}
+	} else {
+		// fts4 with external content require removing entries before content is changed.
+		stmts = []string{
+			`CREATE VIRTUAL TABLE {t}_fts USING fts4(title, description, url,
+				content="{t}", tokenize=unicode61 "remove_diacritics=2")`,
+			`CREATE TRIGGER {t}_fts_ai AFTER INSERT ON {t} BEGIN
+				INSERT INTO {t}_fts(docid, title, description, url)
+				VALUES (new.id, new.title, new.description, new.url);
+			END`,
+			`CREATE TRIGGER {t}_fts_bd BEFORE DELETE ON {t} BEGIN
+				DELETE FROM {t}_fts WHERE docid = old.id;
+			END`,
+			`CREATE TRIGGER {t}_fts_bu BEFORE UPDATE OF title, description, url ON {t} BEGIN
+				DELETE FROM {t}_fts WHERE docid = old.id;
+			END`,
+			`CREATE TRIGGER {t}_fts_au AFTER UPDATE OF title, description, url ON {t} BEGIN
+				INSERT INTO {t}_fts(docid, title, description, url)
+				VALUES (new.id, new.title, new.description, new.url);
+			END`,
+		}
+	}
+
+	stmts = append(stmts, `INSERT INTO {t}_fts({t}_fts) VALUES ('rebuild')`)
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, strings.ReplaceAll(stmt, "{t}", table)); err != nil {
+			return fmt.Errorf("create %s search index error: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+func dropSearchIndex(ctx context.Context, tx *sql.Tx, table string) error {
+	stmts := []string{
+		"DROP TRIGGER IF EXISTS {t}_fts_ai",
+		"DROP TRIGGER IF EXISTS {t}_fts_ad",
+		"DROP TRIGGER IF EXISTS {t}_fts_bd",
+		"DROP TRIGGER IF EXISTS {t}_fts_bu",
+		"DROP TRIGGER IF EXISTS {t}_fts_au",
+		"DROP TABLE IF EXISTS {t}_fts",
+	}
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, strings.ReplaceAll(stmt, "{t}", table)); err != nil {
+			return fmt.Errorf("drop %s search index error: %w", table, err)
+		}
+	}
+
+	return nil
+}
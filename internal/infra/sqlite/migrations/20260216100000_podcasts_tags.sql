@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE podcasts_tags (
+	url VARCHAR NOT NULL,
+	tag VARCHAR NOT NULL,
+	title VARCHAR NOT NULL,
+	CONSTRAINT podcasts_tags_pkey PRIMARY KEY (url, tag)
+);
+
+CREATE INDEX podcasts_tags_tag_idx ON podcasts_tags(tag);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE podcasts_tags;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE invite_codes (
+	code VARCHAR NOT NULL PRIMARY KEY,
+	created_by VARCHAR NOT NULL DEFAULT '',
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMP,
+	used_by VARCHAR NOT NULL DEFAULT '',
+	used_at TIMESTAMP
+);
+
+CREATE TABLE user_registrations (
+	user_id INTEGER NOT NULL PRIMARY KEY,
+	password VARCHAR NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT user_registrations_user_id_fkey FOREIGN KEY (user_id)
+		REFERENCES users(id)
+		ON DELETE CASCADE ON UPDATE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_registrations;
+DROP TABLE invite_codes;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_log (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	user_name VARCHAR NOT NULL DEFAULT '',
+	action VARCHAR NOT NULL,
+	ip VARCHAR NOT NULL DEFAULT '',
+	user_agent VARCHAR NOT NULL DEFAULT '',
+	details VARCHAR NOT NULL DEFAULT ''
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX audit_log_user_name_idx ON audit_log (user_name, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_log;
+-- +goose StatementEnd
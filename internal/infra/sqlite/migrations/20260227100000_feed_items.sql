@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE feed_items (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	feed_id INTEGER NOT NULL,
+	url VARCHAR NOT NULL,
+	guid VARCHAR,
+	title VARCHAR NOT NULL,
+	description TEXT,
+	link TEXT,
+	image_url TEXT,
+	published_at TIMESTAMP,
+	duration INTEGER,
+	enclosure_size INTEGER,
+	enclosure_type VARCHAR,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+	FOREIGN KEY (feed_id) REFERENCES feeds (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX feed_items_feed_id_url_idx ON feed_items (feed_id, url);
+CREATE INDEX feed_items_published_at_idx ON feed_items (feed_id, published_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE feed_items;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE feeds ADD COLUMN etag VARCHAR;
+ALTER TABLE feeds ADD COLUMN last_modified VARCHAR;
+ALTER TABLE feeds ADD COLUMN last_fetch_at TIMESTAMP;
+ALTER TABLE feeds ADD COLUMN last_status INTEGER;
+ALTER TABLE feeds ADD COLUMN last_error TEXT;
+ALTER TABLE feeds ADD COLUMN failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE feeds ADD COLUMN next_retry_at TIMESTAMP;
+ALTER TABLE feeds ADD COLUMN dead BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE feeds DROP COLUMN etag;
+ALTER TABLE feeds DROP COLUMN last_modified;
+ALTER TABLE feeds DROP COLUMN last_fetch_at;
+ALTER TABLE feeds DROP COLUMN last_status;
+ALTER TABLE feeds DROP COLUMN last_error;
+ALTER TABLE feeds DROP COLUMN failures;
+ALTER TABLE feeds DROP COLUMN next_retry_at;
+ALTER TABLE feeds DROP COLUMN dead;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE feeds ADD COLUMN next_check_at TIMESTAMP;
+ALTER TABLE feeds ADD COLUMN check_interval INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE feeds ADD COLUMN check_interval_source VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE feeds ADD COLUMN interval_override INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX feeds_next_check_at_idx ON feeds (next_check_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX feeds_next_check_at_idx;
+
+ALTER TABLE feeds DROP COLUMN next_check_at;
+ALTER TABLE feeds DROP COLUMN check_interval;
+ALTER TABLE feeds DROP COLUMN check_interval_source;
+ALTER TABLE feeds DROP COLUMN interval_override;
+-- +goose StatementEnd
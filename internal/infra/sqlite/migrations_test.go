@@ -0,0 +1,109 @@
+package sqlite
+
+//
+// migrations_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"gitlab.com/kabes/go-gpo/internal/assert"
+)
+
+func TestCreateSearchIndex(t *testing.T) {
+	for _, fts5 := range []bool{false, true} {
+		name := "fts4"
+		if fts5 {
+			name = "fts5"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			testCreateSearchIndex(t, fts5)
+		})
+	}
+}
+
+func testCreateSearchIndex(t *testing.T, fts5 bool) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoErr(t, err)
+
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	var available bool
+
+	err = db.QueryRowContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&available)
+	assert.NoErr(t, err)
+
+	if fts5 && !available {
+		t.Skip("sqlite build without FTS5; run tests with sqlite_fts5 tag")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoErr(t, err)
+
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	_, err = tx.ExecContext(ctx, `CREATE TABLE feeds (id INTEGER PRIMARY KEY, title VARCHAR, description TEXT,
+		url VARCHAR)`)
+	assert.NoErr(t, err)
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO feeds (id, title, description, url)
+		VALUES (1, 'Linux weekly', 'About penguins', 'http://example.com/p1'),
+			(2, 'Café', 'Cooking', 'http://example.com/p2')`)
+	assert.NoErr(t, err)
+
+	err = createSearchIndexFTS(ctx, tx, "feeds", fts5)
+	assert.NoErr(t, err)
+
+	// index is updated by triggers
+	_, err = tx.ExecContext(ctx, `INSERT INTO feeds (id, title, description, url)
+		VALUES (3, 'Penguins news', '', 'http://other.org/feed')`)
+	assert.NoErr(t, err)
+
+	_, err = tx.ExecContext(ctx, `UPDATE feeds SET description = 'Desktops' WHERE id = 1`)
+	assert.NoErr(t, err)
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM feeds WHERE id = 2`)
+	assert.NoErr(t, err)
+
+	search := func(text string) []int64 {
+		t.Helper()
+
+		rows, err := tx.QueryContext(ctx,
+			"SELECT rowid FROM feeds_fts WHERE feeds_fts MATCH ? ORDER BY rowid", text)
+		assert.NoErr(t, err)
+
+		defer rows.Close()
+
+		var ids []int64
+
+		for rows.Next() {
+			var id int64
+
+			assert.NoErr(t, rows.Scan(&id))
+
+			ids = append(ids, id)
+		}
+
+		assert.NoErr(t, rows.Err())
+
+		return ids
+	}
+
+	assert.Equal(t, search("linux"), []int64{1})
+	assert.Equal(t, search("penguins"), []int64{3})
+	assert.Equal(t, search("desktops"), []int64{1})
+	assert.Equal(t, search("example"), []int64{1})
+	assert.Equal(t, search("cafe"), nil)
+}
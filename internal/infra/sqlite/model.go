@@ -7,7 +7,7 @@ package sqlite
 
 import (
 	"database/sql"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -19,13 +19,14 @@ import (
 //----------------------------------------
 
 type DeviceDB struct {
-	ID        int64     `db:"id"`
-	UserID    int64     `db:"user_id"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
-	Name      string    `db:"name"`
-	DevType   string    `db:"dev_type"`
-	Caption   string    `db:"caption"`
+	ID        int64         `db:"id"`
+	UserID    int64         `db:"user_id"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+	Name      string        `db:"name"`
+	DevType   string        `db:"dev_type"`
+	Caption   string        `db:"caption"`
+	SyncGroup sql.NullInt64 `db:"sync_group"`
 
 	Subscriptions int `db:"subscriptions"`
 
@@ -40,7 +41,8 @@ func (d *DeviceDB) MarshalZerologObject(event *zerolog.Event) {
 		Str("caption", d.Caption).
 		Time("created_at", d.CreatedAt).
 		Time("updated_at", d.UpdatedAt).
-		Int("subscriptions", d.Subscriptions)
+		Int("subscriptions", d.Subscriptions).
+		Int64("sync_group", d.SyncGroup.Int64)
 
 	if d.User != nil {
 		event.Object("user", d.User)
@@ -59,6 +61,7 @@ func (d *DeviceDB) toModel() *model.Device {
 		DevType:       d.DevType,
 		Caption:       d.Caption,
 		Subscriptions: d.Subscriptions,
+		SyncGroup:     d.SyncGroup.Int64,
 		UpdatedAt:     d.UpdatedAt,
 		User:          user,
 	}
@@ -87,6 +90,8 @@ type PodcastDB struct {
 	URL           string       `db:"url"`
 	Description   string       `db:"description"`
 	Website       string       `db:"website"`
+	LogoURL       string       `db:"logo_url"`
+	Subscribers   int          `db:"subscribers"`
 
 	Subscribed bool `db:"subscribed"`
 }
@@ -97,8 +102,10 @@ func (p *PodcastDB) MarshalZerologObject(event *zerolog.Event) {
 		Str("title", p.Title).
 		Str("url", p.URL).
 		Str("website", p.Website).
+		Str("logo_url", p.LogoURL).
 		Str("description", p.Description).
 		Bool("subscribed", p.Subscribed).
+		Int("subscribers", p.Subscribers).
 		Time("created_at", p.CreatedAt).
 		Time("updated_at", p.UpdatedAt).
 		Time("metadata_updated_at", p.MetaUpdatedAt.Time)
@@ -111,8 +118,10 @@ func (p *PodcastDB) toModel() *model.Podcast {
 		URL:         p.URL,
 		Description: p.Description,
 		Website:     p.Website,
+		LogoURL:     p.LogoURL,
 		UpdatedAt:   p.UpdatedAt,
 		Subscribed:  p.Subscribed,
+		Subscribers: p.Subscribers,
 		User:        &model.User{ID: p.UserID},
 	}
 }
@@ -128,6 +137,232 @@ func podcastsFromDB(podcasts []PodcastDB) []model.Podcast {
 
 //------------------------------------------------------------------------------
 
+// CatalogPodcastDB is podcast aggregated over all users.
+type CatalogPodcastDB struct {
+	Title       string `db:"title"`
+	URL         string `db:"url"`
+	Description string `db:"description"`
+	Website     string `db:"website"`
+	LogoURL     string `db:"logo_url"`
+	Subscribers int    `db:"subscribers"`
+}
+
+func (p *CatalogPodcastDB) toModel() model.Podcast {
+	return model.Podcast{
+		Title:       p.Title,
+		URL:         p.URL,
+		Description: p.Description,
+		Website:     p.Website,
+		LogoURL:     p.LogoURL,
+		Subscribers: p.Subscribers,
+	}
+}
+
+func catalogPodcastsFromDB(podcasts []CatalogPodcastDB) model.Podcasts {
+	res := make(model.Podcasts, len(podcasts))
+	for i, r := range podcasts {
+		res[i] = r.toModel()
+	}
+
+	return res
+}
+
+//------------------------------------------------------------------------------
+
+type PodcastTagDB struct {
+	URL   string `db:"url"`
+	Tag   string `db:"tag"`
+	Title string `db:"title"`
+	Usage int    `db:"usage"`
+}
+
+func (p *PodcastTagDB) toModel() model.PodcastTag {
+	return model.PodcastTag{
+		Tag:   p.Tag,
+		Title: p.Title,
+		Usage: p.Usage,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type AppTokenDB struct {
+	ID         int64        `db:"id"`
+	UserID     int64        `db:"user_id"`
+	CreatedAt  time.Time    `db:"created_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	Name       string       `db:"name"`
+	Token      string       `db:"token"`
+	DeviceName string       `db:"device_name"`
+	ReadOnly   bool         `db:"read_only"`
+}
+
+func (a *AppTokenDB) MarshalZerologObject(event *zerolog.Event) {
+	event.Int64("id", a.ID).
+		Int64("user_id", a.UserID).
+		Str("name", a.Name).
+		Str("device_name", a.DeviceName).
+		Bool("read_only", a.ReadOnly).
+		Time("created_at", a.CreatedAt).
+		Any("last_used_at", a.LastUsedAt)
+}
+
+func (a *AppTokenDB) toModel() model.AppToken {
+	return model.AppToken{
+		ID:         a.ID,
+		User:       &model.User{ID: a.UserID},
+		Name:       a.Name,
+		Token:      a.Token,
+		DeviceName: a.DeviceName,
+		ReadOnly:   a.ReadOnly,
+		CreatedAt:  a.CreatedAt,
+		LastUsedAt: a.LastUsedAt.Time,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type RememberTokenDB struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+	Token     string    `db:"token"`
+	UserName  string    `db:"username"`
+}
+
+func (r *RememberTokenDB) toModel() *model.RememberToken {
+	return &model.RememberToken{
+		ID:        r.ID,
+		User:      &model.User{ID: r.UserID, UserName: r.UserName},
+		Token:     r.Token,
+		CreatedAt: r.CreatedAt,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type LoginFailureDB struct {
+	LastFailureAt time.Time    `db:"last_failure_at"`
+	LockedUntil   sql.NullTime `db:"locked_until"`
+	Kind          string       `db:"kind"`
+	Subject       string       `db:"subject"`
+	Failures      int          `db:"failures"`
+}
+
+func (l *LoginFailureDB) toModel() model.LoginFailure {
+	return model.LoginFailure{
+		Kind:          l.Kind,
+		Subject:       l.Subject,
+		Failures:      l.Failures,
+		LastFailureAt: l.LastFailureAt,
+		LockedUntil:   l.LockedUntil.Time,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type AuditEventDB struct {
+	CreatedAt time.Time `db:"created_at"`
+	UserName  string    `db:"user_name"`
+	Action    string    `db:"action"`
+	IP        string    `db:"ip"`
+	UserAgent string    `db:"user_agent"`
+	Details   string    `db:"details"`
+	ID        int64     `db:"id"`
+}
+
+func (a *AuditEventDB) toModel() model.AuditEvent {
+	return model.AuditEvent{
+		ID:        a.ID,
+		CreatedAt: a.CreatedAt,
+		UserName:  a.UserName,
+		Action:    a.Action,
+		IP:        a.IP,
+		UserAgent: a.UserAgent,
+		Details:   a.Details,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type InviteCodeDB struct {
+	CreatedAt time.Time    `db:"created_at"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+	UsedAt    sql.NullTime `db:"used_at"`
+	Code      string       `db:"code"`
+	CreatedBy string       `db:"created_by"`
+	UsedBy    string       `db:"used_by"`
+}
+
+func (i *InviteCodeDB) toModel() model.InviteCode {
+	return model.InviteCode{
+		Code:      i.Code,
+		CreatedBy: i.CreatedBy,
+		CreatedAt: i.CreatedAt,
+		ExpiresAt: i.ExpiresAt.Time,
+		UsedBy:    i.UsedBy,
+		UsedAt:    i.UsedAt.Time,
+	}
+}
+
+type UserRegistrationDB struct {
+	CreatedAt time.Time `db:"created_at"`
+	UserID    int64     `db:"user_id"`
+	Password  string    `db:"password"`
+	UserName  string    `db:"username"`
+	Email     string    `db:"email"`
+	Name      string    `db:"name"`
+}
+
+func (u *UserRegistrationDB) toModel() model.UserRegistration {
+	return model.UserRegistration{
+		User: &model.User{
+			ID:       u.UserID,
+			UserName: u.UserName,
+			Email:    u.Email,
+			Name:     u.Name,
+			Locked:   true,
+		},
+		Password:  u.Password,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type PodcastListDB struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Name      string    `db:"name"`
+	Title     string    `db:"title"`
+}
+
+func (p *PodcastListDB) MarshalZerologObject(event *zerolog.Event) {
+	event.Int64("id", p.ID).
+		Int64("user_id", p.UserID).
+		Str("name", p.Name).
+		Str("title", p.Title).
+		Time("created_at", p.CreatedAt).
+		Time("updated_at", p.UpdatedAt)
+}
+
+func (p *PodcastListDB) toModel() model.PodcastList {
+	return model.PodcastList{
+		ID:        p.ID,
+		User:      &model.User{ID: p.UserID},
+		Name:      p.Name,
+		Title:     p.Title,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+//------------------------------------------------------------------------------
+
 type EpisodeDB struct {
 	ID        int64          `db:"id"`
 	PodcastID int64          `db:"podcast_id"`
@@ -142,6 +377,15 @@ type EpisodeDB struct {
 	Position  sql.NullInt32  `db:"position"`
 	Total     sql.NullInt32  `db:"total"`
 
+	// metadata from feed item
+	Released      sql.NullTime `db:"released"`
+	Description   string       `db:"description"`
+	Link          string       `db:"link"`
+	ImageURL      string       `db:"image_url"`
+	EnclosureType string       `db:"enclosure_type"`
+	EnclosureSize int64        `db:"enclosure_size"`
+	Duration      int32        `db:"duration"`
+
 	Podcast *PodcastDB `db:"podcast"`
 	Device  *DeviceDB  `db:"device"`
 }
@@ -165,7 +409,8 @@ func (e *EpisodeDB) MarshalZerologObject(event *zerolog.Event) {
 
 func (e *EpisodeDB) toModel() *model.Episode {
 	var device *model.Device
-	if e.Device != nil {
+	// episodes may be saved without device
+	if e.Device != nil && e.DeviceID.Valid {
 		device = e.Device.toModel()
 	}
 
@@ -180,6 +425,14 @@ func (e *EpisodeDB) toModel() *model.Episode {
 		Started:   nil,
 		Position:  nil,
 		Total:     nil,
+
+		Released:      e.Released.Time,
+		Description:   e.Description,
+		Link:          e.Link,
+		ImageURL:      e.ImageURL,
+		EnclosureType: e.EnclosureType,
+		EnclosureSize: e.EnclosureSize,
+		Duration:      e.Duration,
 	}
 
 	if e.GUID.Valid {
@@ -255,6 +508,14 @@ func (e *episodeCollector) add(dbepisode *EpisodeDB) {
 		Started:   nil,
 		Position:  nil,
 		Total:     nil,
+
+		Released:      dbepisode.Released.Time,
+		Description:   dbepisode.Description,
+		Link:          dbepisode.Link,
+		ImageURL:      dbepisode.ImageURL,
+		EnclosureType: dbepisode.EnclosureType,
+		EnclosureSize: dbepisode.EnclosureSize,
+		Duration:      dbepisode.Duration,
 	}
 
 	if dbepisode.GUID.Valid {
@@ -291,6 +552,13 @@ type UserDB struct {
 	Password  string    `db:"password"`
 	Email     string    `db:"email"`
 	Name      string    `db:"name"`
+	Admin     bool      `db:"admin"`
+	// TOTPSecret is secret for two-factor authentication; empty when disabled.
+	TOTPSecret string `db:"totp_secret"`
+	// TOTPRecoveryCodes are space separated hashes of unused recovery codes.
+	TOTPRecoveryCodes string `db:"totp_recovery_codes"`
+	// TOTPLastStep is time step of last accepted totp code.
+	TOTPLastStep int64 `db:"totp_last_step"`
 }
 
 func (u *UserDB) MarshalZerologObject(event *zerolog.Event) {
@@ -304,6 +572,8 @@ func (u *UserDB) MarshalZerologObject(event *zerolog.Event) {
 		Str("Password", pass).
 		Str("email", u.Email).
 		Str("name", u.Name).
+		Bool("admin", u.Admin).
+		Bool("totp", u.TOTPSecret != "").
 		Time("created_at", u.CreatedAt).
 		Time("updated_at", u.UpdatedAt)
 }
@@ -316,6 +586,11 @@ func (u *UserDB) toModel() *model.User {
 		Email:    u.Email,
 		Name:     u.Name,
 		Locked:   u.Password == model.UserLockedPassword,
+		Admin:    u.Admin,
+
+		TOTPSecret:        u.TOTPSecret,
+		TOTPRecoveryCodes: strings.Fields(u.TOTPRecoveryCodes),
+		TOTPLastStep:      u.TOTPLastStep,
 	}
 }
 
@@ -330,6 +605,22 @@ func usersFromDB(users []UserDB) []model.User {
 	return res
 }
 
+// UserWithCountsDB is user with number of devices and subscribed podcasts.
+type UserWithCountsDB struct {
+	UserDB
+
+	Devices       int `db:"devices"`
+	Subscriptions int `db:"subscriptions"`
+}
+
+func (u *UserWithCountsDB) toModel() model.UserWithCounts {
+	return model.UserWithCounts{
+		User:          u.UserDB.toModel(),
+		Devices:       u.Devices,
+		Subscriptions: u.Subscriptions,
+	}
+}
+
 // ------------------------------------------------------------------------------
 
 type SettingsDB struct {
@@ -355,24 +646,76 @@ func (s SettingsDB) MarshalZerologObject(event *zerolog.Event) {
 //------------------------------------------------------------------------------
 
 type PodcastToUpdate struct {
-	MetaUpdatedAt sql.NullString `db:"metadata_updated_at"`
+	MetaUpdatedAt sql.NullTime   `db:"metadata_updated_at"`
 	URL           string         `db:"url"`
+	ETag          sql.NullString `db:"etag"`
+	LastModified  sql.NullString `db:"last_modified"`
+	IntervalSrc   string         `db:"check_interval_source"`
+	Interval      int64          `db:"check_interval"`
+	Override      int64          `db:"interval_override"`
+	Failures      int            `db:"failures"`
+}
+
+func (p *PodcastToUpdate) toModel() model.PodcastToUpdate {
+	return model.PodcastToUpdate{
+		URL:           p.URL,
+		MetaUpdatedAt: p.MetaUpdatedAt.Time,
+		ETag:          p.ETag.String,
+		LastModified:  p.LastModified.String,
+		Failures:      p.Failures,
+
+		CheckIntervalSource: p.IntervalSrc,
+		CheckInterval:       time.Duration(p.Interval) * time.Second,
+		IntervalOverride:    time.Duration(p.Override) * time.Second,
+	}
 }
 
-func (p *PodcastToUpdate) toModel() (model.PodcastToUpdate, error) {
-	updatedAt := time.Time{}
+//------------------------------------------------------------------------------
 
-	if p.MetaUpdatedAt.Valid && p.MetaUpdatedAt.String != "" {
-		t, err := time.Parse("2006-01-02 15:04:05.999999999-07:00", p.MetaUpdatedAt.String)
-		if err != nil {
-			return model.PodcastToUpdate{}, fmt.Errorf("parse datetime %q failed: %w", p.MetaUpdatedAt.String, err)
-		}
+type FeedStateDB struct {
+	LastFetchAt  sql.NullTime   `db:"last_fetch_at"`
+	NextRetryAt  sql.NullTime   `db:"next_retry_at"`
+	NextCheckAt  sql.NullTime   `db:"next_check_at"`
+	URL          string         `db:"url"`
+	Title        string         `db:"title"`
+	ETag         sql.NullString `db:"etag"`
+	LastModified sql.NullString `db:"last_modified"`
+	LastError    sql.NullString `db:"last_error"`
+	LastStatus   sql.NullInt32  `db:"last_status"`
+	IntervalSrc  string         `db:"check_interval_source"`
+	Interval     int64          `db:"check_interval"`
+	Override     int64          `db:"interval_override"`
+	Failures     int            `db:"failures"`
+	Dead         bool           `db:"dead"`
+}
 
-		updatedAt = t
+func (f *FeedStateDB) toModel() model.FeedState {
+	return model.FeedState{
+		LastFetchAt:  f.LastFetchAt.Time,
+		NextRetryAt:  f.NextRetryAt.Time,
+		NextCheckAt:  f.NextCheckAt.Time,
+		URL:          f.URL,
+		Title:        f.Title,
+		ETag:         f.ETag.String,
+		LastModified: f.LastModified.String,
+		LastError:    f.LastError.String,
+		LastStatus:   int(f.LastStatus.Int32),
+		Failures:     f.Failures,
+		Dead:         f.Dead,
+
+		CheckIntervalSource: f.IntervalSrc,
+		CheckInterval:       time.Duration(f.Interval) * time.Second,
+		IntervalOverride:    time.Duration(f.Override) * time.Second,
 	}
+}
 
-	return model.PodcastToUpdate{
-		URL:           p.URL,
-		MetaUpdatedAt: updatedAt,
-	}, nil
+//------------------------------------------------------------------------------
+
+func syncGroupToDB(group int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: group, Valid: group > 0}
+}
+
+// nullTimeToDB convert time to sql value; zero time is stored as NULL.
+func nullTimeToDB(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t.UTC(), Valid: !t.IsZero()}
 }
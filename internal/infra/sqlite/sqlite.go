@@ -145,7 +145,8 @@ func (d *Database) Migrate(ctx context.Context) error {
 		panic(fmt.Errorf("prepare migration fs failed: %w", err))
 	}
 
-	provider, err := goose.NewProvider(goose.DialectSQLite3, d.db.DB, migdir)
+	provider, err := goose.NewProvider(goose.DialectSQLite3, d.db.DB, migdir,
+		goose.WithGoMigrations(goMigrations()...))
 	if err != nil {
 		panic(fmt.Errorf("create goose provider failed: %w", err))
 	}
@@ -185,7 +186,20 @@ func (d *Database) Clear(ctx context.Context) error {
 		PRAGMA foreign_keys=OFF;
 		DELETE FROM settings;
 		DELETE FROM episodes;
+		DELETE FROM subscriptions_hist;
+		DELETE FROM app_tokens;
+		DELETE FROM remember_tokens;
+		DELETE FROM login_failures;
+		DELETE FROM audit_log;
+		DELETE FROM user_registrations;
+		DELETE FROM invite_codes;
+		DELETE FROM podcast_lists_items;
+		DELETE FROM podcast_lists;
+		DELETE FROM podcasts_tags;
 		DELETE FROM podcasts;
+		DELETE FROM feed_items;
+		DELETE FROM feed_redirects;
+		DELETE FROM feeds;
 		DELETE FROM devices;
 		DELETE FROM users;
 		DELETE FROM sessions;
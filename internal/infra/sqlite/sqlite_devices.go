@@ -34,7 +34,7 @@ func (Repository) GetDevice(
 	device := DeviceDB{}
 	err := dbctx.GetContext(ctx, &device,
 		`
-		SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, d.created_at, d.updated_at,
+		SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, d.sync_group, d.created_at, d.updated_at,
 				u.id as "user.id", u.name as "user.name", u.username as "user.username"
 		FROM devices d
 		JOIN users u ON u.ID = d.user_id
@@ -81,9 +81,9 @@ func (Repository) SaveDevice(ctx context.Context, device *model.Device) (int64,
 		now := time.Now().UTC()
 
 		res, err := dbctx.ExecContext(ctx,
-			"INSERT INTO devices (user_id, name, dev_type, caption, updated_at, created_at) "+
-				"VALUES(?, ?, ?, ?, ?, ?)",
-			device.User.ID, device.Name, device.DevType, device.Caption, now, now)
+			"INSERT INTO devices (user_id, name, dev_type, caption, sync_group, updated_at, created_at) "+
+				"VALUES(?, ?, ?, ?, ?, ?, ?)",
+			device.User.ID, device.Name, device.DevType, device.Caption, syncGroupToDB(device.SyncGroup), now, now)
 		if err != nil {
 			return 0, aerr.Wrapf(err, "insert device failed")
 		}
@@ -101,8 +101,8 @@ func (Repository) SaveDevice(ctx context.Context, device *model.Device) (int64,
 	logger.Debug().Object("device", device).Msgf("sqlite.Repository: update device device_name=%s", device.Name)
 
 	_, err := dbctx.ExecContext(ctx,
-		"UPDATE devices SET dev_type=?, caption=?, updated_at=? WHERE id=?",
-		device.DevType, device.Caption, time.Now().UTC(), device.ID)
+		"UPDATE devices SET dev_type=?, caption=?, sync_group=?, updated_at=? WHERE id=?",
+		device.DevType, device.Caption, syncGroupToDB(device.SyncGroup), time.Now().UTC(), device.ID)
 	if err != nil {
 		return device.ID, aerr.Wrapf(err, "update device failed").WithMeta("device_id", device.ID)
 	}
@@ -133,7 +133,7 @@ func (Repository) ListDevices(ctx context.Context, userid int64) ([]model.Device
 	devices := []DeviceDB{}
 
 	err = dbctx.SelectContext(ctx, &devices, `
-			SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, ? as subscriptions,
+			SELECT d.id, d.user_id, d.name, d.dev_type, d.caption, d.sync_group, ? as subscriptions,
 				d.created_at, d.updated_at,
 				u.id as "user.id", u.name as "user.name", u.username as "user.username"
 			FROM devices d
@@ -159,6 +159,11 @@ func (Repository) DeleteDevice(ctx context.Context, deviceid int64) error {
 		return aerr.Wrapf(err, "delete device failed")
 	}
 
+	_, err = dbctx.ExecContext(ctx, "DELETE FROM subscriptions_hist WHERE device_id=?", deviceid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete device subscriptions failed")
+	}
+
 	_, err = dbctx.ExecContext(ctx, "DELETE FROM devices where id=?", deviceid)
 	if err != nil {
 		return aerr.Wrapf(err, "delete device failed")
@@ -34,10 +34,12 @@ func (Repository) GetEpisode(
 	query := `
 		SELECT e.id, e.podcast_id, e.url, e.title, e.action, e.started, e.position, e.total,
 			e.created_at, e.updated_at, e.device_id,
-			p.url as "podcast.url", p.title as "podcast.title", p.id as "podcast.id",
-			d.name as "device.name", d.id as "device.id"
+			p.url as "podcast.url", coalesce(f.title, '') as "podcast.title", p.id as "podcast.id",
+			coalesce(f.website, '') as "podcast.website", coalesce(f.logo_url, '') as "podcast.logo_url",
+			coalesce(d.name, '') as "device.name", coalesce(d.id, 0) as "device.id"
 		FROM episodes e
 		JOIN podcasts p on p.id = e.podcast_id
+		LEFT JOIN feeds f on f.url = p.url
 		LEFT JOIN devices d on d.id = e.device_id
 		WHERE p.user_id=? AND e.podcast_id = ? and (e.url = ? or e.guid = ?)
 	`
@@ -118,10 +120,17 @@ func (s Repository) listEpisodeActions(
 	query := `
 		SELECT e.id, e.podcast_id, e.url, e.title, eh.action, eh.started, eh.position, eh.total, e.guid,
 			eh.created_at, eh.updated_at, eh.device_id,
-			p.url AS "podcast.url", p.title AS "podcast.title", p.id AS "podcast.id",
-			d.name AS "device.name", d.id AS "device.id"
+			p.url AS "podcast.url", coalesce(f.title, '') AS "podcast.title", p.id AS "podcast.id",
+			coalesce(f.website, '') AS "podcast.website", coalesce(f.logo_url, '') AS "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration,
+			coalesce(d.name, '') AS "device.name", coalesce(d.id, 0) AS "device.id"
 		FROM episodes e
 		JOIN podcasts p ON p.id = e.podcast_id
+		LEFT JOIN feeds f ON f.url = p.url
+		LEFT JOIN feed_items fi ON fi.feed_id = f.id AND fi.url = e.url
 		JOIN episodes_hist eh ON eh.episode_id = e.id
 		LEFT JOIN devices d ON d.id=eh.device_id
 		WHERE p.user_id=?`
@@ -177,12 +186,19 @@ func (s Repository) listEpisodeActionsAggregated(
 	}
 
 	query := `
-		SELECT p.url AS "podcast.url", p.title AS "podcast.title", p.id AS "podcast.id",
+		SELECT p.url AS "podcast.url", coalesce(f.title, '') AS "podcast.title", p.id AS "podcast.id",
+			coalesce(f.website, '') AS "podcast.website", coalesce(f.logo_url, '') AS "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration,
 			e.id, e.podcast_id, e.url, e.title, e.action, e.started, e.position, e.total, e.guid,
 			e.created_at, e.updated_at, e.device_id,
-			d.name AS "device.name", d.id AS "device.id"
+			coalesce(d.name, '') AS "device.name", coalesce(d.id, 0) AS "device.id"
 		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
 		JOIN episodes e ON e.podcast_id  = p.id
+		LEFT JOIN feed_items fi ON fi.feed_id = f.id AND fi.url = e.url
 		LEFT JOIN devices d ON d.id=e.device_id
 		WHERE p.user_id = ?
 		` + epArgs + `
@@ -234,12 +250,19 @@ func (s Repository) listEpisodeActionsAggregatedDev(
 				) AS last_eh
 			FROM episodes e
 		)
-		SELECT p.url AS "podcast.url", p.title AS "podcast.title", p.id AS "podcast.id",
+		SELECT p.url AS "podcast.url", coalesce(f.title, '') AS "podcast.title", p.id AS "podcast.id",
+			coalesce(f.website, '') AS "podcast.website", coalesce(f.logo_url, '') AS "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration,
 			e.id, e.podcast_id, e.url, e.title, eh.action, eh.started, eh.position, eh.total, e.guid,
 			eh.created_at, eh.updated_at, eh.device_id,
-			d.name AS "device.name", d.id AS "device.id"
+			coalesce(d.name, '') AS "device.name", coalesce(d.id, 0) AS "device.id"
 		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
 		JOIN eph e ON e.podcast_id  = p.id
+		LEFT JOIN feed_items fi ON fi.feed_id = f.id AND fi.url = e.url
 		JOIN episodes_hist eh ON eh.rowid = e.last_eh
 		LEFT JOIN devices d ON d.id=eh.device_id
 		WHERE p.user_id = ? ` + epArgs + `
@@ -262,9 +285,16 @@ func (Repository) ListFavorites(ctx context.Context, userid int64) ([]model.Epis
 
 	query := `
 		SELECT e.id, e.podcast_id, e.url, e.title, e.guid, e.created_at, e.updated_at,
-			p.url as "podcast.url", p.title as "podcast.title", p.id as "podcast.id"
+			p.url as "podcast.url", coalesce(f.title, '') as "podcast.title", p.id as "podcast.id",
+			coalesce(f.website, '') as "podcast.website", coalesce(f.logo_url, '') as "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration
 		FROM episodes e
 		JOIN podcasts p on p.id = e.podcast_id
+		LEFT JOIN feeds f on f.url = p.url
+		LEFT JOIN feed_items fi ON fi.feed_id = f.id AND fi.url = e.url
 		JOIN settings s on s.episode_id = e.id
 		WHERE p.user_id=? AND s.scope = 'episode' and s.key = 'is_favorite'
 		`
@@ -291,10 +321,12 @@ func (Repository) GetLastEpisodeAction(ctx context.Context,
 	query := `
 		SELECT e.id, e.podcast_id, e.url, e.title, e.action, e.started, e.position, e.total,
 			e.created_at, e.updated_at, e.device_id,
-			p.url as "podcast.url", p.title as "podcast.title", p.id as "podcast.id",
-			d.name as "device.name", d.id as "device.id"
+			p.url as "podcast.url", coalesce(f.title, '') as "podcast.title", p.id as "podcast.id",
+			coalesce(f.website, '') as "podcast.website", coalesce(f.logo_url, '') as "podcast.logo_url",
+			coalesce(d.name, '') as "device.name", coalesce(d.id, 0) as "device.id"
 		FROM episodes e
 		JOIN podcasts p on p.id = e.podcast_id
+		LEFT JOIN feeds f on f.url = p.url
 		LEFT JOIN devices d on d.id=e.device_id
 		WHERE p.user_id=? AND e.podcast_id = ?
 		ORDER BY e.updated_at DESC
@@ -435,7 +467,7 @@ func insertOrUpdateEpisode(
 	return epinfo.ID, nil
 }
 
-func (Repository) UpdateEpisodeInfo(ctx context.Context, episodes ...model.Episode) error {
+func (Repository) UpdateEpisodeInfo(ctx context.Context, podcasturl string, episodes ...model.Episode) error {
 	logger := log.Ctx(ctx)
 	logger.Debug().Msgf("sqlite.Repository: update episode meta count=%d", len(episodes))
 
@@ -465,5 +497,179 @@ func (Repository) UpdateEpisodeInfo(ctx context.Context, episodes ...model.Episo
 		}
 	}
 
+	return saveFeedItems(ctx, podcasturl, episodes)
+}
+
+// saveFeedItems insert or update metadata of episodes loaded from feed.
+func saveFeedItems(ctx context.Context, podcasturl string, episodes []model.Episode) error {
+	dbctx := db.MustCtx(ctx)
+
+	stmt, err := dbctx.PrepareContext(ctx, `
+		INSERT INTO feed_items (feed_id, url, guid, title, description, link, image_url, published_at,
+			duration, enclosure_size, enclosure_type, created_at, updated_at)
+		SELECT f.id, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		FROM feeds f
+		WHERE f.url = ?
+		ON CONFLICT (feed_id, url) DO UPDATE
+		SET guid=excluded.guid, title=excluded.title, description=excluded.description, link=excluded.link,
+			image_url=excluded.image_url, published_at=excluded.published_at, duration=excluded.duration,
+			enclosure_size=excluded.enclosure_size, enclosure_type=excluded.enclosure_type,
+			updated_at=excluded.updated_at`,
+	)
+	if err != nil {
+		return aerr.Wrapf(err, "prepare insert feed item stmt failed").WithTag(aerr.InternalError)
+	}
+
+	defer stmt.Close()
+
+	now := time.Now().UTC()
+
+	for _, episode := range episodes {
+		_, err := stmt.ExecContext(ctx, episode.URL, episode.GUID, episode.Title, episode.Description,
+			episode.Link, episode.ImageURL, nullTimeToDB(episode.Released), episode.Duration, episode.EnclosureSize,
+			episode.EnclosureType, now, now, podcasturl)
+		if err != nil {
+			return aerr.Wrapf(err, "insert feed item failed").WithTag(aerr.InternalError).
+				WithMeta("podcast_url", podcasturl, "episode_url", episode.URL)
+		}
+	}
+
 	return nil
 }
+
+// ListFeedEpisodes return episodes loaded from feed `podcasturl` published after `since` (if not zero),
+// newest first.
+func (Repository) ListFeedEpisodes(ctx context.Context, podcasturl string, since time.Time, limit uint,
+) ([]model.Episode, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Str("podcast_url", podcasturl).Msgf("sqlite.Repository: list feed episodes since=%s", since)
+
+	query := `
+		SELECT fi.id, fi.url, fi.title, fi.guid, fi.created_at, fi.updated_at, 'new' AS action,
+			f.url AS "podcast.url", f.title AS "podcast.title",
+			coalesce(f.website, '') AS "podcast.website", coalesce(f.logo_url, '') AS "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration
+		FROM feed_items fi
+		JOIN feeds f ON f.id = fi.feed_id
+		WHERE f.url = ?`
+	args := []any{podcasturl}
+
+	if !since.IsZero() {
+		query += " AND fi.published_at > ?"
+		args = append(args, since.UTC()) //nolint:wsl_v5
+	}
+
+	query += " ORDER BY fi.published_at DESC"
+
+	if limit > 0 {
+		query += " LIMIT " + strconv.FormatUint(uint64(limit), 10)
+	}
+
+	res := []EpisodeDB{}
+	dbctx := db.MustCtx(ctx)
+
+	err := dbctx.SelectContext(ctx, &res, query, args...)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query feed episodes failed").WithTag(aerr.InternalError).
+			WithMeta("podcast_url", podcasturl)
+	}
+
+	return episodesFromDB(res), nil
+}
+
+// ListNewEpisodes return episodes found in feeds of podcasts subscribed by user (or by device, when
+// `deviceid` is given) after `since` that have no user actions yet.
+func (Repository) ListNewEpisodes(ctx context.Context, userid int64, deviceid *int64, since time.Time,
+) ([]model.Episode, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Any("device_id", deviceid).
+		Msgf("sqlite.Repository: list new episodes since=%s", since)
+
+	query := `
+		SELECT fi.id, fi.url, fi.title, fi.guid, fi.created_at, fi.created_at AS updated_at, 'new' AS action,
+			p.url AS "podcast.url", f.title AS "podcast.title", p.id AS "podcast.id",
+			coalesce(f.website, '') AS "podcast.website", coalesce(f.logo_url, '') AS "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration
+		FROM podcasts p
+		JOIN feeds f ON f.url = p.url
+		JOIN feed_items fi ON fi.feed_id = f.id
+		WHERE p.user_id = ?
+			-- episodes published before since (i.e. archive loaded on first fetch of feed) are not new
+			AND coalesce(fi.published_at, fi.created_at) > ?
+			AND NOT EXISTS (SELECT 1 FROM episodes e WHERE e.podcast_id = p.id AND e.url = fi.url)`
+	args := []any{userid, since.UTC()}
+
+	if deviceid == nil {
+		query += " AND p.subscribed"
+	} else {
+		// last change made by device (or its sync group) or for all devices
+		query += ` AND (
+				SELECT sh."action"
+				FROM subscriptions_hist sh
+				WHERE sh.podcast_id = p.id AND (sh.device_id = ? OR sh.device_id IS NULL)
+				ORDER BY sh.id DESC
+				LIMIT 1
+			) = 'subscribe'`
+		args = append(args, *deviceid) //nolint:wsl_v5
+	}
+
+	query += " ORDER BY coalesce(fi.published_at, fi.created_at), fi.id"
+
+	res := []EpisodeDB{}
+	dbctx := db.MustCtx(ctx)
+
+	err := dbctx.SelectContext(ctx, &res, query, args...)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "query new episodes failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid, "device_id", deviceid)
+	}
+
+	episodes := episodesFromDB(res)
+
+	// new episodes are reported with time of publication
+	for idx := range episodes {
+		episodes[idx].Timestamp = episodes[idx].ReleasedOrTimestamp()
+	}
+
+	return episodes, nil
+}
+
+func (Repository) GetCatalogEpisode(ctx context.Context, podcasturl, episodeurl string) (*model.Episode, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Str("podcast_url", podcasturl).Str("episode_url", episodeurl).
+		Msgf("sqlite.Repository: get catalog episode podcast_url=%q episode_url=%q", podcasturl, episodeurl)
+
+	dbctx := db.MustCtx(ctx)
+	res := EpisodeDB{}
+
+	// prefer episodes with title (loaded from feed); first seen episode is treated as released
+	err := dbctx.GetContext(ctx, &res, `
+		SELECT e.id, e.podcast_id, e.url, e.title, e.guid, e.created_at, e.updated_at,
+			p.url AS "podcast.url", coalesce(f.title, '') AS "podcast.title",
+			coalesce(f.website, '') AS "podcast.website", coalesce(f.logo_url, '') AS "podcast.logo_url",
+			fi.published_at AS released, coalesce(fi.description, '') AS description,
+			coalesce(fi.link, '') AS link, coalesce(fi.image_url, '') AS image_url,
+			coalesce(fi.enclosure_type, '') AS enclosure_type, coalesce(fi.enclosure_size, 0) AS enclosure_size,
+			coalesce(fi.duration, 0) AS duration
+		FROM episodes e
+		JOIN podcasts p ON p.id = e.podcast_id
+		LEFT JOIN feeds f ON f.url = p.url
+		LEFT JOIN feed_items fi ON fi.feed_id = f.id AND fi.url = e.url
+		WHERE p.url = ? AND e.url = ?
+		ORDER BY e.title = '' OR e.title = e.url, e.created_at
+		LIMIT 1`, podcasturl, episodeurl)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, common.ErrNoData
+	} else if err != nil {
+		return nil, aerr.Wrapf(err, "query catalog episode failed").
+			WithMeta("podcast_url", podcasturl, "episode_url", episodeurl)
+	}
+
+	return res.toModel(), nil
+}
@@ -0,0 +1,150 @@
+package sqlite
+
+//
+// sqlite_lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func (Repository) ListPodcastLists(ctx context.Context, userid int64) ([]model.PodcastList, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("sqlite.Repository: list podcast lists user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+	res := []PodcastListDB{}
+
+	err := dbctx.SelectContext(ctx, &res,
+		"SELECT id, user_id, name, title, created_at, updated_at FROM podcast_lists WHERE user_id=? ORDER BY title, name",
+		userid)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select podcast lists failed").WithMeta("user_id", userid)
+	}
+
+	lists := make([]model.PodcastList, len(res))
+	for i, l := range res {
+		lists[i] = l.toModel()
+	}
+
+	return lists, nil
+}
+
+func (Repository) GetPodcastList(ctx context.Context, userid int64, name string) (*model.PodcastList, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Str("name", name).
+		Msgf("sqlite.Repository: get podcast list user_id=%d name=%s", userid, name)
+
+	dbctx := db.MustCtx(ctx)
+
+	list := PodcastListDB{}
+
+	err := dbctx.GetContext(ctx, &list,
+		"SELECT id, user_id, name, title, created_at, updated_at FROM podcast_lists WHERE user_id=? AND name=?",
+		userid, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, common.ErrNoData
+	} else if err != nil {
+		return nil, aerr.Wrapf(err, "select podcast list failed").WithMeta("user_id", userid, "name", name)
+	}
+
+	podcasts := []CatalogPodcastDB{}
+
+	// metadata of podcasts are loaded from shared feeds catalogue
+	err = dbctx.SelectContext(ctx, &podcasts, `
+		SELECT i.url, coalesce(f.title, '') AS title,
+			coalesce(f.description, '') AS description, coalesce(f.website, '') AS website,
+			coalesce(f.logo_url, '') AS logo_url,
+			(SELECT count(*) FROM podcasts p WHERE p.url = i.url AND p.subscribed) AS subscribers
+		FROM podcast_lists_items i
+		LEFT JOIN feeds f ON f.url = i.url
+		WHERE i.list_id = ?
+		ORDER BY i.position`, list.ID)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select podcast list items failed").WithMeta("list_id", list.ID)
+	}
+
+	res := list.toModel()
+	res.Podcasts = catalogPodcastsFromDB(podcasts)
+
+	return &res, nil
+}
+
+func (Repository) SavePodcastList(ctx context.Context, list *model.PodcastList) (int64, error) {
+	logger := log.Ctx(ctx)
+	dbctx := db.MustCtx(ctx)
+	now := time.Now().UTC()
+	listid := list.ID
+
+	if listid == 0 {
+		logger.Debug().Object("list", list).Msgf("sqlite.Repository: insert podcast list name=%s", list.Name)
+
+		res, err := dbctx.ExecContext(ctx,
+			"INSERT INTO podcast_lists (user_id, name, title, created_at, updated_at) VALUES(?, ?, ?, ?, ?)",
+			list.User.ID, list.Name, list.Title, now, now)
+		if err != nil {
+			return 0, aerr.Wrapf(err, "insert podcast list failed").WithMeta("name", list.Name)
+		}
+
+		listid, err = res.LastInsertId()
+		if err != nil {
+			return 0, aerr.Wrapf(err, "get last id failed")
+		}
+	} else {
+		logger.Debug().Object("list", list).Msgf("sqlite.Repository: update podcast list list_id=%d", listid)
+
+		_, err := dbctx.ExecContext(ctx,
+			"UPDATE podcast_lists SET title=?, updated_at=? WHERE id=?",
+			list.Title, now, listid)
+		if err != nil {
+			return 0, aerr.Wrapf(err, "update podcast list failed").WithMeta("list_id", listid)
+		}
+
+		_, err = dbctx.ExecContext(ctx, "DELETE FROM podcast_lists_items WHERE list_id=?", listid)
+		if err != nil {
+			return 0, aerr.Wrapf(err, "delete podcast list items failed").WithMeta("list_id", listid)
+		}
+	}
+
+	for idx, p := range list.Podcasts {
+		_, err := dbctx.ExecContext(ctx,
+			"INSERT INTO podcast_lists_items (list_id, url, position) VALUES(?, ?, ?)",
+			listid, p.URL, idx)
+		if err != nil {
+			return 0, aerr.Wrapf(err, "insert podcast list item failed").WithMeta("list_id", listid, "url", p.URL)
+		}
+	}
+
+	return listid, nil
+}
+
+func (Repository) DeletePodcastList(ctx context.Context, listid int64) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("list_id", listid).Msgf("sqlite.Repository: delete podcast list list_id=%d", listid)
+
+	dbctx := db.MustCtx(ctx)
+
+	_, err := dbctx.ExecContext(ctx, "DELETE FROM podcast_lists_items WHERE list_id=?", listid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete podcast list items failed").WithMeta("list_id", listid)
+	}
+
+	_, err = dbctx.ExecContext(ctx, "DELETE FROM podcast_lists WHERE id=?", listid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete podcast list failed").WithMeta("list_id", listid)
+	}
+
+	return nil
+}
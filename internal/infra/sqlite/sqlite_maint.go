@@ -68,6 +68,14 @@ var maintScripts = []string{
 		AND EXISTS (
 			SELECT NULL FROM episodes AS ed
 			WHERE ed.url = e.url AND ed.action = 'play' AND ed.updated_at > e.updated_at);`,
+	// delete expired remember me tokens
+	`DELETE FROM remember_tokens WHERE expires_at < datetime('now');`,
+	// delete feeds not used by any user nor podcast list
+	`DELETE FROM feeds
+		WHERE url NOT IN (SELECT url FROM podcasts)
+		AND url NOT IN (SELECT url FROM podcast_lists_items);`,
+	`DELETE FROM podcasts_tags WHERE url NOT IN (SELECT url FROM feeds);`,
+	`DELETE FROM feed_items WHERE feed_id NOT IN (SELECT id FROM feeds);`,
 	`VACUUM;`,
 	`ANALYZE;`,
 	`PRAGMA optimize;`,
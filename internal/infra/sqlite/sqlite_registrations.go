@@ -0,0 +1,196 @@
+package sqlite
+
+//
+// sqlite_registrations.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func (Repository) GetInviteCode(ctx context.Context, code string) (*model.InviteCode, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: get invite code")
+
+	dbctx := db.MustCtx(ctx)
+	res := InviteCodeDB{}
+
+	err := dbctx.GetContext(ctx, &res,
+		"SELECT code, created_by, created_at, expires_at, used_by, used_at FROM invite_codes WHERE code=?",
+		code)
+
+	switch {
+	case err == nil:
+		invite := res.toModel()
+
+		return &invite, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, common.ErrNoData
+	default:
+		return nil, aerr.Wrapf(err, "select invite code failed")
+	}
+}
+
+func (Repository) SaveInviteCode(ctx context.Context, code *model.InviteCode) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Object("invite_code", code).Msg("sqlite.Repository: save invite code")
+
+	dbctx := db.MustCtx(ctx)
+
+	_, err := dbctx.ExecContext(ctx,
+		"INSERT INTO invite_codes (code, created_by, created_at, expires_at, used_by, used_at) "+
+			"VALUES(?, ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (code) DO UPDATE SET expires_at=excluded.expires_at, "+
+			"used_by=excluded.used_by, used_at=excluded.used_at",
+		code.Code, code.CreatedBy, code.CreatedAt.UTC(), nullTimeToDB(code.ExpiresAt),
+		code.UsedBy, nullTimeToDB(code.UsedAt))
+	if err != nil {
+		return aerr.Wrapf(err, "save invite code failed")
+	}
+
+	return nil
+}
+
+func (Repository) UseInviteCode(ctx context.Context, code, username string, usedAt time.Time) (bool, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("sqlite.Repository: use invite code user_name=%s", username)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"UPDATE invite_codes SET used_by=?, used_at=? WHERE code=? AND used_by = ''",
+		username, usedAt.UTC(), code)
+	if err != nil {
+		return false, aerr.Wrapf(err, "update invite code failed")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, aerr.Wrapf(err, "update invite code failed get affected rows")
+	}
+
+	return cnt == 1, nil
+}
+
+func (Repository) ListInviteCodes(ctx context.Context) ([]model.InviteCode, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: list invite codes")
+
+	dbctx := db.MustCtx(ctx)
+	res := []InviteCodeDB{}
+
+	err := dbctx.SelectContext(ctx, &res,
+		"SELECT code, created_by, created_at, expires_at, used_by, used_at FROM invite_codes "+
+			"ORDER BY created_at")
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select invite codes failed")
+	}
+
+	codes := make([]model.InviteCode, len(res))
+	for i, c := range res {
+		codes[i] = c.toModel()
+	}
+
+	return codes, nil
+}
+
+func (Repository) DeleteInviteCode(ctx context.Context, code string) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: delete invite code")
+
+	dbctx := db.MustCtx(ctx)
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM invite_codes WHERE code=?", code); err != nil {
+		return aerr.Wrapf(err, "delete invite code failed")
+	}
+
+	return nil
+}
+
+//-------------------------------------------------------------
+
+func (Repository) GetUserRegistration(ctx context.Context, userid int64) (*model.UserRegistration, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("sqlite.Repository: get user registration user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+	res := UserRegistrationDB{}
+
+	err := dbctx.GetContext(ctx, &res,
+		"SELECT user_id, password, created_at FROM user_registrations WHERE user_id=?",
+		userid)
+
+	switch {
+	case err == nil:
+		registration := res.toModel()
+
+		return &registration, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, common.ErrNoData
+	default:
+		return nil, aerr.Wrapf(err, "select user registration failed").WithMeta("user_id", userid)
+	}
+}
+
+func (Repository) SaveUserRegistration(ctx context.Context, registration *model.UserRegistration) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Object("registration", registration).Msg("sqlite.Repository: insert user registration")
+
+	dbctx := db.MustCtx(ctx)
+
+	_, err := dbctx.ExecContext(ctx,
+		"INSERT INTO user_registrations (user_id, password, created_at) VALUES(?, ?, ?)",
+		registration.User.ID, registration.Password, time.Now().UTC())
+	if err != nil {
+		return aerr.Wrapf(err, "insert user registration failed").WithMeta("user_id", registration.User.ID)
+	}
+
+	return nil
+}
+
+func (Repository) ListUserRegistrations(ctx context.Context) ([]model.UserRegistration, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: list user registrations")
+
+	dbctx := db.MustCtx(ctx)
+	res := []UserRegistrationDB{}
+
+	err := dbctx.SelectContext(ctx, &res,
+		"SELECT r.user_id, r.password, r.created_at, u.username, u.email, u.name "+
+			"FROM user_registrations r JOIN users u ON u.id = r.user_id ORDER BY r.created_at")
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select user registrations failed")
+	}
+
+	registrations := make([]model.UserRegistration, len(res))
+	for i, r := range res {
+		registrations[i] = r.toModel()
+	}
+
+	return registrations, nil
+}
+
+func (Repository) DeleteUserRegistration(ctx context.Context, userid int64) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("sqlite.Repository: delete user registration user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM user_registrations WHERE user_id=?", userid); err != nil {
+		return aerr.Wrapf(err, "delete user registration failed").WithMeta("user_id", userid)
+	}
+
+	return nil
+}
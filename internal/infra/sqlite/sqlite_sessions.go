@@ -215,6 +215,48 @@ func (Repository) SessionExists(ctx context.Context, sid string) (bool, error) {
 	return true, nil
 }
 
+func (Repository) DeleteUserSessions(ctx context.Context, username string) (int, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("sqlite.Repository: delete user sessions user_name=%s", username)
+
+	dbctx := db.MustCtx(ctx)
+
+	var sessions []struct {
+		Key  string `db:"key"`
+		Data []byte `db:"data"`
+	}
+
+	// user name is stored in encoded session data
+	err := dbctx.SelectContext(ctx, &sessions, "SELECT key, data FROM sessions WHERE data IS NOT NULL")
+	if err != nil {
+		return 0, aerr.Wrapf(err, "select sessions failed")
+	}
+
+	deleted := 0
+
+	for _, sess := range sessions {
+		data, err := decodeSession(sess.Data)
+		if err != nil {
+			logger.Warn().Err(err).Str("sid", sess.Key).
+				Msgf("sqlite.Repository: skip invalid session sid=%s error=%q", sess.Key, err)
+
+			continue
+		}
+
+		if user, ok := data["user"].(string); !ok || user != username {
+			continue
+		}
+
+		if _, err := dbctx.ExecContext(ctx, "DELETE FROM sessions WHERE key=?", sess.Key); err != nil {
+			return deleted, aerr.Wrapf(err, "delete session failed").WithMeta("sid", sess.Key)
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
 func decodeSession(data []byte) (map[any]any, error) {
 	if len(data) == 0 {
 		return make(map[any]any), nil
@@ -0,0 +1,142 @@
+package sqlite
+
+//
+// sqlite_subscriptions.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func (Repository) SaveSubscriptionChanges(ctx context.Context, changes ...model.SubscriptionChange) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msgf("sqlite.Repository: save subscription changes count=%d", len(changes))
+
+	dbctx := db.MustCtx(ctx)
+
+	stmt, err := dbctx.PrepareContext(ctx,
+		`INSERT INTO subscriptions_hist (podcast_id, device_id, "action", created_at) VALUES(?, ?, ?, ?)`)
+	if err != nil {
+		return aerr.Wrapf(err, "prepare insert subscription change stmt failed").WithTag(aerr.InternalError)
+	}
+
+	defer stmt.Close()
+
+	for _, change := range changes {
+		deviceid := sql.NullInt64{}
+		if change.DeviceID != nil {
+			deviceid.Valid = true
+			deviceid.Int64 = *change.DeviceID
+		}
+
+		if change.CreatedAt.IsZero() {
+			change.CreatedAt = time.Now().UTC()
+		}
+
+		_, err := stmt.ExecContext(ctx, change.PodcastID, deviceid, change.Action, change.CreatedAt)
+		if err != nil {
+			return aerr.Wrapf(err, "insert subscription change failed").WithTag(aerr.InternalError).
+				WithMeta("podcast_id", change.PodcastID, "device_id", deviceid.Int64)
+		}
+	}
+
+	return nil
+}
+
+func (Repository) ListDeviceSubscriptions(ctx context.Context, userid, deviceid int64, since time.Time,
+) (model.Podcasts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Int64("device_id", deviceid).
+		Msgf("sqlite.Repository: list device subscriptions user_id=%d device_id=%d since=%s",
+			userid, deviceid, since)
+
+	dbctx := db.MustCtx(ctx)
+	res := []PodcastDB{}
+
+	query := `
+		SELECT p.id, p.user_id, p.url, coalesce(f.title, '') as title, sh."action" = 'subscribe' AS subscribed,
+			p.created_at, sh.created_at AS updated_at, f.metadata_updated_at,
+			coalesce(f.description, '') as description, coalesce(f.website, '') as website,
+			coalesce(f.logo_url, '') as logo_url,
+			(SELECT count(*) FROM podcasts ps WHERE ps.url = p.url AND ps.subscribed) as subscribers
+		FROM podcasts p
+		LEFT JOIN feeds f ON f.url = p.url
+		JOIN subscriptions_hist sh ON sh.podcast_id = p.id
+		WHERE p.user_id = ?
+			AND sh.id = (
+				SELECT sh2.id
+				FROM subscriptions_hist sh2
+				WHERE sh2.podcast_id = p.id AND (sh2.device_id = ? OR sh2.device_id IS NULL)
+				ORDER BY sh2.id DESC
+				LIMIT 1
+			)`
+	args := []any{userid, deviceid}
+
+	if !since.IsZero() {
+		query += " AND sh.created_at > ? "
+		args = append(args, since) //nolint:wsl_v5
+	}
+
+	query += " ORDER BY f.title, p.url"
+
+	if err := dbctx.SelectContext(ctx, &res, query, args...); err != nil {
+		return nil, aerr.Wrapf(err, "query device subscriptions failed").
+			WithMeta("user_id", userid, "device_id", deviceid, "since", since)
+	}
+
+	return podcastsFromDB(res), nil
+}
+
+func (Repository) IsPodcastSubscribed(ctx context.Context, podcastid int64) (bool, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("podcast_id", podcastid).
+		Msgf("sqlite.Repository: check is podcast subscribed podcast_id=%d", podcastid)
+
+	dbctx := db.MustCtx(ctx)
+
+	var subscribed bool
+
+	err := dbctx.GetContext(ctx, &subscribed, `
+		SELECT EXISTS (
+			SELECT NULL
+			FROM subscriptions_hist sh
+			JOIN podcasts p ON p.id = sh.podcast_id
+			WHERE sh.podcast_id = ? AND sh."action" = 'subscribe'
+				AND NOT EXISTS (
+					SELECT NULL
+					FROM subscriptions_hist sh2
+					WHERE sh2.podcast_id = sh.podcast_id AND sh2.id > sh.id
+						AND (sh2.device_id IS NULL OR sh2.device_id = sh.device_id)
+				)
+				-- change made for all devices is valid for devices that not changed podcast later
+				AND (
+					sh.device_id IS NOT NULL
+					OR NOT EXISTS (SELECT NULL FROM devices d WHERE d.user_id = p.user_id)
+					OR EXISTS (
+						SELECT NULL
+						FROM devices d
+						WHERE d.user_id = p.user_id
+							AND NOT EXISTS (
+								SELECT NULL
+								FROM subscriptions_hist sh3
+								WHERE sh3.podcast_id = sh.podcast_id AND sh3.id > sh.id AND sh3.device_id = d.id
+							)
+					)
+				)
+		)`, podcastid)
+	if err != nil {
+		return false, aerr.Wrapf(err, "query podcast subscription state failed").WithMeta("podcast_id", podcastid)
+	}
+
+	return subscribed, nil
+}
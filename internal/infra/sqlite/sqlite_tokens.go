@@ -0,0 +1,163 @@
+package sqlite
+
+//
+// sqlite_tokens.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+)
+
+func (Repository) ListAppTokens(ctx context.Context, userid int64) ([]model.AppToken, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("sqlite.Repository: list app tokens user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+	res := []AppTokenDB{}
+
+	err := dbctx.SelectContext(ctx, &res,
+		"SELECT id, user_id, name, token, device_name, read_only, created_at, last_used_at "+
+			"FROM app_tokens WHERE user_id=? ORDER BY name",
+		userid)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select app tokens failed").WithMeta("user_id", userid)
+	}
+
+	tokens := make([]model.AppToken, len(res))
+	for i, t := range res {
+		tokens[i] = t.toModel()
+	}
+
+	return tokens, nil
+}
+
+func (Repository) SaveAppToken(ctx context.Context, token *model.AppToken) (int64, error) {
+	logger := log.Ctx(ctx)
+	dbctx := db.MustCtx(ctx)
+
+	if token.ID == 0 {
+		logger.Debug().Object("token", token).Msgf("sqlite.Repository: insert app token name=%s", token.Name)
+
+		res, err := dbctx.ExecContext(ctx,
+			"INSERT INTO app_tokens (user_id, name, token, device_name, read_only, created_at) "+
+				"VALUES(?, ?, ?, ?, ?, ?)",
+			token.User.ID, token.Name, token.Token, token.DeviceName, token.ReadOnly, time.Now().UTC())
+		if err != nil {
+			return 0, aerr.Wrapf(err, "insert app token failed").WithMeta("name", token.Name)
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return 0, aerr.Wrapf(err, "get last id failed")
+		}
+
+		return id, nil
+	}
+
+	logger.Debug().Object("token", token).Msgf("sqlite.Repository: update app token token_id=%d", token.ID)
+
+	_, err := dbctx.ExecContext(ctx, "UPDATE app_tokens SET last_used_at=? WHERE id=?",
+		token.LastUsedAt.UTC(), token.ID)
+	if err != nil {
+		return 0, aerr.Wrapf(err, "update app token failed").WithMeta("token_id", token.ID)
+	}
+
+	return token.ID, nil
+}
+
+func (Repository) DeleteAppToken(ctx context.Context, tokenid int64) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("token_id", tokenid).Msgf("sqlite.Repository: delete app token token_id=%d", tokenid)
+
+	dbctx := db.MustCtx(ctx)
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM app_tokens WHERE id=?", tokenid); err != nil {
+		return aerr.Wrapf(err, "delete app token failed").WithMeta("token_id", tokenid)
+	}
+
+	return nil
+}
+
+//-------------------------------------------------------------
+
+func (Repository) GetRememberToken(ctx context.Context, token string) (*model.RememberToken, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: get remember token")
+
+	dbctx := db.MustCtx(ctx)
+	res := RememberTokenDB{}
+
+	err := dbctx.GetContext(ctx, &res,
+		"SELECT t.id, t.user_id, t.token, t.created_at, t.expires_at, u.username "+
+			"FROM remember_tokens t JOIN users u ON u.id = t.user_id WHERE t.token=?",
+		token)
+
+	switch {
+	case err == nil:
+		return res.toModel(), nil
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, common.ErrNoData
+	default:
+		return nil, aerr.Wrapf(err, "select remember token failed")
+	}
+}
+
+// SaveRememberToken insert new token; tokens are never updated.
+func (Repository) SaveRememberToken(ctx context.Context, token *model.RememberToken) (int64, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Object("token", token).Msg("sqlite.Repository: insert remember token")
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"INSERT INTO remember_tokens (user_id, token, created_at, expires_at) VALUES(?, ?, ?, ?)",
+		token.User.ID, token.Token, time.Now().UTC(), token.ExpiresAt.UTC())
+	if err != nil {
+		return 0, aerr.Wrapf(err, "insert remember token failed")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, aerr.Wrapf(err, "get last id failed")
+	}
+
+	return id, nil
+}
+
+func (Repository) DeleteRememberToken(ctx context.Context, token string) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: delete remember token")
+
+	dbctx := db.MustCtx(ctx)
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM remember_tokens WHERE token=?", token); err != nil {
+		return aerr.Wrapf(err, "delete remember token failed")
+	}
+
+	return nil
+}
+
+func (Repository) DeleteUserRememberTokens(ctx context.Context, userid int64) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msg("sqlite.Repository: delete user remember tokens")
+
+	dbctx := db.MustCtx(ctx)
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM remember_tokens WHERE user_id=?", userid); err != nil {
+		return aerr.Wrapf(err, "delete user remember tokens failed").WithMeta("user_id", userid)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -28,8 +29,8 @@ func (Repository) GetUser(ctx context.Context, username string) (*model.User, er
 	user := UserDB{}
 
 	err := dbctx.GetContext(ctx, &user,
-		"SELECT id, username, password, email, name, created_at, updated_at "+
-			"FROM users WHERE username=?",
+		"SELECT id, username, password, email, name, admin, totp_secret, totp_recovery_codes, totp_last_step, "+
+			"created_at, updated_at FROM users WHERE username=?",
 		username)
 
 	switch {
@@ -50,9 +51,9 @@ func (Repository) SaveUser(ctx context.Context, user *model.User) (int64, error)
 		logger.Debug().Object("user", user).Msgf("sqlite.Repository: insert user user_name=%s", user.UserName)
 
 		res, err := dbctx.ExecContext(ctx,
-			"INSERT INTO users (username, password, email, name, created_at, updated_at) "+
-				"VALUES(?, ?, ?, ?, ?, ?)",
-			user.UserName, user.Password, user.Email, user.Name, time.Now().UTC(), time.Now().UTC())
+			"INSERT INTO users (username, password, email, name, admin, created_at, updated_at) "+
+				"VALUES(?, ?, ?, ?, ?, ?, ?)",
+			user.UserName, user.Password, user.Email, user.Name, user.Admin, time.Now().UTC(), time.Now().UTC())
 		if err != nil {
 			return 0, aerr.Wrapf(err, "insert user failed").WithTag(aerr.InternalError)
 		}
@@ -69,8 +70,10 @@ func (Repository) SaveUser(ctx context.Context, user *model.User) (int64, error)
 	logger.Debug().Object("user", user).Msgf("update user user_name=%s", user.UserName)
 
 	_, err := dbctx.ExecContext(ctx,
-		"UPDATE users SET password=?, email=?, name=?, updated_at=? WHERE id=?",
-		user.Password, user.Email, user.Name, time.Now().UTC(), user.ID)
+		"UPDATE users SET password=?, email=?, name=?, admin=?, totp_secret=?, totp_recovery_codes=?, "+
+			"updated_at=? WHERE id=?",
+		user.Password, user.Email, user.Name, user.Admin, user.TOTPSecret, strings.Join(user.TOTPRecoveryCodes, " "),
+		time.Now().UTC(), user.ID)
 	if err != nil {
 		return 0, aerr.Wrapf(err, "update user failed").WithTag(aerr.InternalError)
 	}
@@ -85,7 +88,8 @@ func (Repository) ListUsers(ctx context.Context, activeOnly bool) ([]model.User,
 
 	var users []UserDB
 
-	sql := "SELECT id, username, password, email, name, created_at, updated_at FROM users"
+	sql := "SELECT id, username, password, email, name, admin, totp_secret, totp_recovery_codes, totp_last_step, " +
+		"created_at, updated_at FROM users"
 	if activeOnly {
 		sql += " WHERE password != 'LOCKED'"
 	}
@@ -102,6 +106,33 @@ func (Repository) ListUsers(ctx context.Context, activeOnly bool) ([]model.User,
 	return usersFromDB(users), nil
 }
 
+// ListUsersWithCounts get all users with number of devices and subscribed podcasts.
+func (Repository) ListUsersWithCounts(ctx context.Context) ([]model.UserWithCounts, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Msg("sqlite.Repository: list users with counts")
+
+	var users []UserWithCountsDB
+
+	dbctx := db.MustCtx(ctx)
+
+	err := dbctx.SelectContext(ctx, &users,
+		"SELECT u.id, u.username, u.password, u.email, u.name, u.admin, u.totp_secret, u.totp_recovery_codes, "+
+			"u.totp_last_step, u.created_at, u.updated_at, "+
+			"(SELECT count(*) FROM devices d WHERE d.user_id=u.id) AS devices, "+
+			"(SELECT count(*) FROM podcasts p WHERE p.user_id=u.id AND p.subscribed) AS subscriptions "+
+			"FROM users u ORDER BY u.username")
+	if err != nil {
+		return nil, aerr.Wrapf(err, "select users failed").WithTag(aerr.InternalError)
+	}
+
+	res := make([]model.UserWithCounts, len(users))
+	for i, u := range users {
+		res[i] = u.toModel()
+	}
+
+	return res, nil
+}
+
 // DeleteUser and all related objects.
 func (Repository) DeleteUser(ctx context.Context, userid int64) error {
 	logger := log.Ctx(ctx)
@@ -116,6 +147,40 @@ func (Repository) DeleteUser(ctx context.Context, userid int64) error {
 		return aerr.Wrapf(err, "delete episodes failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
 	}
 
+	_, err = dbctx.ExecContext(ctx,
+		"DELETE FROM subscriptions_hist WHERE podcast_id IN (SELECT id FROM podcasts WHERE user_id=?)",
+		userid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete subscriptions_hist failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	_, err = dbctx.ExecContext(ctx,
+		"DELETE FROM podcast_lists_items WHERE list_id IN (SELECT id FROM podcast_lists WHERE user_id=?)",
+		userid)
+	if err != nil {
+		return aerr.Wrapf(err, "delete podcast_lists_items failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM podcast_lists WHERE user_id=?", userid); err != nil {
+		return aerr.Wrapf(err, "delete podcast_lists failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM app_tokens WHERE user_id=?", userid); err != nil {
+		return aerr.Wrapf(err, "delete app_tokens failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM remember_tokens WHERE user_id=?", userid); err != nil {
+		return aerr.Wrapf(err, "delete remember_tokens failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	if _, err := dbctx.ExecContext(ctx, "DELETE FROM user_registrations WHERE user_id=?", userid); err != nil {
+		return aerr.Wrapf(err, "delete user_registrations failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
 	if _, err := dbctx.ExecContext(ctx, "DELETE FROM settings WHERE user_id=?", userid); err != nil {
 		return aerr.Wrapf(err, "delete settings failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
 	}
@@ -134,3 +199,68 @@ func (Repository) DeleteUser(ctx context.Context, userid int64) error {
 
 	return nil
 }
+
+func (Repository) UpdateTOTPLastStep(ctx context.Context, userid, step int64) (bool, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).
+		Msgf("sqlite.Repository: update totp last step user_id=%d step=%d", userid, step)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"UPDATE users SET totp_last_step=? WHERE id=? AND totp_last_step < ?",
+		step, userid, step)
+	if err != nil {
+		return false, aerr.Wrapf(err, "update totp last step failed").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, aerr.Wrapf(err, "update totp last step failed get affected rows").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	return cnt == 1, nil
+}
+
+func (Repository) LockUser(ctx context.Context, userid int64) error {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("sqlite.Repository: lock user user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+
+	// password of already locked account is kept
+	_, err := dbctx.ExecContext(ctx,
+		"UPDATE users SET locked_password=password, password=?, updated_at=? "+
+			"WHERE id=? AND password <> ?",
+		model.UserLockedPassword, time.Now().UTC(), userid, model.UserLockedPassword)
+	if err != nil {
+		return aerr.Wrapf(err, "lock user failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	return nil
+}
+
+func (Repository) UnlockUser(ctx context.Context, userid int64) (bool, error) {
+	logger := log.Ctx(ctx)
+	logger.Debug().Int64("user_id", userid).Msgf("sqlite.Repository: unlock user user_id=%d", userid)
+
+	dbctx := db.MustCtx(ctx)
+
+	res, err := dbctx.ExecContext(ctx,
+		"UPDATE users SET password=locked_password, locked_password='', updated_at=? "+
+			"WHERE id=? AND password=? AND locked_password <> ''",
+		time.Now().UTC(), userid, model.UserLockedPassword)
+	if err != nil {
+		return false, aerr.Wrapf(err, "unlock user failed").WithTag(aerr.InternalError).WithMeta("user_id", userid)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return false, aerr.Wrapf(err, "unlock user failed get affected rows").WithTag(aerr.InternalError).
+			WithMeta("user_id", userid)
+	}
+
+	return cnt == 1, nil
+}
@@ -0,0 +1,180 @@
+package mailer
+
+//
+// mailer.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/config"
+)
+
+// sendTimeout limit time of whole smtp session.
+const sendTimeout = 30 * time.Second
+
+// Message is plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+func (m *Message) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("to", m.To).
+		Str("subject", m.Subject)
+}
+
+// Sender deliver emails.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+//------------------------------------------------------------------------------
+
+// SMTPSender send emails by smtp server.
+type SMTPSender struct {
+	cfg *config.SMTPConf
+}
+
+func NewSMTPSender(cfg *config.SMTPConf) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Object("msg", msg).Msgf("SMTPSender: send mail to=%s", msg.To)
+
+	if _, err := mail.ParseAddress(msg.To); err != nil || strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return aerr.ErrValidation.WithUserMsg("invalid recipient address").WithMeta("to", msg.To)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	client, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := s.send(client, msg); err != nil {
+		return aerr.Wrapf(err, "send mail failed").WithTag(aerr.InternalError).
+			WithMeta("address", s.cfg.Address, "to", msg.To)
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) connect(ctx context.Context) (*smtp.Client, error) {
+	host, _, _ := net.SplitHostPort(s.cfg.Address)
+	tlsConfig := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	if s.cfg.TLS {
+		dialer := tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", s.cfg.Address)
+	} else {
+		dialer := net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", s.cfg.Address)
+	}
+
+	if err != nil {
+		return nil, aerr.Wrapf(err, "connect to smtp server failed").WithTag(aerr.InternalError).
+			WithMeta("address", s.cfg.Address)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+
+		return nil, aerr.Wrapf(err, "start smtp session failed").WithTag(aerr.InternalError).
+			WithMeta("address", s.cfg.Address)
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !s.cfg.TLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+
+			return nil, aerr.Wrapf(err, "starttls failed").WithTag(aerr.InternalError).
+				WithMeta("address", s.cfg.Address)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, host)); err != nil {
+			client.Close()
+
+			return nil, aerr.Wrapf(err, "smtp authentication failed").WithTag(aerr.InternalError).
+				WithMeta("address", s.cfg.Address, "username", s.cfg.Username)
+		}
+	}
+
+	return client, nil
+}
+
+func (s *SMTPSender) send(client *smtp.Client, msg *Message) error {
+	if err := client.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("rcpt to: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+
+	if _, err := w.Write(s.format(msg)); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("close message: %w", err)
+	}
+
+	return client.Quit() //nolint:wrapcheck
+}
+
+// format create message with headers; body lines are separated by CRLF.
+func (s *SMTPSender) format(msg *Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return buf.Bytes()
+}
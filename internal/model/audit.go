@@ -0,0 +1,64 @@
+package model
+
+//
+// audit.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Actions recorded in audit log.
+const (
+	AuditLogin          = "login"
+	AuditLoginFailed    = "login_failed"
+	AuditPasswordChange = "password_change"
+	AuditAccountLock    = "account_lock"
+	AuditAccountUnlock  = "account_unlock"
+	AuditDeviceDelete   = "device_delete"
+	AuditPodcastDelete  = "podcast_delete"
+	AuditDataImport     = "data_import"
+	AuditDataExport     = "data_export"
+)
+
+// AuditActions is list of all known audit actions.
+//
+//nolint:gochecknoglobals
+var AuditActions = []string{
+	AuditLogin, AuditLoginFailed, AuditPasswordChange, AuditAccountLock, AuditAccountUnlock,
+	AuditDeviceDelete, AuditPodcastDelete, AuditDataImport, AuditDataExport,
+}
+
+// IsValidAuditAction check is `action` one of known audit actions.
+func IsValidAuditAction(action string) bool {
+	return slices.Contains(AuditActions, action)
+}
+
+// AuditEvent is security-related event stored in audit log.
+type AuditEvent struct {
+	CreatedAt time.Time
+	// UserName is name of account that event concern; may be unknown user for failed logins.
+	UserName string
+	Action   string
+	// IP and UserAgent identify client; empty for actions run from cli.
+	IP        string
+	UserAgent string
+	Details   string
+	ID        int64
+}
+
+func (a *AuditEvent) MarshalZerologObject(event *zerolog.Event) {
+	event.Int64("id", a.ID).
+		Str("user_name", a.UserName).
+		Str("action", a.Action).
+		Str("ip", a.IP).
+		Str("user_agent", a.UserAgent).
+		Str("details", a.Details).
+		Time("created_at", a.CreatedAt)
+}
@@ -23,6 +23,8 @@ type Device struct {
 	DevType       string
 	Caption       string
 	Subscriptions int
+	// SyncGroup identify group of synchronized devices; 0 - device is not synchronized.
+	SyncGroup int64
 }
 
 func (d *Device) MarshalZerologObject(event *zerolog.Event) {
@@ -33,7 +35,8 @@ func (d *Device) MarshalZerologObject(event *zerolog.Event) {
 		Str("caption", d.Caption).
 		Time("updated_at", d.UpdatedAt).
 		Time("last_seen_at", d.LastSeenAt).
-		Int("subscriptions", d.Subscriptions)
+		Int("subscriptions", d.Subscriptions).
+		Int64("sync_group", d.SyncGroup)
 
 	if d.User != nil {
 		event.Object("user", d.User)
@@ -63,3 +66,65 @@ func (d Devices) ToIDsMap() map[string]int64 {
 
 	return devices
 }
+
+// FindByName return device with given name.
+func (d Devices) FindByName(name string) (Device, bool) {
+	for _, dev := range d {
+		if dev.Name == name {
+			return dev, true
+		}
+	}
+
+	return Device{}, false
+}
+
+// SyncGroupMembers return devices that belong to `group`.
+func (d Devices) SyncGroupMembers(group int64) Devices {
+	res := make(Devices, 0)
+
+	if group == 0 {
+		return res
+	}
+
+	for _, dev := range d {
+		if dev.SyncGroup == group {
+			res = append(res, dev)
+		}
+	}
+
+	return res
+}
+
+//------------------------------------------------------------------------------
+
+// SyncStatus describe which user devices have synchronized subscriptions.
+type SyncStatus struct {
+	Synchronized    [][]string
+	NotSynchronized []string
+}
+
+func NewSyncStatus(devices Devices) SyncStatus {
+	status := SyncStatus{
+		Synchronized:    make([][]string, 0),
+		NotSynchronized: make([]string, 0),
+	}
+
+	groups := make(map[int64]int)
+
+	for _, dev := range devices {
+		if dev.SyncGroup == 0 {
+			status.NotSynchronized = append(status.NotSynchronized, dev.Name)
+
+			continue
+		}
+
+		if idx, ok := groups[dev.SyncGroup]; ok {
+			status.Synchronized[idx] = append(status.Synchronized[idx], dev.Name)
+		} else {
+			groups[dev.SyncGroup] = len(status.Synchronized)
+			status.Synchronized = append(status.Synchronized, []string{dev.Name})
+		}
+	}
+
+	return status
+}
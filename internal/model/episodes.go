@@ -34,6 +34,16 @@ type Episode struct {
 	Total    *int32
 	GUID     *string
 
+	// metadata loaded from podcast feed; empty when episode is not found in feed.
+	Released      time.Time
+	Description   string
+	Link          string
+	ImageURL      string
+	EnclosureType string
+	EnclosureSize int64
+	// Duration of episode in seconds.
+	Duration int32
+
 	Podcast *Podcast
 	Device  *Device
 }
@@ -46,6 +56,15 @@ func (e *Episode) DeviceName() string {
 	return e.Device.Name
 }
 
+// ReleasedOrTimestamp return release date of episode or, when unknown, time of action.
+func (e *Episode) ReleasedOrTimestamp() time.Time {
+	if e.Released.IsZero() {
+		return e.Timestamp
+	}
+
+	return e.Released
+}
+
 func (e *Episode) Validate() error {
 	if !validators.IsValidEpisodeAction(e.Action) {
 		return aerr.ErrValidation.WithUserMsg("invalid action")
@@ -98,10 +117,9 @@ func NewFavoriteFromModel(episodedb *Episode) Favorite {
 		URL:          episodedb.URL,
 		PodcastTitle: common.Coalesce(episodedb.Podcast.Title, episodedb.Podcast.URL),
 		PodcastURL:   episodedb.Podcast.URL,
-		Website:      "",
-		MygpoLink:    "",
-		// Release is date of update, so this is not release date...
-		Released: episodedb.Timestamp,
+		Website:      episodedb.Podcast.Website,
+		MygpoLink:    episodedb.Podcast.WebPath(),
+		Released:     episodedb.ReleasedOrTimestamp(),
 	}
 }
 
@@ -112,6 +130,7 @@ type EpisodeUpdate struct {
 	Episode      *Episode
 	Title        string
 	URL          string
+	Description  string
 	PodcastTitle string
 	PodcastURL   string
 	Website      string
@@ -126,12 +145,12 @@ func NewEpisodeUpdate(episodedb *Episode) EpisodeUpdate {
 		URL:          episodedb.URL,
 		PodcastTitle: episodedb.Podcast.Title,
 		PodcastURL:   episodedb.Podcast.URL,
+		Description:  episodedb.Description,
 		Status:       episodedb.Action,
-		// do not tracking released time; use updated time
-		Released:  episodedb.Timestamp,
-		Episode:   nil,
-		Website:   "",
-		MygpoLink: "",
+		Released:     episodedb.ReleasedOrTimestamp(),
+		Episode:      nil,
+		Website:      episodedb.Podcast.Website,
+		MygpoLink:    episodedb.Podcast.WebPath(),
 	}
 }
 
@@ -182,12 +201,12 @@ func NewEpisodeUpdateWithEpisode(episodedb *Episode) EpisodeUpdate {
 		URL:          episodedb.URL,
 		PodcastTitle: episodedb.Podcast.Title,
 		PodcastURL:   episodedb.Podcast.URL,
+		Description:  episodedb.Description,
 		Status:       episodedb.Action,
-		// do not tracking released time; use updated time
-		Released:  episodedb.Timestamp,
-		Episode:   nil,
-		Website:   "",
-		MygpoLink: "",
+		Released:     episodedb.ReleasedOrTimestamp(),
+		Episode:      nil,
+		Website:      episodedb.Podcast.Website,
+		MygpoLink:    episodedb.Podcast.WebPath(),
 	}
 
 	if episodedb.Action != ActionNew {
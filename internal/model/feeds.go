@@ -0,0 +1,97 @@
+package model
+
+//
+// feeds.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Health status of feed.
+const (
+	FeedHealthUnknown = "unknown"
+	FeedHealthOK      = "ok"
+	FeedHealthFailing = "failing"
+	FeedHealthDead    = "dead"
+)
+
+// Source of feed check interval.
+const (
+	FeedScheduleDefault      = "default"
+	FeedScheduleCadence      = "cadence"
+	FeedScheduleDormant      = "dormant"
+	FeedScheduleTTL          = "ttl"
+	FeedScheduleUpdatePeriod = "update-period"
+	FeedScheduleOverride     = "override"
+)
+
+// FeedState keep result of last fetching podcast feed.
+type FeedState struct {
+	LastFetchAt time.Time
+	// NextRetryAt, when not zero, block fetching feed until given time.
+	NextRetryAt time.Time
+	// NextCheckAt is time when feed should be fetched again.
+	NextCheckAt time.Time
+	URL         string
+	// Title of podcast; only for display, not saved.
+	Title        string
+	ETag         string
+	LastModified string
+	LastError    string
+	LastStatus   int
+	// Failures is number of consecutive failed fetches.
+	Failures int
+	// CheckIntervalSource describe how CheckInterval was computed.
+	CheckIntervalSource string
+	// CheckInterval is interval of checking feed computed from its content.
+	CheckInterval time.Duration
+	// IntervalOverride, when not zero, replace computed CheckInterval.
+	IntervalOverride time.Duration
+	// Dead feeds are not fetched anymore.
+	Dead bool
+}
+
+// Health return health status of feed.
+func (f *FeedState) Health() string {
+	switch {
+	case f.Dead:
+		return FeedHealthDead
+	case f.Failures > 0:
+		return FeedHealthFailing
+	case f.LastFetchAt.IsZero():
+		return FeedHealthUnknown
+	default:
+		return FeedHealthOK
+	}
+}
+
+// Interval return effective interval of checking feed and its source.
+func (f *FeedState) Interval() (time.Duration, string) {
+	if f.IntervalOverride > 0 {
+		return f.IntervalOverride, FeedScheduleOverride
+	}
+
+	return f.CheckInterval, f.CheckIntervalSource
+}
+
+func (f *FeedState) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("url", f.URL).
+		Str("etag", f.ETag).
+		Str("last_modified", f.LastModified).
+		Int("last_status", f.LastStatus).
+		Str("last_error", f.LastError).
+		Int("failures", f.Failures).
+		Bool("dead", f.Dead).
+		Time("last_fetch_at", f.LastFetchAt).
+		Time("next_retry_at", f.NextRetryAt).
+		Time("next_check_at", f.NextCheckAt).
+		Dur("check_interval", f.CheckInterval).
+		Str("check_interval_source", f.CheckIntervalSource).
+		Dur("interval_override", f.IntervalOverride)
+}
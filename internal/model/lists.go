@@ -0,0 +1,45 @@
+package model
+
+//
+// lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// PodcastList is named list of podcasts published by user.
+type PodcastList struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      *User
+	// Name is unique (for user) identifier of list created from title.
+	Name     string
+	Title    string
+	Podcasts Podcasts
+	ID       int64
+}
+
+func (p *PodcastList) MarshalZerologObject(event *zerolog.Event) {
+	event.Int64("id", p.ID).
+		Str("name", p.Name).
+		Str("title", p.Title).
+		Int("podcasts", len(p.Podcasts)).
+		Time("created_at", p.CreatedAt).
+		Time("updated_at", p.UpdatedAt)
+
+	if p.User != nil {
+		event.Int64("user_id", p.User.ID)
+	}
+}
+
+// PodcastListName create list name from title; all characters other than letters and digits
+// are replaced by `-`.
+func PodcastListName(title string) string {
+	return slugify(title)
+}
@@ -0,0 +1,45 @@
+package model
+
+//
+// loginfailures.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Kinds of login failures subjects.
+const (
+	LoginFailureUser = "user"
+	LoginFailureIP   = "ip"
+)
+
+// LoginFailure count failed logins for user name or client ip address.
+type LoginFailure struct {
+	LastFailureAt time.Time
+	// LockedUntil, when not zero, block login until given time.
+	LockedUntil time.Time
+	// Kind is LoginFailureUser or LoginFailureIP.
+	Kind string
+	// Subject is user name or ip address.
+	Subject  string
+	Failures int
+}
+
+// IsLocked check is login blocked in given time.
+func (l *LoginFailure) IsLocked(now time.Time) bool {
+	return !l.LockedUntil.IsZero() && l.LockedUntil.After(now)
+}
+
+func (l *LoginFailure) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("kind", l.Kind).
+		Str("subject", l.Subject).
+		Int("failures", l.Failures).
+		Time("last_failure_at", l.LastFailureAt).
+		Time("locked_until", l.LockedUntil)
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -29,6 +30,16 @@ type Podcast struct {
 	Subscribed    bool
 }
 
+// WebPath return path (relative to webroot) to podcast page in web interface or empty string
+// for podcasts not stored in database. Used as mygpo_link in api.
+func (p *Podcast) WebPath() string {
+	if p.ID == 0 {
+		return ""
+	}
+
+	return "/web/podcast/" + strconv.FormatInt(p.ID, 10) + "/"
+}
+
 func (p *Podcast) SetSubscribed(timestamp time.Time) bool {
 	if p.Subscribed {
 		return false
@@ -63,6 +74,7 @@ func (p *Podcast) MarshalZerologObject(event *zerolog.Event) {
 
 type PodcastWithLastEpisode struct {
 	LastEpisode *Episode
+	Tags        PodcastTags
 	Title       string
 	URL         string
 	Description string
@@ -107,6 +119,19 @@ func (s Podcasts) FindPodcastByURL(url string) (Podcast, bool) {
 	return Podcast{}, false
 }
 
+// SubscribedOnly return only subscribed podcasts.
+func (s Podcasts) SubscribedOnly() Podcasts {
+	res := make(Podcasts, 0, len(s))
+
+	for _, p := range s {
+		if p.Subscribed {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
 func (s Podcasts) ToURLs() []string {
 	res := make([]string, 0, len(s))
 	for _, p := range s {
@@ -144,6 +169,8 @@ type PodcastMetaUpdate struct {
 	URL           string
 	Description   string
 	Website       string
+	LogoURL       string
+	Tags          PodcastTags
 	NotModified   bool
 }
 
@@ -152,7 +179,9 @@ func (p *PodcastMetaUpdate) MarshalZerologObject(event *zerolog.Event) {
 		Str("title", p.Title).
 		Str("url", p.URL).
 		Str("website", p.Website).
+		Str("logo_url", p.LogoURL).
 		Str("description", p.Description).
+		Int("tags", len(p.Tags)).
 		Bool("not_modified", p.NotModified).
 		Time("metadata_updated_at", p.MetaUpdatedAt)
 }
@@ -160,6 +189,14 @@ func (p *PodcastMetaUpdate) MarshalZerologObject(event *zerolog.Event) {
 type PodcastToUpdate struct {
 	MetaUpdatedAt time.Time
 	URL           string
+	// ETag and LastModified are values of headers returned by last fetch; used for conditional requests.
+	ETag         string
+	LastModified string
+	// CheckIntervalSource and CheckInterval are schedule computed on last fetch.
+	CheckIntervalSource string
+	CheckInterval       time.Duration
+	IntervalOverride    time.Duration
+	Failures            int
 }
 
 //------------------------------------------------------------------------------
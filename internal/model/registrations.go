@@ -0,0 +1,60 @@
+package model
+
+//
+// registrations.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// InviteCode allow to register new account when registration require invitation. Code can be
+// used only once.
+type InviteCode struct {
+	CreatedAt time.Time
+	// ExpiresAt, when not zero, limit time when code can be used.
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	Code      string
+	// CreatedBy is name of admin that created code; empty when created by cli.
+	CreatedBy string
+	// UsedBy is name of registered user.
+	UsedBy string
+}
+
+// IsValid check is code unused and not expired.
+func (i *InviteCode) IsValid(now time.Time) bool {
+	return i.UsedAt.IsZero() && (i.ExpiresAt.IsZero() || i.ExpiresAt.After(now))
+}
+
+func (i *InviteCode) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("created_by", i.CreatedBy).
+		Str("used_by", i.UsedBy).
+		Time("created_at", i.CreatedAt).
+		Time("expires_at", i.ExpiresAt).
+		Time("used_at", i.UsedAt)
+}
+
+//-------------------------------------------------------------
+
+// UserRegistration is account registered by user and waiting for admin approval. Account is locked
+// until approval; password selected by user is kept here.
+type UserRegistration struct {
+	CreatedAt time.Time
+	User      *User
+	// Password is hashed password set on approval.
+	Password string
+}
+
+func (u *UserRegistration) MarshalZerologObject(event *zerolog.Event) {
+	event.Time("created_at", u.CreatedAt)
+
+	if u.User != nil {
+		event.Int64("user_id", u.User.ID).Str("user_name", u.User.UserName)
+	}
+}
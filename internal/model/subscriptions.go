@@ -22,6 +22,35 @@ type Subscription struct {
 
 // ------------------------------------------------------
 
+const (
+	SubscriptionActionSubscribe   = "subscribe"
+	SubscriptionActionUnsubscribe = "unsubscribe"
+)
+
+// SubscriptionChange is entry in subscriptions history. Change without device apply to all devices.
+type SubscriptionChange struct {
+	CreatedAt time.Time
+	DeviceID  *int64
+	PodcastID int64
+	Action    string
+}
+
+func NewSubscriptionChange(podcastid int64, deviceid *int64, subscribed bool, ts time.Time) SubscriptionChange {
+	action := SubscriptionActionUnsubscribe
+	if subscribed {
+		action = SubscriptionActionSubscribe
+	}
+
+	return SubscriptionChange{
+		CreatedAt: ts,
+		DeviceID:  deviceid,
+		PodcastID: podcastid,
+		Action:    action,
+	}
+}
+
+// ------------------------------------------------------
+
 type SubscriptionState struct {
 	Added   []Podcast
 	Removed []Podcast
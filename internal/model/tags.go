@@ -0,0 +1,65 @@
+package model
+
+//
+// tags.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import "strings"
+
+// PodcastTag is category of podcast loaded from feed.
+type PodcastTag struct {
+	// Tag is normalized Title used as identifier.
+	Tag   string
+	Title string
+	// Usage is number of podcasts with tag.
+	Usage int
+}
+
+// NewPodcastTag create tag from category name; return false when category is empty or invalid.
+func NewPodcastTag(title string) (PodcastTag, bool) {
+	title = strings.TrimSpace(title)
+
+	tag := slugify(title)
+	if tag == "" {
+		return PodcastTag{}, false
+	}
+
+	return PodcastTag{Tag: tag, Title: title}, true
+}
+
+// PodcastTags is list of tags.
+type PodcastTags []PodcastTag
+
+// Contains return true when list contain tag.
+func (p PodcastTags) Contains(tag string) bool {
+	for _, t := range p {
+		if t.Tag == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// slugify convert text to lowercase string that contain only letters, digits and `-`.
+func slugify(text string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '-'
+		}
+	}, strings.TrimSpace(text))
+
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,74 @@
+package model
+
+//
+// tokens.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// AppTokenPrefix is prefix of all generated app tokens.
+const AppTokenPrefix = "gpo-"
+
+// AppToken is application password that can be used by clients instead of user password.
+// Token may be restricted to one device and to read-only access.
+type AppToken struct {
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	User       *User
+	// Name is unique (for user) name of token.
+	Name string
+	// Token is hashed secret.
+	Token string
+	// DeviceName, when not empty, restrict token to given device.
+	DeviceName string
+	ID         int64
+	ReadOnly   bool
+}
+
+func (a *AppToken) MarshalZerologObject(event *zerolog.Event) {
+	event.Int64("id", a.ID).
+		Str("name", a.Name).
+		Str("device_name", a.DeviceName).
+		Bool("read_only", a.ReadOnly).
+		Time("created_at", a.CreatedAt).
+		Time("last_used_at", a.LastUsedAt)
+
+	if a.User != nil {
+		event.Int64("user_id", a.User.ID)
+	}
+}
+
+// IsAppToken check is `secret` look like app token.
+func IsAppToken(secret string) bool {
+	return strings.HasPrefix(secret, AppTokenPrefix)
+}
+
+//-------------------------------------------------------------
+
+// RememberToken allow user to login into web gui without password ("remember me").
+type RememberToken struct {
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	User      *User
+	// Token is hashed secret.
+	Token string
+	ID    int64
+}
+
+func (r *RememberToken) MarshalZerologObject(event *zerolog.Event) {
+	event.Int64("id", r.ID).
+		Time("created_at", r.CreatedAt).
+		Time("expires_at", r.ExpiresAt)
+
+	if r.User != nil {
+		event.Int64("user_id", r.User.ID)
+	}
+}
@@ -17,6 +17,20 @@ type User struct {
 	Name     string
 
 	Locked bool
+	// Admin is user with administrator privileges.
+	Admin bool
+
+	// TOTPSecret is secret for two-factor authentication; empty when 2FA is disabled.
+	TOTPSecret string
+	// TOTPRecoveryCodes are hashes of unused recovery codes.
+	TOTPRecoveryCodes []string
+	// TOTPLastStep is time step of last accepted totp code; codes from this and earlier steps are rejected.
+	TOTPLastStep int64
+}
+
+// TOTPEnabled return true when user must use two-factor authentication.
+func (u *User) TOTPEnabled() bool {
+	return u.TOTPSecret != ""
 }
 
 func (u *User) MarshalZerologObject(event *zerolog.Event) {
@@ -24,5 +38,21 @@ func (u *User) MarshalZerologObject(event *zerolog.Event) {
 		Str("user_name", u.UserName).
 		Str("email", u.Email).
 		Str("name", u.Name).
-		Bool("locked", u.Locked)
+		Bool("locked", u.Locked).
+		Bool("admin", u.Admin).
+		Bool("totp", u.TOTPEnabled())
+}
+
+// UserWithCounts is user with number of devices and subscribed podcasts.
+type UserWithCounts struct {
+	User          *User
+	Devices       int
+	Subscriptions int
+}
+
+// TOTPKey is secret for two-factor authentication with provisioning url (otpauth://) for
+// authenticator apps.
+type TOTPKey struct {
+	Secret string
+	URL    string
 }
@@ -0,0 +1,45 @@
+package query
+
+//
+// audit.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+// GetAuditEventsQuery define filters for audit log events. Empty fields are not used for filtering.
+type GetAuditEventsQuery struct {
+	Since    time.Time
+	UserName string
+	Action   string
+	Limit    uint
+}
+
+func (q *GetAuditEventsQuery) Validate() error {
+	if q.UserName != "" && !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if q.Action != "" && !model.IsValidAuditAction(q.Action) {
+		return aerr.ErrValidation.WithUserMsg("invalid action %q", q.Action)
+	}
+
+	return nil
+}
+
+func (q *GetAuditEventsQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName).
+		Str("action", q.Action).
+		Time("since", q.Since).
+		Uint("limit", q.Limit)
+}
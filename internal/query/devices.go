@@ -22,3 +22,17 @@ func (q *GetDevicesQuery) Validate() error {
 
 	return nil
 }
+
+// ------------------------------------------------------
+
+type GetSyncStatusQuery struct {
+	UserName string
+}
+
+func (q *GetSyncStatusQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	return nil
+}
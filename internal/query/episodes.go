@@ -127,3 +127,63 @@ func (q *GetLastEpisodesActionsQuery) MarshalZerologObject(event *zerolog.Event)
 		Time("since", q.Since).
 		Uint("limit", q.Limit)
 }
+
+//------------------------------------------------------------------------------
+
+// GetFeedEpisodesQuery define arguments used to get episodes loaded from podcast feed.
+type GetFeedEpisodesQuery struct {
+	Since      time.Time
+	UserName   string
+	PodcastURL string
+	Limit      uint
+}
+
+func (q *GetFeedEpisodesQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if q.PodcastURL == "" {
+		return common.ErrInvalidPodcast.WithUserMsg("missing podcast url")
+	}
+
+	return nil
+}
+
+func (q *GetFeedEpisodesQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName).
+		Str("podcast_url", q.PodcastURL).
+		Time("since", q.Since).
+		Uint("limit", q.Limit)
+}
+
+//------------------------------------------------------------------------------
+
+// GetEpisodeDataQuery define arguments used to get metadata of episode known to instance.
+type GetEpisodeDataQuery struct {
+	UserName   string
+	PodcastURL string
+	EpisodeURL string
+}
+
+func (q *GetEpisodeDataQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if q.PodcastURL == "" {
+		return common.ErrInvalidPodcast.WithUserMsg("missing podcast url")
+	}
+
+	if q.EpisodeURL == "" {
+		return common.ErrInvalidEpisode.WithUserMsg("missing episode url")
+	}
+
+	return nil
+}
+
+func (q *GetEpisodeDataQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName).
+		Str("podcast_url", q.PodcastURL).
+		Str("episode_url", q.EpisodeURL)
+}
@@ -0,0 +1,55 @@
+package query
+
+//
+// lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+type GetPodcastListsQuery struct {
+	UserName string
+}
+
+func (q *GetPodcastListsQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	return nil
+}
+
+func (q *GetPodcastListsQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName)
+}
+
+//------------------------------------------------------------------------------
+
+type GetPodcastListQuery struct {
+	UserName string
+	ListName string
+}
+
+func (q *GetPodcastListQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if q.ListName == "" {
+		return aerr.ErrValidation.WithUserMsg("missing list name")
+	}
+
+	return nil
+}
+
+func (q *GetPodcastListQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName).
+		Str("list_name", q.ListName)
+}
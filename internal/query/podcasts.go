@@ -0,0 +1,152 @@
+package query
+
+//
+// podcasts.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"strings"
+
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+// SearchPodcastsQuery define arguments used to search podcasts known to instance.
+type SearchPodcastsQuery struct {
+	Text  string
+	Limit uint
+}
+
+func (q *SearchPodcastsQuery) Validate() error {
+	if strings.TrimSpace(q.Text) == "" {
+		return aerr.ErrValidation.WithUserMsg("empty search query")
+	}
+
+	return nil
+}
+
+func (q *SearchPodcastsQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("text", q.Text).
+		Uint("limit", q.Limit)
+}
+
+//------------------------------------------------------------------------------
+
+// MaxToplistLength is maximal number of podcasts returned by toplist and suggestions.
+const MaxToplistLength = 100
+
+// GetToplistQuery define arguments used to get most popular podcasts.
+type GetToplistQuery struct {
+	Limit uint
+}
+
+func (q *GetToplistQuery) Validate() error {
+	if q.Limit == 0 || q.Limit > MaxToplistLength {
+		return aerr.ErrValidation.WithUserMsg("invalid number of podcasts")
+	}
+
+	return nil
+}
+
+func (q *GetToplistQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Uint("limit", q.Limit)
+}
+
+//------------------------------------------------------------------------------
+
+// GetSuggestionsQuery define arguments used to get podcasts suggested for user.
+type GetSuggestionsQuery struct {
+	UserName string
+	Limit    uint
+}
+
+func (q *GetSuggestionsQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if q.Limit == 0 || q.Limit > MaxToplistLength {
+		return aerr.ErrValidation.WithUserMsg("invalid number of podcasts")
+	}
+
+	return nil
+}
+
+func (q *GetSuggestionsQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName).
+		Uint("limit", q.Limit)
+}
+
+//------------------------------------------------------------------------------
+
+// GetPodcastDataQuery define arguments used to get metadata of podcast known to instance.
+type GetPodcastDataQuery struct {
+	UserName   string
+	PodcastURL string
+}
+
+func (q *GetPodcastDataQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	if q.PodcastURL == "" {
+		return common.ErrInvalidPodcast.WithUserMsg("missing podcast url")
+	}
+
+	return nil
+}
+
+func (q *GetPodcastDataQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName).
+		Str("podcast_url", q.PodcastURL)
+}
+
+//------------------------------------------------------------------------------
+
+// GetTagsQuery define arguments used to get most used podcasts tags.
+type GetTagsQuery struct {
+	Limit uint
+}
+
+func (q *GetTagsQuery) Validate() error {
+	if q.Limit == 0 || q.Limit > MaxToplistLength {
+		return aerr.ErrValidation.WithUserMsg("invalid number of tags")
+	}
+
+	return nil
+}
+
+func (q *GetTagsQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Uint("limit", q.Limit)
+}
+
+//------------------------------------------------------------------------------
+
+// GetTagPodcastsQuery define arguments used to get most popular podcasts with tag.
+type GetTagPodcastsQuery struct {
+	Tag   string
+	Limit uint
+}
+
+func (q *GetTagPodcastsQuery) Validate() error {
+	if strings.TrimSpace(q.Tag) == "" {
+		return aerr.ErrValidation.WithUserMsg("missing tag")
+	}
+
+	if q.Limit == 0 || q.Limit > MaxToplistLength {
+		return aerr.ErrValidation.WithUserMsg("invalid number of podcasts")
+	}
+
+	return nil
+}
+
+func (q *GetTagPodcastsQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("tag", q.Tag).
+		Uint("limit", q.Limit)
+}
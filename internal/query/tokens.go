@@ -0,0 +1,30 @@
+package query
+
+//
+// tokens.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/validators"
+)
+
+type GetAppTokensQuery struct {
+	UserName string
+}
+
+func (q *GetAppTokensQuery) Validate() error {
+	if !validators.IsValidUserName(q.UserName) {
+		return common.ErrInvalidUser.WithUserMsg("invalid username")
+	}
+
+	return nil
+}
+
+func (q *GetAppTokensQuery) MarshalZerologObject(event *zerolog.Event) {
+	event.Str("username", q.UserName)
+}
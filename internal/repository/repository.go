@@ -30,7 +30,74 @@ type Users interface {
 	GetUser(ctx context.Context, username string) (*model.User, error)
 	SaveUser(ctx context.Context, user *model.User) (int64, error)
 	ListUsers(ctx context.Context, activeOnly bool) ([]model.User, error)
+	// ListUsersWithCounts return all users with number of devices and subscribed podcasts.
+	ListUsersWithCounts(ctx context.Context) ([]model.UserWithCounts, error)
 	DeleteUser(ctx context.Context, userid int64) error
+	// UpdateTOTPLastStep save time step of accepted totp code when it is later than saved one.
+	// Return false when code from this step was already used.
+	UpdateTOTPLastStep(ctx context.Context, userid, step int64) (bool, error)
+	// LockUser lock account and keep current password, so account can be unlocked later.
+	LockUser(ctx context.Context, userid int64) error
+	// UnlockUser restore password kept by LockUser. Return false when account is not locked or
+	// password is unknown.
+	UnlockUser(ctx context.Context, userid int64) (bool, error)
+}
+
+type AppTokens interface {
+	ListAppTokens(ctx context.Context, userid int64) ([]model.AppToken, error)
+	// SaveAppToken insert new token or update last usage time of existing one.
+	SaveAppToken(ctx context.Context, token *model.AppToken) (int64, error)
+	DeleteAppToken(ctx context.Context, tokenid int64) error
+}
+
+type RememberTokens interface {
+	// GetRememberToken find token by hashed secret; returned token contain user id and name.
+	GetRememberToken(ctx context.Context, token string) (*model.RememberToken, error)
+	SaveRememberToken(ctx context.Context, token *model.RememberToken) (int64, error)
+	DeleteRememberToken(ctx context.Context, token string) error
+	// DeleteUserRememberTokens revoke all tokens of user.
+	DeleteUserRememberTokens(ctx context.Context, userid int64) error
+}
+
+type LoginFailures interface {
+	GetLoginFailure(ctx context.Context, kind, subject string) (*model.LoginFailure, error)
+	// SaveLoginFailure insert or update login failure.
+	SaveLoginFailure(ctx context.Context, failure *model.LoginFailure) error
+	ListLoginFailures(ctx context.Context) ([]model.LoginFailure, error)
+	// DeleteLoginFailures delete failures for subject; empty subject delete all failures given kind;
+	// empty kind delete all failures.
+	DeleteLoginFailures(ctx context.Context, kind, subject string) error
+	// DeleteExpiredLoginFailures delete not locked failures registered before `before`; return number
+	// of deleted rows.
+	DeleteExpiredLoginFailures(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Registrations interface {
+	GetInviteCode(ctx context.Context, code string) (*model.InviteCode, error)
+	// SaveInviteCode insert or update invite code.
+	SaveInviteCode(ctx context.Context, code *model.InviteCode) error
+	// UseInviteCode mark not used yet invite code as used by `username`; return false when code is
+	// unknown or already used.
+	UseInviteCode(ctx context.Context, code, username string, usedAt time.Time) (bool, error)
+	ListInviteCodes(ctx context.Context) ([]model.InviteCode, error)
+	DeleteInviteCode(ctx context.Context, code string) error
+
+	// GetUserRegistration find pending registration for user; returned registration contain user id only.
+	GetUserRegistration(ctx context.Context, userid int64) (*model.UserRegistration, error)
+	SaveUserRegistration(ctx context.Context, registration *model.UserRegistration) error
+	// ListUserRegistrations return pending registrations with users.
+	ListUserRegistrations(ctx context.Context) ([]model.UserRegistration, error)
+	DeleteUserRegistration(ctx context.Context, userid int64) error
+}
+
+type AuditLog interface {
+	SaveAuditEvent(ctx context.Context, event *model.AuditEvent) error
+	// ListAuditEvents return events from newest; empty username, action, zero since and limit are not
+	// used for filtering.
+	ListAuditEvents(ctx context.Context, username, action string, since time.Time, limit uint,
+	) ([]model.AuditEvent, error)
+	// DeleteAuditEvents delete events created before `before`; return number of deleted events.
+	DeleteAuditEvents(ctx context.Context, before time.Time) (int64, error)
 }
 
 type Episodes interface {
@@ -44,7 +111,19 @@ type Episodes interface {
 	ListFavorites(ctx context.Context, userid int64) ([]model.Episode, error)
 	GetLastEpisodeAction(ctx context.Context,
 		userid, podcastid int64, excludeDelete bool) (*model.Episode, error)
-	UpdateEpisodeInfo(ctx context.Context, episodes ...model.Episode) error
+	// UpdateEpisodeInfo update title and guid of episodes of all users and save metadata of episodes
+	// loaded from feed of podcast `podcasturl`.
+	UpdateEpisodeInfo(ctx context.Context, podcasturl string, episodes ...model.Episode) error
+	// ListFeedEpisodes return episodes loaded from feed of podcast `podcasturl` released after `since`;
+	// newest first.
+	ListFeedEpisodes(ctx context.Context, podcasturl string, since time.Time, limit uint) ([]model.Episode, error)
+	// ListNewEpisodes return episodes published (or found in feeds when publication date is unknown)
+	// after `since` and without any user action. Only podcasts subscribed by device (when `deviceid`
+	// is given) or by user are checked.
+	ListNewEpisodes(ctx context.Context, userid int64, deviceid *int64, since time.Time) ([]model.Episode, error)
+	// GetCatalogEpisode return episode with podcast metadata regardless of user. Return ErrNoData when
+	// no user has this episode.
+	GetCatalogEpisode(ctx context.Context, podcasturl, episodeurl string) (*model.Episode, error)
 }
 
 type Podcasts interface {
@@ -53,10 +132,67 @@ type Podcasts interface {
 	GetPodcast(ctx context.Context, userid int64, podcasturl string) (*model.Podcast, error)
 	GetPodcastByID(ctx context.Context, userid, podcastid int64) (*model.Podcast, error)
 	SavePodcast(ctx context.Context, podcast *model.Podcast) (int64, error)
-	// ListPodcastsToUpdate return list of url-s podcasts that need update (load title etc).
-	ListPodcastsToUpdate(ctx context.Context, since time.Time) ([]model.PodcastToUpdate, error)
+	// ListPodcastsToUpdate return list of url-s podcasts that need update (load title etc). When `scheduled`
+	// is set, only feeds which time of next check passed are returned.
+	ListPodcastsToUpdate(ctx context.Context, since time.Time, scheduled bool) ([]model.PodcastToUpdate, error)
 	UpdatePodcastsInfo(ctx context.Context, podcast *model.PodcastMetaUpdate) error
+	// GetFeedState return fetch state of feed `podcasturl`. Return ErrNoData when feed is unknown.
+	GetFeedState(ctx context.Context, podcasturl string) (*model.FeedState, error)
+	// SaveFeedState update fetch state of existing feed.
+	SaveFeedState(ctx context.Context, state *model.FeedState) error
+	// ListFeedStates return fetch state of feeds subscribed by any user; ordered from dead and failing.
+	ListFeedStates(ctx context.Context, failingOnly bool) ([]model.FeedState, error)
+	// ListPodcastsByURL return podcasts of all users with given url.
+	ListPodcastsByURL(ctx context.Context, podcasturl string) (model.Podcasts, error)
+	// MovePodcastData move episodes and settings of podcast `fromid` to podcast `toid` of the same user.
+	// Devices subscribing `fromid` are unsubscribed from it and subscribed to `toid` at `timestamp`.
+	MovePodcastData(ctx context.Context, fromid, toid int64, timestamp time.Time) error
+	// MoveFeed change url of feed, its tags and podcast lists items from `oldurl` to `newurl`. When feed
+	// `newurl` already exists, episodes are merged into it. Redirect from `oldurl` is remembered.
+	MoveFeed(ctx context.Context, oldurl, newurl string) error
+	// GetFeedRedirect return current url of feed moved from `podcasturl`. Return ErrNoData when feed
+	// was not moved.
+	GetFeedRedirect(ctx context.Context, podcasturl string) (string, error)
 	DeletePodcast(ctx context.Context, podcastid int64) error
+	// SearchPodcasts find podcasts of all users by title, description or url. Result contains
+	// unique podcasts with number of subscribers.
+	SearchPodcasts(ctx context.Context, text string, limit uint) (model.Podcasts, error)
+	// ListTopPodcasts return podcasts with the biggest number of subscribers.
+	ListTopPodcasts(ctx context.Context, limit uint) (model.Podcasts, error)
+	// ListSuggestedPodcasts return podcasts not subscribed by user but subscribed by other users that
+	// share subscriptions with user.
+	ListSuggestedPodcasts(ctx context.Context, userid int64, limit uint) (model.Podcasts, error)
+	// GetCatalogPodcast return podcast with metadata and number of subscribers aggregated over all users.
+	// Return ErrNoData when podcast is unknown.
+	GetCatalogPodcast(ctx context.Context, podcasturl string) (*model.Podcast, error)
+	// ListTags return most used tags of subscribed podcasts.
+	ListTags(ctx context.Context, limit uint) ([]model.PodcastTag, error)
+	// ListPodcastsByTag return podcasts with tag ordered by number of subscribers.
+	ListPodcastsByTag(ctx context.Context, tag string, limit uint) (model.Podcasts, error)
+	// ListPodcastsTags return tags of all user podcasts; key is podcast url.
+	ListPodcastsTags(ctx context.Context, userid int64) (map[string]model.PodcastTags, error)
+}
+
+type Subscriptions interface {
+	// SaveSubscriptionChanges append changes to subscriptions history.
+	SaveSubscriptionChanges(ctx context.Context, changes ...model.SubscriptionChange) error
+	// ListDeviceSubscriptions return user podcasts with state taken from last subscription change made
+	// by device or for all devices; only podcasts changed after `since` are returned.
+	ListDeviceSubscriptions(ctx context.Context, userid, deviceid int64, since time.Time) (model.Podcasts, error)
+	// IsPodcastSubscribed return true when podcast is subscribed by any user device, i.e. last change
+	// of podcast made by the device or for all devices is subscription. Change made for all devices
+	// is also used when user has no devices.
+	IsPodcastSubscribed(ctx context.Context, podcastid int64) (bool, error)
+}
+
+type PodcastLists interface {
+	// ListPodcastLists return all lists created by user (without podcasts).
+	ListPodcastLists(ctx context.Context, userid int64) ([]model.PodcastList, error)
+	// GetPodcastList return list with podcasts; podcasts metadata are taken from podcasts of all users.
+	GetPodcastList(ctx context.Context, userid int64, name string) (*model.PodcastList, error)
+	// SavePodcastList insert or update list and replace its podcasts.
+	SavePodcastList(ctx context.Context, list *model.PodcastList) (int64, error)
+	DeletePodcastList(ctx context.Context, listid int64) error
 }
 
 type Settings interface {
@@ -74,13 +210,22 @@ type Sessions interface {
 	CleanSessions(ctx context.Context, maxLifeTime, maxLifeTimeForEmpty time.Duration) error
 	ReadOrCreate(ctx context.Context, sid string, maxLifeTime time.Duration) (*model.Session, error)
 	SessionExists(ctx context.Context, sid string) (bool, error)
+	// DeleteUserSessions delete all sessions where `username` is logged; return number of deleted sessions.
+	DeleteUserSessions(ctx context.Context, username string) (int, error)
 }
 
 type Repository interface {
 	Devices
 	Users
+	AppTokens
+	RememberTokens
+	LoginFailures
+	Registrations
+	AuditLog
 	Episodes
 	Podcasts
+	Subscriptions
+	PodcastLists
 	Settings
 	Sessions
 }
@@ -0,0 +1,165 @@
+package server
+
+//
+// ldap.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/rs/zerolog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+const ldapTimeout = 10 * time.Second
+
+// ldapAuthenticator verify user credentials by binding to ldap directory. Access and admin role
+// are granted by membership in configured groups. Local account is created on first successful
+// login and updated on each next.
+//
+// Used by basicAuthenticator instead of local passwords.
+type ldapAuthenticator struct {
+	usersSrv *service.UsersSrv
+	cfg      *config.LDAPConf
+}
+
+func (l ldapAuthenticator) LoginUser(ctx context.Context, username, password string) (*model.User, error) {
+	ctx, end := common.NewTask(ctx, "LDAPLoginUser")
+	defer end()
+
+	if username == "" {
+		return nil, common.ErrEmptyUsername
+	}
+
+	// empty password mean unauthenticated bind that always succeed
+	if password == "" {
+		return nil, common.ErrUnauthorized
+	}
+
+	conn, err := l.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	userdn := strings.ReplaceAll(l.cfg.UserDN, "{username}", ldap.EscapeDN(username))
+
+	logger := zerolog.Ctx(ctx)
+	logger.Debug().Str("user_dn", userdn).Msgf("LDAPAuthenticator: bind user_dn=%q", userdn)
+
+	if err := conn.Bind(userdn, password); err != nil {
+		if ldap.IsErrorAnyOf(err, ldap.LDAPResultInvalidCredentials, ldap.LDAPResultNoSuchObject) {
+			return nil, common.ErrUnauthorized.WithError(err)
+		}
+
+		return nil, aerr.Wrapf(err, "ldap bind failed").WithTag(aerr.InternalError)
+	}
+
+	cmd := command.ProvisionUserCmd{UserName: username}
+
+	if err := l.loadUserAttributes(conn, userdn, &cmd); err != nil {
+		return nil, err
+	}
+
+	if l.cfg.GroupBaseDN != "" {
+		groups, err := l.getUserGroups(conn, userdn)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Debug().Strs("groups", groups).Msgf("LDAPAuthenticator: user_dn=%q groups=%q", userdn, groups)
+
+		if l.cfg.AccessGroup != "" && !containsDN(groups, l.cfg.AccessGroup) {
+			return nil, common.ErrUnauthorized.WithMsg("user not in ldap access group")
+		}
+
+		cmd.Admin = l.cfg.AdminGroup != "" && containsDN(groups, l.cfg.AdminGroup)
+	}
+
+	user, err := l.usersSrv.ProvisionUser(ctx, &cmd)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "provision ldap user failed")
+	}
+
+	return user, nil
+}
+
+func (l ldapAuthenticator) connect() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(l.cfg.URL, ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}))
+	if err != nil {
+		return nil, aerr.Wrapf(err, "connect to ldap server failed").WithTag(aerr.InternalError).
+			WithMeta("url", l.cfg.URL)
+	}
+
+	conn.SetTimeout(ldapTimeout)
+
+	if l.cfg.StartTLS {
+		u, _ := url.Parse(l.cfg.URL)
+
+		err := conn.StartTLS(&tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err != nil {
+			conn.Close()
+
+			return nil, aerr.Wrapf(err, "ldap start tls failed").WithTag(aerr.InternalError)
+		}
+	}
+
+	return conn, nil
+}
+
+// loadUserAttributes load email and name of user from directory.
+func (l ldapAuthenticator) loadUserAttributes(conn *ldap.Conn, userdn string, cmd *command.ProvisionUserCmd) error {
+	req := ldap.NewSearchRequest(userdn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)", []string{l.cfg.EmailAttr, l.cfg.NameAttr}, nil)
+
+	res, err := conn.Search(req)
+	if err != nil {
+		return aerr.Wrapf(err, "ldap search user failed").WithTag(aerr.InternalError).WithMeta("user_dn", userdn)
+	}
+
+	if len(res.Entries) > 0 {
+		cmd.Email = res.Entries[0].GetAttributeValue(l.cfg.EmailAttr)
+		cmd.Name = res.Entries[0].GetAttributeValue(l.cfg.NameAttr)
+	}
+
+	return nil
+}
+
+// getUserGroups return dn of all groups that user belong to.
+func (l ldapAuthenticator) getUserGroups(conn *ldap.Conn, userdn string) ([]string, error) {
+	filter := strings.ReplaceAll(l.cfg.GroupFilter, "{userdn}", ldap.EscapeFilter(userdn))
+	req := ldap.NewSearchRequest(l.cfg.GroupBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{"dn"}, nil)
+
+	res, err := conn.Search(req)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "ldap search groups failed").WithTag(aerr.InternalError).
+			WithMeta("filter", filter)
+	}
+
+	groups := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		groups = append(groups, e.DN)
+	}
+
+	return groups, nil
+}
+
+func containsDN(dns []string, dn string) bool {
+	return slices.ContainsFunc(dns, func(d string) bool { return strings.EqualFold(d, dn) })
+}
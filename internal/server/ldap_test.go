@@ -0,0 +1,264 @@
+package server
+
+//
+// ldap_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+const (
+	testLDAPAccessGroup = "cn=gpo,ou=groups,dc=example,dc=com"
+	testLDAPAdminGroup  = "cn=admins,ou=groups,dc=example,dc=com"
+)
+
+func TestLDAPLoginUser(t *testing.T) {
+	ctx, i := prepareTests(t)
+	auth := newTestLDAPAuthenticator(t, i)
+
+	user, err := auth.LoginUser(ctx, "user1", "secret1")
+	if err != nil {
+		t.Fatalf("login error: %#+v", err)
+	}
+
+	if user.ID == 0 || user.Email != "user1@example.com" || user.Name != "User 1" || user.Admin {
+		t.Errorf("invalid user: %+v", user)
+	}
+
+	// local account is created
+	if _, err := do.MustInvoke[*service.UsersSrv](i).CheckUser(ctx, "user1"); err != nil {
+		t.Errorf("check local user error: %#+v", err)
+	}
+
+	// next login use existing account
+	user2, err := auth.LoginUser(ctx, "user1", "secret1")
+	if err != nil {
+		t.Fatalf("login error: %#+v", err)
+	}
+
+	if user2.ID != user.ID {
+		t.Errorf("invalid user id: %d, expected: %d", user2.ID, user.ID)
+	}
+}
+
+func TestLDAPLoginAdmin(t *testing.T) {
+	ctx, i := prepareTests(t)
+	auth := newTestLDAPAuthenticator(t, i)
+
+	user, err := auth.LoginUser(ctx, "admin", "secret2")
+	if err != nil {
+		t.Fatalf("login error: %#+v", err)
+	}
+
+	if !user.Admin {
+		t.Errorf("user should be admin: %+v", user)
+	}
+}
+
+func TestLDAPLoginInvalid(t *testing.T) {
+	ctx, i := prepareTests(t)
+	auth := newTestLDAPAuthenticator(t, i)
+
+	cases := []struct {
+		username, password string
+	}{
+		{"user1", "invalid"},
+		{"user1", ""},
+		{"unknown", "secret1"},
+		// user3 is not in access group
+		{"user3", "secret3"},
+	}
+
+	for _, c := range cases {
+		_, err := auth.LoginUser(ctx, c.username, c.password)
+		if !aerr.HasTag(err, common.AuthenticationError) {
+			t.Errorf("user=%q password=%q: expected unauthorized error, got: %#+v", c.username, c.password, err)
+		}
+	}
+
+	if _, err := do.MustInvoke[*service.UsersSrv](i).CheckUser(ctx, "user3"); !errors.Is(err, common.ErrUserNotFound) {
+		t.Errorf("user should not be created: %#+v", err)
+	}
+}
+
+//-------------------------------------------------------------
+
+func newTestLDAPAuthenticator(t *testing.T, i do.Injector) ldapAuthenticator {
+	t.Helper()
+
+	srv := newMockLDAPServer(t)
+	srv.addUser("user1", "secret1", "user1@example.com", "User 1", testLDAPAccessGroup)
+	srv.addUser("admin", "secret2", "admin@example.com", "Admin", testLDAPAccessGroup, testLDAPAdminGroup)
+	srv.addUser("user3", "secret3", "user3@example.com", "User 3")
+
+	cfg := config.LDAPConf{
+		URL:         "ldap://" + srv.listener.Addr().String(),
+		UserDN:      "uid={username},ou=people,dc=example,dc=com",
+		GroupBaseDN: "ou=groups,dc=example,dc=com",
+		AccessGroup: testLDAPAccessGroup,
+		AdminGroup:  testLDAPAdminGroup,
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("validate config error: %#+v", err)
+	}
+
+	return ldapAuthenticator{
+		usersSrv: do.MustInvoke[*service.UsersSrv](i),
+		cfg:      &cfg,
+	}
+}
+
+// mockLDAPUser is user entry in mockLDAPServer.
+type mockLDAPUser struct {
+	password string
+	attrs    map[string]string
+	groups   []string
+}
+
+// mockLDAPServer is minimal ldap server that support simple bind and search for bound user entry and
+// groups.
+type mockLDAPServer struct {
+	listener net.Listener
+	users    map[string]mockLDAPUser
+}
+
+func newMockLDAPServer(t *testing.T) *mockLDAPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %#+v", err)
+	}
+
+	srv := &mockLDAPServer{listener: listener, users: make(map[string]mockLDAPUser)}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go srv.serve(conn)
+		}
+	}()
+
+	return srv
+}
+
+func (s *mockLDAPServer) addUser(uid, password, mail, name string, groups ...string) {
+	s.users["uid="+uid+",ou=people,dc=example,dc=com"] = mockLDAPUser{
+		password: password,
+		attrs:    map[string]string{"mail": mail, "cn": name},
+		groups:   groups,
+	}
+}
+
+func (s *mockLDAPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	bounddn := ""
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 { //nolint:mnd
+			return
+		}
+
+		msgid, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, _ := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+
+			if user, ok := s.users[dn]; ok && user.password == password {
+				bounddn = dn
+				s.writeResult(conn, msgid, ldap.ApplicationBindResponse, ldap.LDAPResultSuccess)
+			} else {
+				s.writeResult(conn, msgid, ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials)
+			}
+		case ldap.ApplicationSearchRequest:
+			base, _ := op.Children[0].Value.(string)
+			s.search(conn, msgid, base, bounddn)
+			s.writeResult(conn, msgid, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (s *mockLDAPServer) search(conn net.Conn, msgid int64, base, bounddn string) {
+	user, ok := s.users[bounddn]
+	if !ok {
+		return
+	}
+
+	if base == bounddn {
+		s.writeEntry(conn, msgid, bounddn, user.attrs)
+
+		return
+	}
+
+	for _, g := range user.groups {
+		if strings.HasSuffix(g, ","+base) {
+			s.writeEntry(conn, msgid, g, nil)
+		}
+	}
+}
+
+func (s *mockLDAPServer) writeResult(conn net.Conn, msgid int64, tag ber.Tag, code uint16) {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matched dn"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "message"))
+
+	s.write(conn, msgid, op)
+}
+
+func (s *mockLDAPServer) writeEntry(conn net.Conn, msgid int64, dn string, attrs map[string]string) {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "dn"))
+
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+
+	for name, value := range attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "name"))
+
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "values")
+		values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "value"))
+		attr.AppendChild(values)
+		attributes.AppendChild(attr)
+	}
+
+	op.AppendChild(attributes)
+
+	s.write(conn, msgid, op)
+}
+
+func (s *mockLDAPServer) write(conn net.Conn, msgid int64, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "message")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgid, "id"))
+	packet.AppendChild(op)
+
+	_, _ = conn.Write(packet.Bytes())
+}
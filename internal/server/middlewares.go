@@ -10,16 +10,19 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
 	"gitea.com/go-chi/session"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
@@ -28,6 +31,7 @@ import (
 	"gitlab.com/kabes/go-gpo/internal/aerr"
 	"gitlab.com/kabes/go-gpo/internal/common"
 	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/model"
 	"gitlab.com/kabes/go-gpo/internal/repository"
 	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
 	"gitlab.com/kabes/go-gpo/internal/service"
@@ -67,14 +71,46 @@ func newAuthenticator(i do.Injector) (authenticator, error) { //nolint:ireturn
 
 	switch cfg.AuthMethod {
 	case "basic":
+		usersSrv := do.MustInvoke[*service.UsersSrv](i)
+
 		return basicAuthenticator{
+			passwordAuth: usersSrv,
+			tokensSrv:    do.MustInvoke[*service.AppTokensSrv](i),
+			guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+			auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+			web:          newWebLogin(i, usersSrv),
+		}, nil
+	case "ldap":
+		ldapAuth := ldapAuthenticator{
 			usersSrv: do.MustInvoke[*service.UsersSrv](i),
+			cfg:      &cfg.LDAP,
+		}
+
+		return basicAuthenticator{
+			passwordAuth: ldapAuth,
+			tokensSrv:    do.MustInvoke[*service.AppTokensSrv](i),
+			guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+			auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+			web:          newWebLogin(i, ldapAuth),
 		}, nil
 	case "proxy":
 		return proxyAuthenticator{
 			usersSrv: do.MustInvoke[*service.UsersSrv](i),
+			auditSrv: do.MustInvoke[*service.AuditSrv](i),
 			cfg:      cfg,
 		}, nil
+	case "oidc":
+		return &oidcAuthenticator{
+			basic: basicAuthenticator{
+				passwordAuth: do.MustInvoke[*service.UsersSrv](i),
+				tokensSrv:    do.MustInvoke[*service.AppTokensSrv](i),
+				guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+				auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+			},
+			usersSrv: do.MustInvoke[*service.UsersSrv](i),
+			cfg:      &cfg.OIDC,
+			webroot:  cfg.MainServer.WebRoot,
+		}, nil
 	}
 
 	return nil, aerr.ErrInvalidConf.WithUserMsg("unknown auth method: %s", cfg.AuthMethod)
@@ -82,8 +118,26 @@ func newAuthenticator(i do.Injector) (authenticator, error) { //nolint:ireturn
 
 //-------------------------------------------------------------
 
+// passwordAuthenticator verify user name and password.
+type passwordAuthenticator interface {
+	LoginUser(ctx context.Context, username, password string) (*model.User, error)
+}
+
+// basicAuthenticator authenticate users by basic auth. As password may be used user password or
+// app token. When `web` is set, unauthenticated web gui requests are redirected to login form.
+// Failed logins are limited by `guardSrv`. Logins are recorded in audit log.
 type basicAuthenticator struct {
-	usersSrv *service.UsersSrv
+	passwordAuth passwordAuthenticator
+	tokensSrv    *service.AppTokensSrv
+	guardSrv     *service.LoginGuardSrv
+	auditSrv     *service.AuditSrv
+	web          *webLogin
+}
+
+func (a basicAuthenticator) publicRoutes(router chi.Router) {
+	if a.web != nil {
+		a.web.routes(router)
+	}
 }
 
 func (a basicAuthenticator) handle(next http.Handler) http.Handler {
@@ -97,6 +151,12 @@ func (a basicAuthenticator) handle(next http.Handler) http.Handler {
 
 		defer common.NewRegion(ctx, "authenticator handle").End()
 
+		if sessionuser == "" && !basicAuthOk && a.web != nil && isWebRequest(r, a.web.webroot) {
+			a.web.handleAnonymous(w, r.WithContext(ctx), next)
+
+			return
+		}
+
 		// (no valid session, no auth, continue to next handler) or
 		// (session is valid and (there is no new auth or there is auth but username is not changed))
 		// - continue
@@ -108,13 +168,51 @@ func (a basicAuthenticator) handle(next http.Handler) http.Handler {
 
 		common.TraceLazyPrintf(ctx, "Authenticator: start login user")
 
-		switch _, err := a.usersSrv.LoginUser(ctx, username, password); {
+		ip := remoteIP(r)
+
+		if wait, err := a.guardSrv.CheckLogin(ctx, username, ip); err != nil {
+			logger.Error().Err(err).Msgf("Authenticator: check login error user_name=%s error=%q", username, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		} else if wait > 0 {
+			logger.Info().Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+				Str(common.LogKeyAuthFailReason, "locked").
+				Msgf("Authenticator: login locked user_name=%s remote=%s", username, ip)
+			a.auditSrv.Record(ctx, model.AuditLoginFailed, username, "basic auth: too many failed logins")
+			writeLoginLocked(w, r, wait)
+
+			return
+		}
+
+		switch user, token, err := a.login(ctx, username, password); {
 		case err == nil:
 			// no error login/check user - continue
+			// password is not enough to access web gui when 2fa is enabled
+			totpRequired := token == nil && user.TOTPEnabled()
+
+			// failures are reset after second factor is verified
+			if !totpRequired {
+				if err := a.guardSrv.LoginSucceeded(ctx, username); err != nil {
+					logger.Error().Err(err).Msgf("Authenticator: reset login failures error=%q", err)
+				}
+			}
+
 			_ = sess.Set("user", username)
+			srvsupport.SetSessionAppToken(sess, token)
+			srvsupport.SetSessionTOTPRequired(sess, totpRequired)
+
+			l := logger.Info().Str(common.LogKeyAuthResult, common.LogAuthResultSuccess)
+			details := "basic auth"
+
+			if token != nil {
+				l = l.Str("app_token", token.Name)
+				details = "app token: " + token.Name
+			}
 
-			logger.Info().Str(common.LogKeyAuthResult, common.LogAuthResultSuccess).
-				Msgf("Authenticator: user authenticated user_name=%s", username)
+			a.auditSrv.Record(ctx, model.AuditLogin, username, details)
+
+			l.Msgf("Authenticator: user authenticated user_name=%s", username)
 			common.TraceLazyPrintf(ctx, "Authenticator: user authenticated")
 			next.ServeHTTP(w, r.WithContext(common.ContextWithUser(ctx, username)))
 
@@ -124,6 +222,12 @@ func (a basicAuthenticator) handle(next http.Handler) http.Handler {
 				Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
 				Str(common.LogKeyAuthFailReason, err.Error()).
 				Msgf("Authenticator: user authentication failed user_name=%s error=%q", username, err)
+			a.auditSrv.Record(ctx, model.AuditLoginFailed, username,
+				"basic auth: "+aerr.GetUserMessageOr(err, "authorization failed"))
+
+			if err := a.guardSrv.LoginFailed(ctx, username, ip); err != nil {
+				logger.Error().Err(err).Msgf("Authenticator: register login failure error=%q", err)
+			}
 
 			common.TraceLazyPrintf(ctx, "Authenticator: auth failed")
 			w.Header().Add("WWW-Authenticate", "Basic realm=\"go-gpo\"")
@@ -140,6 +244,26 @@ func (a basicAuthenticator) handle(next http.Handler) http.Handler {
 	})
 }
 
+// login authenticate user by app token or password. Return user and token when it was used to
+// authenticate user.
+func (a basicAuthenticator) login(ctx context.Context, username, password string,
+) (*model.User, *model.AppToken, error) {
+	if model.IsAppToken(password) {
+		token, err := a.tokensSrv.LoginWithToken(ctx, username, password)
+		if err == nil {
+			return token.User, token, nil
+		} else if !aerr.HasTag(err, common.AuthenticationError) {
+			return nil, nil, err //nolint:wrapcheck
+		}
+
+		// not valid token; check is it user password
+	}
+
+	user, err := a.passwordAuth.LoginUser(ctx, username, password)
+
+	return user, nil, err //nolint:wrapcheck
+}
+
 //-------------------------------------------------------------
 
 // proxyAuthenticator use reverse proxy on front of go-gpo to authenticate users.
@@ -147,6 +271,7 @@ func (a basicAuthenticator) handle(next http.Handler) http.Handler {
 // Proxy address must be on list accepted proxy ip/nets.
 type proxyAuthenticator struct {
 	usersSrv *service.UsersSrv
+	auditSrv *service.AuditSrv
 	cfg      *config.ServerConf
 }
 
@@ -189,6 +314,7 @@ func (p proxyAuthenticator) handle(next http.Handler) http.Handler {
 
 			logger.Info().Str(common.LogKeyAuthResult, common.LogAuthResultSuccess).Str("proxy_ip", proxyip).
 				Msgf("ProxyAuthenticator: user authenticated user_name=%s", username)
+			p.auditSrv.Record(ctx, model.AuditLogin, username, "proxy")
 			common.TraceLazyPrintf(ctx, "ProxyAuthenticator: user authenticated")
 			next.ServeHTTP(w, r.WithContext(common.ContextWithUser(ctx, username)))
 
@@ -199,6 +325,8 @@ func (p proxyAuthenticator) handle(next http.Handler) http.Handler {
 				Str(common.LogKeyAuthResult, common.LogAuthResultFailed).Str("proxy_ip", proxyip).
 				Str(common.LogKeyAuthFailReason, err.Error()).
 				Msgf("ProxyAuthenticator: user authentication failed user_name=%s error=%q", username, err)
+			p.auditSrv.Record(ctx, model.AuditLoginFailed, username,
+				"proxy: "+aerr.GetUserMessageOr(err, "authorization failed"))
 
 			common.TraceLazyPrintf(ctx, "ProxyAuthenticator: auth failed")
 			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
@@ -215,6 +343,59 @@ func (p proxyAuthenticator) handle(next http.Handler) http.Handler {
 
 //-------------------------------------------------------------
 
+// AppTokenScope reject modifying requests when session is authenticated by read-only app token.
+// Login and logout are always allowed.
+func AppTokenScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _, readonly := srvsupport.SessionAppToken(session.GetSession(r))
+		if token == "" || !readonly || r.Method == http.MethodGet || r.Method == http.MethodHead ||
+			strings.HasSuffix(r.URL.Path, "/login.json") || strings.HasSuffix(r.URL.Path, "/logout.json") {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		hlog.FromRequest(r).Info().Str("app_token", token).
+			Msgf("AppTokenScope: request method=%s forbidden for read-only token", r.Method)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+}
+
+// PasswordAuthOnly reject requests when session is authenticated by app token. App tokens are
+// accepted only by api.
+func PasswordAuthOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, _, _ := srvsupport.SessionAppToken(session.GetSession(r)); token != "" {
+			hlog.FromRequest(r).Info().Str("app_token", token).
+				Msg("PasswordAuthOnly: access by app token forbidden")
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newTOTPVerifiedMiddleware create middleware that redirect to login form sessions authenticated
+// only by password (basic auth) of users with enabled two-factor authentication.
+func newTOTPVerifiedMiddleware(webroot string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if srvsupport.SessionTOTPRequired(session.GetSession(r)) {
+				hlog.FromRequest(r).Info().Msg("TOTPVerified: two-factor authentication required")
+				http.Redirect(w, r, webroot+"/web/login", http.StatusFound)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+//-------------------------------------------------------------
+
 type logResponseWriter struct {
 	http.ResponseWriter // compose original http.ResponseWriter
 
@@ -492,7 +673,63 @@ func newSessionMiddleware(i do.Injector) (sessionMiddleware, error) {
 		return nil, aerr.Wrapf(err, "start session manager failed")
 	}
 
-	return sess, nil
+	csrf := newCSRFMiddleware(cfg.MainServer.WebRoot)
+
+	return func(next http.Handler) http.Handler {
+		return sess(csrf(next))
+	}, nil
+}
+
+// newCSRFMiddleware create middleware that issue per-session csrf token for web gui and verify it
+// on every state-changing web request. Token is put into request context for templates.
+// Api clients can't send csrf token, so state-changing api requests authenticated by session cookie
+// (i.e. after login by /api/2/auth) are rejected when are made cross-origin by browser. Requests
+// with basic auth don't depend on session.
+func newCSRFMiddleware(webroot string) func(http.Handler) http.Handler {
+	crossOrigin := http.NewCrossOriginProtection()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isWebRequest(r, webroot) {
+				_, _, basicAuth := r.BasicAuth()
+				sessionAuth := !basicAuth && srvsupport.SessionUser(session.GetSession(r)) != ""
+
+				if err := crossOrigin.Check(r); sessionAuth && err != nil {
+					hlog.FromRequest(r).Warn().
+						Msgf("CSRFMiddleware: cross-origin api request rejected method=%s url=%q error=%q",
+							r.Method, r.URL.Redacted(), err)
+					http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+					return
+				}
+
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			token := srvsupport.SessionCSRFToken(session.GetSession(r))
+
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				reqtoken := r.Header.Get("X-CSRF-Token")
+				if reqtoken == "" {
+					reqtoken = r.PostFormValue("csrf_token")
+				}
+
+				if subtle.ConstantTimeCompare([]byte(reqtoken), []byte(token)) != 1 {
+					hlog.FromRequest(r).Warn().
+						Msgf("CSRFMiddleware: invalid csrf token method=%s url=%q", r.Method, r.URL.Redacted())
+					http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(common.ContextWithCSRFToken(r.Context(), token)))
+		})
+	}
 }
 
 //-------------------------------------------------------------
@@ -600,6 +837,34 @@ func newRealIPMiddleware(cfg *config.ServerConf) func(http.Handler) http.Handler
 	}
 }
 
+// newClientInfoMiddleware put client ip address and user agent into request context; they are
+// recorded in audit log.
+func newClientInfoMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := common.ContextWithClientInfo(r.Context(), remoteIP(r), r.UserAgent())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// remoteIP return client ip address (without port) from request.
+func remoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+
+	return r.RemoteAddr
+}
+
+// writeLoginLocked write "too many requests" response with time to wait for next login.
+func writeLoginLocked(w http.ResponseWriter, r *http.Request, wait time.Duration) {
+	setRetryAfter(w, wait)
+	srvsupport.WriteError(w, r, http.StatusTooManyRequests, "too many failed logins; try again later")
+}
+
+func setRetryAfter(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(max(wait.Round(time.Second), time.Second).Seconds())))
+}
+
 func findRealIP(r *http.Request) string {
 	for _, header := range realIPHeaders {
 		v := r.Header.Get(header)
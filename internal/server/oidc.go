@@ -0,0 +1,292 @@
+package server
+
+//
+// oidc.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"sync"
+
+	"gitea.com/go-chi/session"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	"golang.org/x/oauth2"
+)
+
+const authMethodOIDC = "oidc"
+
+var (
+	errOIDCInvalidState = aerr.New("invalid oidc state").WithUserMsg("invalid or expired login request").
+				WithTag(common.AuthenticationError)
+	errOIDCInvalidToken = aerr.New("invalid oidc id token").WithUserMsg("authorization failed").
+				WithTag(common.AuthenticationError)
+	errOIDCMissingUsername = aerr.New("missing username claim in id token").
+				WithUserMsg("user name not provided by identity provider").WithTag(common.AuthenticationError)
+)
+
+// publicRoutesProvider is implemented by authenticators that serve own endpoints (i.e. login callbacks)
+// that must be accessible without authentication.
+type publicRoutesProvider interface {
+	publicRoutes(router chi.Router)
+}
+
+// oidcAuthenticator authenticate web gui users by OpenID Connect provider (authorization code flow
+// with PKCE). Api is still authenticated by basic auth (password or app token).
+type oidcAuthenticator struct {
+	basic    basicAuthenticator
+	usersSrv *service.UsersSrv
+	cfg      *config.OIDCConf
+	webroot  string
+
+	// provider is discovered on first use.
+	provider   *oidc.Provider
+	providerMu sync.Mutex
+}
+
+func (a *oidcAuthenticator) handle(next http.Handler) http.Handler {
+	basic := a.basic.handle(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isWebRequest(r, a.webroot) {
+			basic.ServeHTTP(w, r)
+
+			return
+		}
+
+		sess := session.GetSession(r)
+		sessionuser := srvsupport.SessionUser(sess)
+
+		if sessionuser != "" && srvsupport.SessionAuthMethod(sess) == authMethodOIDC {
+			next.ServeHTTP(w, r.WithContext(common.ContextWithUser(r.Context(), sessionuser)))
+
+			return
+		}
+
+		hlog.FromRequest(r).Debug().Str("sid", sess.ID()).Msg("OIDCAuthenticator: redirect to login")
+		http.Redirect(w, r, a.webroot+"/oidc/login", http.StatusFound)
+	})
+}
+
+func (a *oidcAuthenticator) publicRoutes(router chi.Router) {
+	router.Get(a.webroot+"/oidc/login", srvsupport.WrapNamed(a.login, "oidc_login"))
+	router.Get(a.webroot+"/oidc/callback", srvsupport.WrapNamed(a.callback, "oidc_callback"))
+}
+
+// login start authentication by redirecting user to provider.
+func (a *oidcAuthenticator) login(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger,
+) {
+	provider, err := a.getProvider(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msgf("OIDCAuthenticator: provider discovery error=%q", err)
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+
+		return
+	}
+
+	state, nonce, verifier := rand.Text(), rand.Text(), oauth2.GenerateVerifier()
+
+	sess := session.GetSession(r)
+	_ = sess.Set("oidc_state", state)
+	_ = sess.Set("oidc_nonce", nonce)
+	_ = sess.Set("oidc_verifier", verifier)
+
+	url := a.oauthConfig(provider).AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce))
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// callback finish authentication; exchange code for tokens, verify id token and login user.
+func (a *oidcAuthenticator) callback(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger,
+) {
+	sess := session.GetSession(r)
+
+	identity, err := a.authenticate(ctx, sess, r)
+	if err == nil {
+		_, err = a.getOrCreateUser(ctx, identity)
+	}
+
+	username := identity.UserName
+
+	switch {
+	case err == nil:
+	case aerr.HasTag(err, common.AuthenticationError) || aerr.HasTag(err, aerr.ValidationError):
+		logger.Info().Str(common.LogKeyUserName, username).
+			Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+			Str(common.LogKeyAuthFailReason, err.Error()).
+			Msgf("OIDCAuthenticator: user authentication failed user_name=%s error=%q", username, err)
+		a.basic.auditSrv.Record(ctx, model.AuditLoginFailed, username,
+			"oidc: "+aerr.GetUserMessageOr(err, "authorization failed"))
+		http.Error(w, aerr.GetUserMessage(err), http.StatusUnauthorized)
+
+		return
+	default:
+		logger.Error().Err(err).Msgf("OIDCAuthenticator: internal error user_name=%s error=%q", username, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	// new session id after login
+	sess, err = session.RegenerateSession(w, r)
+	if err != nil {
+		logger.Error().Err(err).Msgf("OIDCAuthenticator: regenerate session error=%q", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	_ = sess.Set("user", username)
+	srvsupport.SetSessionAuthMethod(sess, authMethodOIDC)
+	srvsupport.SetSessionAppToken(sess, nil)
+	srvsupport.SetSessionTOTPRequired(sess, false)
+
+	logger.Info().Str(common.LogKeyUserName, username).Str(common.LogKeyAuthResult, common.LogAuthResultSuccess).
+		Msgf("OIDCAuthenticator: user authenticated user_name=%s", username)
+	a.basic.auditSrv.Record(ctx, model.AuditLogin, username, "oidc")
+
+	http.Redirect(w, r, a.webroot+"/web", http.StatusFound)
+}
+
+// oidcIdentity is user data loaded from id token.
+type oidcIdentity struct {
+	UserName string
+	Email    string
+	Name     string
+}
+
+// authenticate verify callback request and return user identity from id token.
+func (a *oidcAuthenticator) authenticate(ctx context.Context, sess session.Store, r *http.Request,
+) (oidcIdentity, error) {
+	identity := oidcIdentity{}
+
+	state, _ := sess.Get("oidc_state").(string)
+	nonce, _ := sess.Get("oidc_nonce").(string)
+	verifier, _ := sess.Get("oidc_verifier").(string)
+
+	// state is valid only once
+	_ = sess.Delete("oidc_state")
+	_ = sess.Delete("oidc_nonce")
+	_ = sess.Delete("oidc_verifier")
+
+	query := r.URL.Query()
+	if state == "" || query.Get("state") != state {
+		return identity, errOIDCInvalidState
+	}
+
+	if e := query.Get("error"); e != "" {
+		return identity, aerr.New("provider error: %s", e).WithUserMsg("authorization failed").
+			WithTag(common.AuthenticationError).WithMeta("description", query.Get("error_description"))
+	}
+
+	provider, err := a.getProvider(ctx)
+	if err != nil {
+		return identity, err
+	}
+
+	token, err := a.oauthConfig(provider).Exchange(ctx, query.Get("code"), oauth2.VerifierOption(verifier))
+	if err != nil {
+		return identity, aerr.Wrapf(err, "exchange code failed").WithUserMsg("authorization failed").
+			WithTag(common.AuthenticationError)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return identity, errOIDCInvalidToken.WithMsg("missing id token")
+	}
+
+	idtoken, err := provider.Verifier(&oidc.Config{ClientID: a.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return identity, errOIDCInvalidToken.WithError(err)
+	}
+
+	if idtoken.Nonce != nonce {
+		return identity, errOIDCInvalidToken.WithMsg("invalid nonce")
+	}
+
+	var claims map[string]any
+	if err := idtoken.Claims(&claims); err != nil {
+		return identity, errOIDCInvalidToken.WithError(err)
+	}
+
+	identity.UserName, _ = claims[a.cfg.UsernameClaim].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+
+	if identity.UserName == "" {
+		return identity, errOIDCMissingUsername.WithMeta("claim", a.cfg.UsernameClaim)
+	}
+
+	return identity, nil
+}
+
+// getOrCreateUser check is user exists and is active. Create user when not exists and auto provisioning
+// is enabled. User is created with random password and may use app tokens to access api.
+func (a *oidcAuthenticator) getOrCreateUser(ctx context.Context, identity oidcIdentity) (*model.User, error) {
+	username := identity.UserName
+
+	user, err := a.usersSrv.CheckUser(ctx, username)
+	if !errors.Is(err, common.ErrUserNotFound) || !a.cfg.AutoProvision {
+		return user, err //nolint:wrapcheck
+	}
+
+	cmd := command.NewUserCmd{
+		UserName: username,
+		Password: rand.Text(),
+		Email:    identity.Email,
+		Name:     identity.Name,
+	}
+
+	if _, err := a.usersSrv.AddUser(ctx, &cmd); err != nil {
+		return nil, aerr.Wrapf(err, "create user failed")
+	}
+
+	zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, username).
+		Msgf("OIDCAuthenticator: user created user_name=%s", username)
+
+	return a.usersSrv.CheckUser(ctx, username) //nolint:wrapcheck
+}
+
+func (a *oidcAuthenticator) getProvider(ctx context.Context) (*oidc.Provider, error) {
+	a.providerMu.Lock()
+	defer a.providerMu.Unlock()
+
+	if a.provider != nil {
+		return a.provider, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, a.cfg.IssuerURL)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "oidc provider discovery failed").WithMeta("issuer", a.cfg.IssuerURL)
+	}
+
+	a.provider = provider
+
+	return provider, nil
+}
+
+func (a *oidcAuthenticator) oauthConfig(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     a.cfg.ClientID,
+		ClientSecret: a.cfg.ClientSecret,
+		RedirectURL:  a.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       a.cfg.ScopesList(),
+	}
+}
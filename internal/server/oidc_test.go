@@ -0,0 +1,368 @@
+package server
+
+//
+// oidc_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"gitea.com/go-chi/session"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func TestOIDCLoginExistingUser(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	provider := newMockOIDCProvider(t, "client1")
+	provider.claims = map[string]any{"preferred_username": "user1"}
+
+	srv := newOIDCTestServer(t, i, provider, false)
+
+	status, body := doGet(t, newTestClient(t), srv.URL+"/web")
+	if status != http.StatusOK {
+		t.Fatalf("invalid status: %d, body: %q", status, body)
+	}
+
+	if body != "user1" {
+		t.Errorf("invalid session user: %q", body)
+	}
+}
+
+func TestOIDCLoginUnknownUser(t *testing.T) {
+	_, i := prepareTests(t)
+
+	provider := newMockOIDCProvider(t, "client1")
+	provider.claims = map[string]any{"preferred_username": "user2"}
+
+	srv := newOIDCTestServer(t, i, provider, false)
+
+	if status, body := doGet(t, newTestClient(t), srv.URL+"/web"); status != http.StatusUnauthorized {
+		t.Fatalf("invalid status: %d, body: %q", status, body)
+	}
+}
+
+func TestOIDCLoginAutoProvision(t *testing.T) {
+	ctx, i := prepareTests(t)
+
+	provider := newMockOIDCProvider(t, "client1")
+	provider.claims = map[string]any{
+		"preferred_username": "user3",
+		"email":              "user3@example.com",
+		"name":               "User 3",
+	}
+
+	srv := newOIDCTestServer(t, i, provider, true)
+
+	status, body := doGet(t, newTestClient(t), srv.URL+"/web")
+	if status != http.StatusOK || body != "user3" {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	user, err := do.MustInvoke[*service.UsersSrv](i).CheckUser(ctx, "user3")
+	if err != nil {
+		t.Fatalf("check created user error: %#+v", err)
+	}
+
+	if user.Email != "user3@example.com" || user.Name != "User 3" {
+		t.Errorf("invalid created user: %+v", user)
+	}
+}
+
+func TestOIDCLoginInvalidState(t *testing.T) {
+	_, i := prepareTests(t)
+	provider := newMockOIDCProvider(t, "client1")
+	srv := newOIDCTestServer(t, i, provider, false)
+
+	status, _ := doGet(t, newTestClient(t), srv.URL+"/oidc/callback?state=abc&code=123")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("invalid status: %d", status)
+	}
+}
+
+func TestOIDCApiBasicAuth(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	provider := newMockOIDCProvider(t, "client1")
+	srv := newOIDCTestServer(t, i, provider, false)
+	client := newTestClient(t)
+
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/api/", nil)
+	req.SetBasicAuth("user1", "user1123")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("invalid status: %d", resp.StatusCode)
+	}
+
+	// session authenticated by basic auth can't be used to access web
+	if _, err := client.Get(srv.URL + "/web"); err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if provider.authRequests != 1 {
+		t.Errorf("expected redirect to oidc provider")
+	}
+}
+
+//-------------------------------------------------------------
+
+// newOIDCTestServer create server with oidc authenticator; /web and /api/ endpoints return
+// session user name.
+func newOIDCTestServer(t *testing.T, i do.Injector, provider *mockOIDCProvider, autoprovision bool,
+) *httptest.Server {
+	t.Helper()
+
+	var handler http.Handler
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	auth := &oidcAuthenticator{
+		basic: basicAuthenticator{
+			passwordAuth: do.MustInvoke[*service.UsersSrv](i),
+			tokensSrv:    do.MustInvoke[*service.AppTokensSrv](i),
+			guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+			auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+		},
+		usersSrv: do.MustInvoke[*service.UsersSrv](i),
+		cfg: &config.OIDCConf{
+			IssuerURL:     provider.srv.URL,
+			ClientID:      provider.clientID,
+			RedirectURL:   srv.URL + "/oidc/callback",
+			UsernameClaim: "preferred_username",
+			AutoProvision: autoprovision,
+		},
+	}
+
+	sess, err := session.Sessioner(session.Options{Provider: "memory"})
+	if err != nil {
+		t.Fatalf("start session manager failed: %#+v", err)
+	}
+
+	userHandler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(srvsupport.SessionUser(session.GetSession(r))))
+	}
+
+	router := chi.NewRouter()
+	router.Use(hlog.NewHandler(log.Logger))
+	router.Use(sess)
+	router.Group(auth.publicRoutes)
+	router.Group(func(group chi.Router) {
+		group.Use(auth.handle)
+		group.Use(AuthenticatedOnly)
+		group.Get("/web", userHandler)
+		group.Get("/api/", userHandler)
+	})
+
+	handler = router
+
+	return srv
+}
+
+func newTestClient(t *testing.T) *http.Client {
+	t.Helper()
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("create cookie jar failed: %#+v", err)
+	}
+
+	return &http.Client{Jar: jar}
+}
+
+func doGet(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+
+	resp, err := client.Get(url) //nolint:noctx
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response failed: %#+v", err)
+	}
+
+	return resp.StatusCode, string(body)
+}
+
+//-------------------------------------------------------------
+
+// mockOIDCProvider is minimal OpenID Connect provider that authenticate every request as user
+// defined by `claims`.
+type mockOIDCProvider struct {
+	srv      *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	claims   map[string]any
+
+	mu           sync.Mutex
+	authRequests int
+	// codes map issued code to pkce challenge and nonce
+	codes map[string][2]string
+}
+
+func newMockOIDCProvider(t *testing.T, clientID string) *mockOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key failed: %#+v", err)
+	}
+
+	provider := &mockOIDCProvider{
+		key:      key,
+		clientID: clientID,
+		codes:    make(map[string][2]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", provider.discovery)
+	mux.HandleFunc("GET /jwks", provider.jwks)
+	mux.HandleFunc("GET /authorize", provider.authorize)
+	mux.HandleFunc("POST /token", provider.token)
+
+	provider.srv = httptest.NewServer(mux)
+	t.Cleanup(provider.srv.Close)
+
+	return provider
+}
+
+func (p *mockOIDCProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{
+		"issuer":                                p.srv.URL,
+		"authorization_endpoint":                p.srv.URL + "/authorize",
+		"token_endpoint":                        p.srv.URL + "/token",
+		"jwks_uri":                              p.srv.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *mockOIDCProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "key1",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *mockOIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != p.clientID || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+
+		return
+	}
+
+	code := rand.Text()
+
+	p.mu.Lock()
+	p.authRequests++
+	p.codes[code] = [2]string{query.Get("code_challenge"), query.Get("nonce")}
+	p.mu.Unlock()
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	q := redirect.Query()
+	q.Set("code", code)
+	q.Set("state", query.Get("state"))
+	redirect.RawQuery = q.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *mockOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	p.mu.Lock()
+	data, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(challenge[:]) != data[0] {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+
+		return
+	}
+
+	claims := map[string]any{
+		"iss":   p.srv.URL,
+		"aud":   p.clientID,
+		"sub":   "subject",
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": data[1],
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+
+	writeJSON(w, map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     p.sign(claims),
+	})
+}
+
+func (p *mockOIDCProvider) sign(claims map[string]any) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "key1"})
+	payload, _ := json.Marshal(claims)
+	data := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(data))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, hash[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return data + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
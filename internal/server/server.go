@@ -24,6 +24,7 @@ import (
 	"gitlab.com/kabes/go-gpo/internal/aerr"
 	gpoapi "gitlab.com/kabes/go-gpo/internal/api"
 	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
 	gpoweb "gitlab.com/kabes/go-gpo/internal/web"
 )
 
@@ -123,6 +124,7 @@ func createRoutes(injector do.Injector, router chi.Router, cfg *config.ServerCon
 	web := do.MustInvoke[gpoweb.WEB](injector)
 	sessionMW := do.MustInvoke[sessionMiddleware](injector)
 	logMW := do.MustInvoke[logMiddleware](injector)
+	logout := newWebLogout(injector)
 	webroot := cfg.MainServer.WebRoot
 
 	router.Group(func(group chi.Router) {
@@ -131,6 +133,7 @@ func createRoutes(injector do.Injector, router chi.Router, cfg *config.ServerCon
 		}
 
 		group.Use(hlog.RequestIDHandler("req_id", "Request-Id"))
+		group.Use(newClientInfoMiddleware)
 
 		if cfg.DebugFlags.HasFlag(config.DebugFlightRecorder) {
 			group.Use(newFRMiddleware())
@@ -144,17 +147,37 @@ func createRoutes(injector do.Injector, router chi.Router, cfg *config.ServerCon
 		group.Use(newRecoverMiddleware)
 		group.Use(middleware.CleanPath)
 		group.Use(sessionMW)
-		group.Use(authMW.handle)
-		group.Use(AuthenticatedOnly)
-		group.
-			With(newPromMiddleware("api", nil)).
-			With(middleware.NoCache).
-			Mount(webroot+"/", api.Routes())
-		group.
-			With(newPromMiddleware("web", nil)).
-			Mount(webroot+"/web", web.Routes())
-		group.Get(webroot+"/", func(w http.ResponseWriter, r *http.Request) {
-			http.Redirect(w, r, webroot+"/web", http.StatusMovedPermanently)
+
+		if prp, ok := authMW.(publicRoutesProvider); ok {
+			group.With(middleware.NoCache).Group(prp.publicRoutes)
+		}
+
+		group.With(middleware.NoCache).Post(webroot+"/web/logout", srvsupport.WrapNamed(logout.logout, "web_logout"))
+
+		if cfg.RegistrationEnabled() {
+			group.With(middleware.NoCache).Group(newWebRegister(injector).routes)
+		}
+
+		if cfg.PasswordResetEnabled() {
+			group.With(middleware.NoCache).Group(newWebPasswordReset(injector).routes)
+		}
+
+		group.Group(func(group chi.Router) {
+			group.Use(authMW.handle)
+			group.Use(AuthenticatedOnly)
+			group.
+				With(newPromMiddleware("api", nil)).
+				With(middleware.NoCache).
+				With(AppTokenScope).
+				Mount(webroot+"/", api.Routes())
+			group.
+				With(newPromMiddleware("web", nil)).
+				With(PasswordAuthOnly).
+				With(newTOTPVerifiedMiddleware(webroot)).
+				Mount(webroot+"/web", web.Routes())
+			group.Get(webroot+"/", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, webroot+"/web", http.StatusMovedPermanently)
+			})
 		})
 	})
 }
@@ -9,6 +9,7 @@ package srvsupport
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"net/http"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"github.com/rs/zerolog/hlog"
 	"gitlab.com/kabes/go-gpo/internal/aerr"
 	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
 )
 
 func SessionUser(store session.Store) string {
@@ -30,6 +32,73 @@ func SessionUser(store session.Store) string {
 	return ""
 }
 
+// SessionAuthMethod return name of method used to authenticate session user when it was other than
+// default (basic or proxy) one.
+func SessionAuthMethod(store session.Store) string {
+	method, _ := store.Get("auth_method").(string)
+
+	return method
+}
+
+// SetSessionAuthMethod save in session method used to authenticate user.
+func SetSessionAuthMethod(store session.Store, method string) {
+	_ = store.Set("auth_method", method)
+}
+
+// SessionTOTPRequired return true when user was authenticated only by password and two-factor
+// authentication is required to access web gui.
+func SessionTOTPRequired(store session.Store) bool {
+	required, _ := store.Get("totp_required").(bool)
+
+	return required
+}
+
+// SetSessionTOTPRequired mark session as requiring two-factor authentication for web gui.
+func SetSessionTOTPRequired(store session.Store, required bool) {
+	if required {
+		_ = store.Set("totp_required", true)
+	} else {
+		_ = store.Delete("totp_required")
+	}
+}
+
+// SessionCSRFToken return csrf token for session; new token is generated when not exists.
+func SessionCSRFToken(store session.Store) string {
+	if token, ok := store.Get("csrf_token").(string); ok && token != "" {
+		return token
+	}
+
+	token := rand.Text()
+	_ = store.Set("csrf_token", token)
+
+	return token
+}
+
+// SessionAppToken return name of app token used to authenticate session user and token restrictions.
+// Name is empty when user was authenticated other way.
+func SessionAppToken(store session.Store) (string, string, bool) {
+	name, _ := store.Get("app_token").(string)
+	devicename, _ := store.Get("app_token_device").(string)
+	readonly, _ := store.Get("app_token_ro").(bool)
+
+	return name, devicename, readonly
+}
+
+// SetSessionAppToken save in session app token used to authenticate user; nil `token` clear it.
+func SetSessionAppToken(store session.Store, token *model.AppToken) {
+	if token == nil {
+		_ = store.Delete("app_token")
+		_ = store.Delete("app_token_device")
+		_ = store.Delete("app_token_ro")
+
+		return
+	}
+
+	_ = store.Set("app_token", token.Name)
+	_ = store.Set("app_token_device", token.DeviceName)
+	_ = store.Set("app_token_ro", token.ReadOnly)
+}
+
 // Wrap add context and logger to handler.
 func Wrap(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request,
 	logger *zerolog.Logger),
@@ -82,7 +151,7 @@ func CheckAndWriteError(w http.ResponseWriter, r *http.Request, err error) {
 	msg := aerr.GetUserMessage(err)
 
 	switch {
-	case errors.Is(err, common.ErrUnknownDevice):
+	case errors.Is(err, common.ErrUnknownDevice), errors.Is(err, common.ErrUnknownPodcastList):
 		WriteError(w, r, http.StatusNotFound, msg)
 
 	case aerr.HasTag(err, aerr.InternalError):
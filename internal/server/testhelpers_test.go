@@ -0,0 +1,62 @@
+package server
+
+//
+// testhelpers_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/infra"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+	"gitlab.com/kabes/go-gpo/internal/service"
+)
+
+func prepareTests(t *testing.T) (context.Context, *do.RootScope) {
+	t.Helper()
+
+	ctx := log.Logger.WithContext(context.Background())
+	i := do.New(service.Package, db.Package, infra.Package)
+	do.ProvideValue(i, config.NewDBConfig("sqlite3", ":memory:"))
+
+	rdb := do.MustInvoke[repository.Database](i)
+	if _, err := rdb.Open(ctx); err != nil {
+		t.Fatalf("connect to db error: %#+v", err)
+	}
+
+	if err := rdb.Migrate(ctx); err != nil {
+		t.Fatalf("prepare db error: %#+v", err)
+	}
+
+	if err := rdb.Clear(ctx); err != nil {
+		t.Fatalf("clear db error: %#+v", err)
+	}
+
+	return ctx, i
+}
+
+func prepareTestUser(ctx context.Context, t *testing.T, i do.Injector, name string) {
+	t.Helper()
+
+	usersSrv := do.MustInvoke[*service.UsersSrv](i)
+	newuser := command.NewUserCmd{
+		UserName: name,
+		Password: name + "123",
+		Email:    name + "@example.com",
+		Name:     "test user " + name,
+	}
+
+	if _, err := usersSrv.AddUser(ctx, &newuser); err != nil {
+		t.Fatalf("create test user failed: %#+v", err)
+	}
+}
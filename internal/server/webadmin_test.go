@@ -0,0 +1,181 @@
+package server
+
+//
+// webadmin_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	gpoweb "gitlab.com/kabes/go-gpo/internal/web"
+)
+
+func TestWebAdminUsers(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+	prepareTestUser(ctx, t, i, "admin")
+
+	usersSrv := do.MustInvoke[*service.UsersSrv](i)
+	if err := usersSrv.SetAdmin(ctx, &command.SetUserAdminCmd{UserName: "admin", Admin: true}); err != nil {
+		t.Fatalf("set admin error: %#+v", err)
+	}
+
+	srv := newWebAdminTestServer(t, i)
+	client := newTestClient(t)
+	setTestUserCookie(t, client, srv.URL, "user1")
+
+	// non-admin user can't access admin pages
+	for _, path := range []string{"/web/admin/users", "/web/admin/registrations"} {
+		if status, _ := doGet(t, client, srv.URL+path); status != http.StatusForbidden {
+			t.Errorf("%s: invalid status for non-admin: %d", path, status)
+		}
+	}
+
+	status, _ := doPostForm(t, client, srv.URL+"/web/admin/users/delete", url.Values{"username": {"admin"}})
+	if status != http.StatusForbidden {
+		t.Errorf("invalid status for non-admin post: %d", status)
+	}
+
+	setTestUserCookie(t, client, srv.URL, "admin")
+
+	status, body := doGet(t, client, srv.URL+"/web/admin/users")
+	if status != http.StatusOK || !strings.Contains(body, "user1") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/admin/users", url.Values{
+		"username": {"user2"}, "password": {"user2123"}, "email": {"user2@example.com"},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("create user - invalid status: %d", status)
+	}
+
+	if _, err := usersSrv.LoginUser(ctx, "user2", "user2123"); err != nil {
+		t.Fatalf("login new user error: %#+v", err)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/admin/users/lock", url.Values{"username": {"user2"}})
+	if status != http.StatusOK {
+		t.Fatalf("lock user - invalid status: %d", status)
+	}
+
+	if _, err := usersSrv.LoginUser(ctx, "user2", "user2123"); err == nil {
+		t.Fatalf("locked user should not login")
+	}
+
+	// unlock restore previous password and clear failed logins
+	guardSrv := do.MustInvoke[*service.LoginGuardSrv](i)
+	for range 5 {
+		if err := guardSrv.LoginFailed(ctx, "user2", "192.168.1.10"); err != nil {
+			t.Fatalf("login failed error: %#+v", err)
+		}
+	}
+
+	if wait, err := guardSrv.CheckLogin(ctx, "user2", ""); err != nil || wait == 0 {
+		t.Fatalf("login should be locked: %v, %#+v", wait, err)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/admin/users/unlock", url.Values{"username": {"user2"}})
+	if status != http.StatusOK {
+		t.Fatalf("unlock user - invalid status: %d", status)
+	}
+
+	if _, err := usersSrv.LoginUser(ctx, "user2", "user2123"); err != nil {
+		t.Fatalf("login unlocked user error: %#+v", err)
+	}
+
+	if wait, err := guardSrv.CheckLogin(ctx, "user2", ""); err != nil || wait != 0 {
+		t.Fatalf("login should not be locked: %v, %#+v", wait, err)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/admin/users/unlock", url.Values{"username": {"user2"}})
+	if status != http.StatusOK || !strings.Contains(body, "account is not locked") {
+		t.Fatalf("unlock active user - invalid response: %d, body: %q", status, body)
+	}
+
+	// new password also unlock account
+	status, _ = doPostForm(t, client, srv.URL+"/web/admin/users/lock", url.Values{"username": {"user2"}})
+	if status != http.StatusOK {
+		t.Fatalf("lock user - invalid status: %d", status)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/admin/users/password",
+		url.Values{"username": {"user2"}, "password": {"newpass"}})
+	if status != http.StatusOK {
+		t.Fatalf("unlock user - invalid status: %d", status)
+	}
+
+	if _, err := usersSrv.LoginUser(ctx, "user2", "newpass"); err != nil {
+		t.Fatalf("login unlocked user error: %#+v", err)
+	}
+
+	// admin can't delete own account
+	status, body = doPostForm(t, client, srv.URL+"/web/admin/users/delete", url.Values{"username": {"admin"}})
+	if status != http.StatusOK || !strings.Contains(body, "not allowed for own account") {
+		t.Fatalf("delete own account - invalid response: %d, body: %q", status, body)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/admin/users/delete", url.Values{"username": {"user2"}})
+	if status != http.StatusOK {
+		t.Fatalf("delete user - invalid status: %d", status)
+	}
+
+	if _, err := usersSrv.CheckUser(ctx, "user2"); err == nil {
+		t.Fatalf("user should be deleted")
+	}
+}
+
+//-------------------------------------------------------------
+
+// newWebAdminTestServer create server with web gui; user is taken from `test_user` cookie.
+func newWebAdminTestServer(t *testing.T, i do.Injector) *httptest.Server {
+	t.Helper()
+
+	gpoweb.Package(i)
+	do.ProvideNamedValue(i, "server.webroot", "")
+
+	web := do.MustInvoke[gpoweb.WEB](i)
+
+	router := chi.NewRouter()
+	router.Use(hlog.NewHandler(log.Logger))
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c, err := r.Cookie("test_user"); err == nil {
+				r = r.WithContext(common.ContextWithUser(r.Context(), c.Value))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	})
+	router.Mount("/web", web.Routes())
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func setTestUserCookie(t *testing.T, client *http.Client, srvurl, username string) {
+	t.Helper()
+
+	u, err := url.Parse(srvurl)
+	if err != nil {
+		t.Fatalf("parse url error: %#+v", err)
+	}
+
+	client.Jar.SetCookies(u, []*http.Cookie{{Name: "test_user", Value: username, Path: "/"}})
+}
@@ -0,0 +1,435 @@
+package server
+
+//
+// weblogin.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"gitea.com/go-chi/session"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+// rememberCookieName is name of cookie that keep "remember me" token.
+const rememberCookieName = "remember"
+
+// isWebRequest check is request for web gui.
+func isWebRequest(r *http.Request, webroot string) bool {
+	path := r.URL.Path
+
+	return path == webroot+"/" || path == webroot+"/web" || strings.HasPrefix(path, webroot+"/web/")
+}
+
+//-------------------------------------------------------------
+
+// maxTOTPAttempts is number of invalid verification codes after which login must be started again.
+const maxTOTPAttempts = 5
+
+// webLogin serve login form for web gui and restore sessions from "remember me" cookie.
+// Users with enabled two-factor authentication must enter verification code after password.
+type webLogin struct {
+	passwordAuth passwordAuthenticator
+	rememberSrv  *service.RememberTokensSrv
+	totpSrv      *service.TOTPSrv
+	guardSrv     *service.LoginGuardSrv
+	auditSrv     *service.AuditSrv
+	renderer     *nt.Renderer
+	webroot      string
+	secureCookie bool
+	registration bool
+	passReset    bool
+}
+
+func newWebLogin(i do.Injector, passwordAuth passwordAuthenticator) *webLogin {
+	cfg := do.MustInvoke[*config.ServerConf](i)
+
+	return &webLogin{
+		passwordAuth: passwordAuth,
+		rememberSrv:  do.MustInvoke[*service.RememberTokensSrv](i),
+		totpSrv:      do.MustInvoke[*service.TOTPSrv](i),
+		guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+		auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+		renderer:     do.MustInvoke[*nt.Renderer](i),
+		webroot:      cfg.MainServer.WebRoot,
+		secureCookie: cfg.MainServer.UseSecureCookie(),
+		registration: cfg.RegistrationEnabled(),
+		passReset:    cfg.PasswordResetEnabled(),
+	}
+}
+
+func (l *webLogin) routes(router chi.Router) {
+	router.Get(l.webroot+"/web/login", srvsupport.WrapNamed(l.loginPage, "web_login"))
+	router.Post(l.webroot+"/web/login", srvsupport.WrapNamed(l.login, "web_login_post"))
+	router.Get(l.webroot+"/web/login/totp", srvsupport.WrapNamed(l.totpPage, "web_login_totp"))
+	router.Post(l.webroot+"/web/login/totp", srvsupport.WrapNamed(l.verifyTOTP, "web_login_totp_post"))
+}
+
+// handleAnonymous authenticate web request without session by "remember me" cookie or redirect user
+// to login form.
+func (l *webLogin) handleAnonymous(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	logger := hlog.FromRequest(r)
+	ctx := r.Context()
+
+	cookie, err := r.Cookie(rememberCookieName)
+	if err != nil || cookie.Value == "" {
+		logger.Debug().Msg("WebLogin: redirect to login")
+		http.Redirect(w, r, l.webroot+"/web/login", http.StatusFound)
+
+		return
+	}
+
+	user, err := l.rememberSrv.LoginWithRememberToken(ctx, cookie.Value)
+	if err != nil {
+		if aerr.HasTag(err, common.AuthenticationError) || aerr.HasTag(err, aerr.ValidationError) {
+			logger.Info().Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+				Str(common.LogKeyAuthFailReason, err.Error()).
+				Msgf("WebLogin: remember token authentication failed error=%q", err)
+		} else {
+			logger.Error().Err(err).Msgf("WebLogin: remember token authentication error=%q", err)
+		}
+
+		setRememberCookie(w, l.webroot, "", l.secureCookie)
+		http.Redirect(w, r, l.webroot+"/web/login", http.StatusFound)
+
+		return
+	}
+
+	sess, err := session.RegenerateSession(w, r)
+	if err != nil {
+		logger.Error().Err(err).Msgf("WebLogin: regenerate session error=%q", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	_ = sess.Set("user", user.UserName)
+	srvsupport.SetSessionAppToken(sess, nil)
+
+	logger.Info().Str(common.LogKeyUserName, user.UserName).
+		Str(common.LogKeyAuthResult, common.LogAuthResultSuccess).
+		Msgf("WebLogin: user authenticated by remember token user_name=%s", user.UserName)
+	l.auditSrv.Record(ctx, model.AuditLogin, user.UserName, "web: remember me")
+
+	next.ServeHTTP(w, r.WithContext(common.ContextWithUser(ctx, user.UserName)))
+}
+
+func (l *webLogin) loginPage(ctx context.Context, w http.ResponseWriter, r *http.Request, _ *zerolog.Logger) {
+	sess := session.GetSession(r)
+	if srvsupport.SessionUser(sess) != "" && !srvsupport.SessionTOTPRequired(sess) {
+		http.Redirect(w, r, l.webroot+"/web/", http.StatusFound)
+
+		return
+	}
+
+	l.renderer.WritePage(ctx, w, l.newLoginPage("", ""))
+}
+
+func (l *webLogin) login(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("WebLogin: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	username := strings.TrimSpace(r.PostFormValue("username"))
+	password := r.PostFormValue("password")
+	ip := remoteIP(r)
+
+	if wait, err := l.guardSrv.CheckLogin(ctx, username, ip); err != nil {
+		logger.Error().Err(err).Msgf("WebLogin: check login error user_name=%s error=%q", username, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	} else if wait > 0 {
+		logger.Info().Str(common.LogKeyUserName, username).
+			Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+			Str(common.LogKeyAuthFailReason, "locked").
+			Msgf("WebLogin: login locked user_name=%s remote=%s", username, ip)
+		l.auditSrv.Record(ctx, model.AuditLoginFailed, username, "web: too many failed logins")
+
+		setRetryAfter(w, wait)
+		w.WriteHeader(http.StatusTooManyRequests)
+		l.renderer.WritePage(ctx, w, l.newLoginPage("Error: too many failed logins; try again later", username))
+
+		return
+	}
+
+	user, err := l.passwordAuth.LoginUser(ctx, username, password)
+
+	switch {
+	case err == nil:
+		// login failures are cleared after user is fully authenticated
+	case aerr.HasTag(err, common.AuthenticationError) || aerr.HasTag(err, aerr.ValidationError):
+		logger.Info().Str(common.LogKeyUserName, username).
+			Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+			Str(common.LogKeyAuthFailReason, err.Error()).
+			Msgf("WebLogin: user authentication failed user_name=%s error=%q", username, err)
+		l.auditSrv.Record(ctx, model.AuditLoginFailed, username,
+			"web: "+aerr.GetUserMessageOr(err, "authorization failed"))
+
+		if err := l.guardSrv.LoginFailed(ctx, username, ip); err != nil {
+			logger.Error().Err(err).Msgf("WebLogin: register login failure error=%q", err)
+		}
+
+		w.WriteHeader(http.StatusUnauthorized)
+		l.renderer.WritePage(ctx, w, l.newLoginPage("Error: invalid user name or password", username))
+
+		return
+	default:
+		logger.Error().Err(err).Msgf("WebLogin: internal error user_name=%s error=%q", username, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	remember := r.PostFormValue("remember") != ""
+
+	if user.TOTPEnabled() {
+		l.startTOTPVerification(w, r, logger, username, remember)
+
+		return
+	}
+
+	l.completeLogin(ctx, w, r, logger, username, remember)
+}
+
+// startTOTPVerification keep user authenticated by password in session and redirect to verification
+// code form. User is not logged in until code is verified.
+func (l *webLogin) startTOTPVerification(w http.ResponseWriter, r *http.Request, logger *zerolog.Logger,
+	username string, remember bool,
+) {
+	sess, err := session.RegenerateSession(w, r)
+	if err != nil {
+		logger.Error().Err(err).Msgf("WebLogin: regenerate session error=%q", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	_ = sess.Delete("user")
+	_ = sess.Set("totp_user", username)
+	_ = sess.Set("totp_remember", remember)
+	_ = sess.Set("totp_attempts", 0)
+
+	logger.Info().Str(common.LogKeyUserName, username).
+		Msgf("WebLogin: password verified, two-factor authentication required user_name=%s", username)
+
+	http.Redirect(w, r, l.webroot+"/web/login/totp", http.StatusFound)
+}
+
+func (l *webLogin) totpPage(ctx context.Context, w http.ResponseWriter, r *http.Request, _ *zerolog.Logger) {
+	if username, _ := session.GetSession(r).Get("totp_user").(string); username == "" {
+		http.Redirect(w, r, l.webroot+"/web/login", http.StatusFound)
+
+		return
+	}
+
+	l.renderer.WritePage(ctx, w, &nt.LoginTOTPPage{})
+}
+
+func (l *webLogin) verifyTOTP(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("WebLogin: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	sess := session.GetSession(r)
+	username, _ := sess.Get("totp_user").(string)
+	remember, _ := sess.Get("totp_remember").(bool)
+	attempts, _ := sess.Get("totp_attempts").(int)
+
+	if username == "" {
+		http.Redirect(w, r, l.webroot+"/web/login", http.StatusFound)
+
+		return
+	}
+
+	ip := remoteIP(r)
+
+	if wait, err := l.guardSrv.CheckLogin(ctx, username, ip); err != nil {
+		logger.Error().Err(err).Msgf("WebLogin: check login error user_name=%s error=%q", username, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	} else if wait > 0 {
+		logger.Info().Str(common.LogKeyUserName, username).
+			Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+			Str(common.LogKeyAuthFailReason, "locked").
+			Msgf("WebLogin: two-factor authentication locked user_name=%s remote=%s", username, ip)
+		l.auditSrv.Record(ctx, model.AuditLoginFailed, username, "web: too many failed logins")
+
+		clearTOTPVerification(sess)
+		setRetryAfter(w, wait)
+		w.WriteHeader(http.StatusTooManyRequests)
+		l.renderer.WritePage(ctx, w, l.newLoginPage("Error: too many failed logins; try again later", username))
+
+		return
+	}
+
+	err := l.totpSrv.VerifyTOTP(ctx, username, r.PostFormValue("code"))
+
+	switch {
+	case err == nil:
+	case aerr.HasTag(err, common.AuthenticationError) || aerr.HasTag(err, aerr.ValidationError):
+		logger.Info().Str(common.LogKeyUserName, username).
+			Str(common.LogKeyAuthResult, common.LogAuthResultFailed).
+			Str(common.LogKeyAuthFailReason, err.Error()).
+			Msgf("WebLogin: two-factor authentication failed user_name=%s error=%q", username, err)
+		l.auditSrv.Record(ctx, model.AuditLoginFailed, username, "web: invalid verification code")
+
+		if err := l.guardSrv.LoginFailed(ctx, username, ip); err != nil {
+			logger.Error().Err(err).Msgf("WebLogin: register login failure error=%q", err)
+		}
+
+		if attempts++; attempts >= maxTOTPAttempts {
+			clearTOTPVerification(sess)
+			http.Redirect(w, r, l.webroot+"/web/login", http.StatusFound)
+
+			return
+		}
+
+		_ = sess.Set("totp_attempts", attempts)
+
+		w.WriteHeader(http.StatusUnauthorized)
+		l.renderer.WritePage(ctx, w, &nt.LoginTOTPPage{Msg: "Error: invalid verification code"})
+
+		return
+	default:
+		logger.Error().Err(err).Msgf("WebLogin: verify totp internal error user_name=%s error=%q", username, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	clearTOTPVerification(sess)
+	l.completeLogin(ctx, w, r, logger, username, remember)
+}
+
+// completeLogin mark session as authenticated and optionally create "remember me" token.
+func (l *webLogin) completeLogin(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger, username string, remember bool,
+) {
+	// new session id after login
+	sess, err := session.RegenerateSession(w, r)
+	if err != nil {
+		logger.Error().Err(err).Msgf("WebLogin: regenerate session error=%q", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	_ = sess.Set("user", username)
+	srvsupport.SetSessionAppToken(sess, nil)
+	srvsupport.SetSessionTOTPRequired(sess, false)
+
+	if err := l.guardSrv.LoginSucceeded(ctx, username); err != nil {
+		logger.Error().Err(err).Msgf("WebLogin: reset login failures error=%q", err)
+	}
+
+	logger.Info().Str(common.LogKeyUserName, username).Str(common.LogKeyAuthResult, common.LogAuthResultSuccess).
+		Msgf("WebLogin: user authenticated user_name=%s", username)
+	l.auditSrv.Record(ctx, model.AuditLogin, username, "web")
+
+	if remember {
+		secret, err := l.rememberSrv.CreateRememberToken(ctx, username)
+		if err != nil {
+			// user is logged in; only remember me will not work
+			logger.Error().Err(err).Msgf("WebLogin: create remember token user_name=%s error=%q", username, err)
+		} else {
+			setRememberCookie(w, l.webroot, secret, l.secureCookie)
+		}
+	}
+
+	http.Redirect(w, r, l.webroot+"/web/", http.StatusFound)
+}
+
+func (l *webLogin) newLoginPage(msg, username string) *nt.LoginPage {
+	return &nt.LoginPage{
+		Msg:                  msg,
+		UserName:             username,
+		RegisterEnabled:      l.registration,
+		PasswordResetEnabled: l.passReset,
+	}
+}
+
+func clearTOTPVerification(sess session.Store) {
+	_ = sess.Delete("totp_user")
+	_ = sess.Delete("totp_remember")
+	_ = sess.Delete("totp_attempts")
+}
+
+// setRememberCookie set "remember me" cookie; empty `secret` remove cookie.
+func setRememberCookie(w http.ResponseWriter, webroot, secret string, secure bool) {
+	maxAge := int(service.RememberTokenLifetime.Seconds())
+	if secret == "" {
+		maxAge = -1
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     rememberCookieName,
+		Value:    secret,
+		Path:     webroot + "/web",
+		MaxAge:   maxAge,
+		Secure:   secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+//-------------------------------------------------------------
+
+// webLogout destroy user session and "remember me" token. Used by all authenticators.
+type webLogout struct {
+	rememberSrv  *service.RememberTokensSrv
+	webroot      string
+	secureCookie bool
+}
+
+func newWebLogout(i do.Injector) webLogout {
+	cfg := do.MustInvoke[*config.ServerConf](i)
+
+	return webLogout{
+		rememberSrv:  do.MustInvoke[*service.RememberTokensSrv](i),
+		webroot:      cfg.MainServer.WebRoot,
+		secureCookie: cfg.MainServer.UseSecureCookie(),
+	}
+}
+
+func (l webLogout) logout(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	sess := session.GetSession(r)
+	username := srvsupport.SessionUser(sess)
+
+	if cookie, err := r.Cookie(rememberCookieName); err == nil {
+		if err := l.rememberSrv.DeleteRememberToken(ctx, cookie.Value); err != nil {
+			logger.Error().Err(err).Msgf("WebLogout: delete remember token user_name=%s error=%q", username, err)
+		}
+
+		setRememberCookie(w, l.webroot, "", l.secureCookie)
+	}
+
+	sess.Flush()
+	_ = sess.Destroy(w, r)
+
+	logger.Info().Str(common.LogKeyUserName, username).Msgf("WebLogout: user logged out user_name=%s", username)
+
+	http.Redirect(w, r, l.webroot+"/web/", http.StatusFound)
+}
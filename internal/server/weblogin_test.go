@@ -0,0 +1,495 @@
+package server
+
+//
+// weblogin_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.com/go-chi/session"
+	"github.com/go-chi/chi/v5"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+func TestWebLoginForm(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+	client := newTestClient(t)
+
+	// unauthenticated user is redirected to login form
+	status, body := doGet(t, client, srv.URL+"/web/")
+	if status != http.StatusOK || !strings.Contains(body, `name="password"`) {
+		t.Fatalf("expected login form: %d, body: %q", status, body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login", url.Values{"username": {"user1"}, "password": {"bad"}})
+	if status != http.StatusUnauthorized || !strings.Contains(body, "invalid user name or password") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusOK || body != "user1" {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	if hasRememberCookie(client, srv.URL) {
+		t.Errorf("unexpected remember cookie")
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/logout", nil)
+	if status != http.StatusOK || !strings.Contains(body, `name="password"`) {
+		t.Fatalf("expected login form after logout: %d, body: %q", status, body)
+	}
+}
+
+func TestWebLoginAudit(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+	client := newTestClient(t)
+
+	doPostForm(t, client, srv.URL+"/web/login", url.Values{"username": {"user1"}, "password": {"bad"}})
+	doPostForm(t, client, srv.URL+"/web/login", url.Values{"username": {"user1"}, "password": {"user1123"}})
+
+	events, err := do.MustInvoke[*service.AuditSrv](i).ListEvents(ctx, &query.GetAuditEventsQuery{UserName: "user1"})
+	if err != nil {
+		t.Fatalf("list audit events error: %#+v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("invalid number of events: %+v", events)
+	}
+
+	if events[0].Action != model.AuditLogin || events[1].Action != model.AuditLoginFailed {
+		t.Errorf("invalid events: %+v", events)
+	}
+
+	if events[0].IP != "127.0.0.1" || events[0].UserAgent == "" {
+		t.Errorf("missing client info: %+v", events[0])
+	}
+}
+
+func TestWebLoginRememberMe(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+	client := newTestClient(t)
+
+	status, body := doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}, "remember": {"1"}})
+	if status != http.StatusOK || body != "user1" {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	webURL, _ := url.Parse(srv.URL + "/web/")
+	remember := findCookie(client.Jar.Cookies(webURL), rememberCookieName)
+
+	if remember == nil {
+		t.Fatalf("missing remember cookie")
+	}
+
+	// new client without session, only with remember cookie
+	client2 := newTestClient(t)
+	client2.Jar.SetCookies(webURL, []*http.Cookie{remember})
+
+	if status, body := doGet(t, client2, srv.URL+"/web/"); status != http.StatusOK || body != "user1" {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	// logout revoke token
+	doPostForm(t, client2, srv.URL+"/web/logout", nil)
+
+	client3 := newTestClient(t)
+	client3.Jar.SetCookies(webURL, []*http.Cookie{remember})
+
+	if status, body := doGet(t, client3, srv.URL+"/web/"); !strings.Contains(body, `name="password"`) {
+		t.Fatalf("expected login form: %d, body: %q", status, body)
+	}
+}
+
+func TestWebLoginApiNotRedirected(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+
+	if status, _ := doGet(t, newTestClient(t), srv.URL+"/api/"); status != http.StatusUnauthorized {
+		t.Fatalf("invalid status: %d", status)
+	}
+
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/api/", nil)
+	req.SetBasicAuth("user1", "user1123")
+
+	resp, err := newTestClient(t).Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("invalid status: %d", resp.StatusCode)
+	}
+}
+
+func TestWebLoginTOTP(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+	secret, enableCode := enableTestTOTP(t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+	client := newTestClient(t)
+
+	status, body := doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusOK || !strings.Contains(body, `name="code"`) {
+		t.Fatalf("expected verification form: %d, body: %q", status, body)
+	}
+
+	// session is not authenticated before verification
+	if _, body := doGet(t, client, srv.URL+"/web/"); !strings.Contains(body, `name="password"`) {
+		t.Fatalf("expected login form: %q", body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusOK || !strings.Contains(body, `name="code"`) {
+		t.Fatalf("expected verification form: %d, body: %q", status, body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {"000000"}})
+	if status != http.StatusUnauthorized || !strings.Contains(body, "invalid verification code") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	// code used to enable totp is rejected
+	status, body = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {enableCode}})
+	if status != http.StatusUnauthorized || !strings.Contains(body, "invalid verification code") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	code, err := totp.GenerateCode(secret, time.Now().Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("generate code error: %#+v", err)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {code}})
+	if status != http.StatusOK || body != "user1" {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	// code can't be used again
+	status, _ = doPostForm(t, client, srv.URL+"/web/logout", url.Values{})
+	if status != http.StatusOK {
+		t.Fatalf("invalid logout status: %d", status)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusOK || !strings.Contains(body, `name="code"`) {
+		t.Fatalf("expected verification form: %d, body: %q", status, body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {code}})
+	if status != http.StatusUnauthorized || !strings.Contains(body, "invalid verification code") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+}
+
+func TestWebLoginTOTPBasicAuth(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+	enableTestTOTP(t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+
+	client := newTestClient(t)
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	// basic auth is not enough for web gui
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/web/", nil)
+	req.SetBasicAuth("user1", "user1123")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != "/web/login" {
+		t.Fatalf("invalid response: %d, location: %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+
+	// but api is still available
+	req, _ = http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/api/", nil)
+	req.SetBasicAuth("user1", "user1123")
+
+	resp, err = newTestClient(t).Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("invalid status: %d", resp.StatusCode)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+
+	basicAuthGet := func(password string) *http.Response {
+		req, _ := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/api/", nil)
+		req.SetBasicAuth("user1", password)
+
+		resp, err := newTestClient(t).Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %#+v", err)
+		}
+
+		resp.Body.Close()
+
+		return resp
+	}
+
+	for range 5 {
+		if resp := basicAuthGet("bad"); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("invalid status: %d", resp.StatusCode)
+		}
+	}
+
+	// valid password is rejected when login is locked
+	resp := basicAuthGet("user1123")
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("invalid response: %d, retry-after: %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	status, body := doPostForm(t, newTestClient(t), srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusTooManyRequests || !strings.Contains(body, "too many failed logins") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	// unlock
+	if err := do.MustInvoke[*service.LoginGuardSrv](i).ClearLoginFailures(ctx, "", ""); err != nil {
+		t.Fatalf("clear failures error: %#+v", err)
+	}
+
+	if resp := basicAuthGet("user1123"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("invalid status: %d", resp.StatusCode)
+	}
+}
+
+func TestLoginLockoutTOTP(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+	secret, _ := enableTestTOTP(t, i, "user1")
+
+	srv := newWebLoginTestServer(t, i)
+	client := newTestClient(t)
+
+	// valid password do not reset failures of second factor
+	for range 5 {
+		status, body := doPostForm(t, client, srv.URL+"/web/login",
+			url.Values{"username": {"user1"}, "password": {"user1123"}})
+		if status != http.StatusOK || !strings.Contains(body, `name="code"`) {
+			t.Fatalf("expected verification form: %d, body: %q", status, body)
+		}
+
+		status, body = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {"000000"}})
+		if status != http.StatusUnauthorized || !strings.Contains(body, "invalid verification code") {
+			t.Fatalf("invalid response: %d, body: %q", status, body)
+		}
+	}
+
+	// account is locked
+	status, body := doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusTooManyRequests || !strings.Contains(body, "too many failed logins") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	// unlock; valid code clear failures
+	if err := do.MustInvoke[*service.LoginGuardSrv](i).ClearLoginFailures(ctx, "", ""); err != nil {
+		t.Fatalf("clear failures error: %#+v", err)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/login",
+		url.Values{"username": {"user1"}, "password": {"user1123"}})
+	if status != http.StatusOK {
+		t.Fatalf("invalid status: %d", status)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {"000000"}})
+	if status != http.StatusUnauthorized {
+		t.Fatalf("invalid status: %d", status)
+	}
+
+	code, err := totp.GenerateCode(secret, time.Now().Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("generate code error: %#+v", err)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/login/totp", url.Values{"code": {code}})
+	if status != http.StatusOK || body != "user1" {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	failures, err := do.MustInvoke[*service.LoginGuardSrv](i).ListLoginFailures(ctx)
+	if err != nil {
+		t.Fatalf("list failures error: %#+v", err)
+	}
+
+	for _, f := range failures {
+		if f.Kind == model.LoginFailureUser {
+			t.Fatalf("unexpected user failures: %+v", f)
+		}
+	}
+}
+
+//-------------------------------------------------------------
+
+// newWebLoginTestServer create server with basic authenticator and login form; /web/ and /api/ endpoints
+// return session user name.
+func newWebLoginTestServer(t *testing.T, i do.Injector) *httptest.Server {
+	t.Helper()
+
+	do.ProvideNamedValue(i, "server.webroot", "")
+
+	renderer, err := nt.NewRenderer(i)
+	if err != nil {
+		t.Fatalf("create renderer failed: %#+v", err)
+	}
+
+	usersSrv := do.MustInvoke[*service.UsersSrv](i)
+	rememberSrv := do.MustInvoke[*service.RememberTokensSrv](i)
+	auth := basicAuthenticator{
+		passwordAuth: usersSrv,
+		tokensSrv:    do.MustInvoke[*service.AppTokensSrv](i),
+		guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+		auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+		web: &webLogin{
+			passwordAuth: usersSrv,
+			rememberSrv:  rememberSrv,
+			totpSrv:      do.MustInvoke[*service.TOTPSrv](i),
+			guardSrv:     do.MustInvoke[*service.LoginGuardSrv](i),
+			auditSrv:     do.MustInvoke[*service.AuditSrv](i),
+			renderer:     renderer,
+		},
+	}
+	logout := webLogout{rememberSrv: rememberSrv}
+
+	sess, err := session.Sessioner(session.Options{Provider: "memory"})
+	if err != nil {
+		t.Fatalf("start session manager failed: %#+v", err)
+	}
+
+	userHandler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(srvsupport.SessionUser(session.GetSession(r))))
+	}
+
+	router := chi.NewRouter()
+	router.Use(hlog.NewHandler(log.Logger))
+	router.Use(newClientInfoMiddleware)
+	router.Use(sess)
+	router.Group(auth.publicRoutes)
+	router.Post("/web/logout", srvsupport.WrapNamed(logout.logout, "web_logout"))
+	router.Group(func(group chi.Router) {
+		group.Use(auth.handle)
+		group.Use(AuthenticatedOnly)
+		group.With(newTOTPVerifiedMiddleware("")).Get("/web/", userHandler)
+		group.Get("/api/", userHandler)
+	})
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func doPostForm(t *testing.T, client *http.Client, url string, data url.Values) (int, string) {
+	t.Helper()
+
+	resp, err := client.PostForm(url, data) //nolint:noctx
+	if err != nil {
+		t.Fatalf("request failed: %#+v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response failed: %#+v", err)
+	}
+
+	return resp.StatusCode, string(body)
+}
+
+func hasRememberCookie(client *http.Client, srvurl string) bool {
+	u, _ := url.Parse(srvurl + "/web/")
+
+	return findCookie(client.Jar.Cookies(u), rememberCookieName) != nil
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// enableTestTOTP enable two-factor authentication for user and return secret.
+// enableTestTOTP enable totp for user; return secret and code used to enable it.
+func enableTestTOTP(t *testing.T, i do.Injector, username string) (string, string) {
+	t.Helper()
+
+	totpSrv := do.MustInvoke[*service.TOTPSrv](i)
+
+	key, err := totpSrv.GenerateTOTPKey(username)
+	if err != nil {
+		t.Fatalf("generate key error: %#+v", err)
+	}
+
+	code, err := totp.GenerateCode(key.Secret, time.Now())
+	if err != nil {
+		t.Fatalf("generate code error: %#+v", err)
+	}
+
+	cmd := command.EnableTOTPCmd{UserName: username, Secret: key.Secret, Code: code}
+	if _, err := totpSrv.EnableTOTP(t.Context(), &cmd); err != nil {
+		t.Fatalf("enable totp error: %#+v", err)
+	}
+
+	return key.Secret, code
+}
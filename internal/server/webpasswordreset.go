@@ -0,0 +1,191 @@
+package server
+
+//
+// webpasswordreset.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/mailer"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+// webPasswordReset serve public forms for resetting forgotten password. Link with reset token is
+// sent to user email.
+type webPasswordReset struct {
+	resetSrv *service.PasswordResetSrv
+	sender   mailer.Sender
+	renderer *nt.Renderer
+	webroot  string
+	// publicURL is external address of web root used in links.
+	publicURL string
+}
+
+func newWebPasswordReset(i do.Injector) webPasswordReset {
+	cfg := do.MustInvoke[*config.ServerConf](i)
+
+	return webPasswordReset{
+		resetSrv:  do.MustInvoke[*service.PasswordResetSrv](i),
+		sender:    mailer.NewSMTPSender(&cfg.SMTP),
+		renderer:  do.MustInvoke[*nt.Renderer](i),
+		webroot:   cfg.MainServer.WebRoot,
+		publicURL: cfg.PublicURL,
+	}
+}
+
+func (p webPasswordReset) routes(router chi.Router) {
+	router.Get(p.webroot+"/web/forgot-password", srvsupport.WrapNamed(p.forgotPage, "web_forgot_password"))
+	router.Post(p.webroot+"/web/forgot-password", srvsupport.WrapNamed(p.forgot, "web_forgot_password_post"))
+	router.Get(p.webroot+"/web/reset-password", srvsupport.WrapNamed(p.resetPage, "web_reset_password"))
+	router.Post(p.webroot+"/web/reset-password", srvsupport.WrapNamed(p.reset, "web_reset_password_post"))
+}
+
+func (p webPasswordReset) forgotPage(ctx context.Context, w http.ResponseWriter, _ *http.Request,
+	_ *zerolog.Logger,
+) {
+	p.renderer.WritePage(ctx, w, &nt.ForgotPasswordPage{})
+}
+
+// forgot send reset link to user. Response is the same for unknown users, locked accounts and accounts
+// without email to not reveal existing accounts. Mail is sent in background, so response time also do
+// not depend on account state.
+func (p webPasswordReset) forgot(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("WebPasswordReset: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	username := r.PostFormValue("username")
+
+	user, token, err := p.resetSrv.CreateResetToken(ctx, username)
+	switch {
+	case err == nil:
+		go p.sendResetMessage(context.WithoutCancel(ctx), user, token)
+	case aerr.HasTag(err, aerr.InternalError):
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("WebPasswordReset: create reset token user_name=%s error=%q", username, err)
+
+		return
+	default:
+		logger.Info().Err(err).Str(common.LogKeyUserName, username).
+			Msgf("WebPasswordReset: reset rejected user_name=%s error=%q", username, err)
+	}
+
+	p.renderer.WritePage(ctx, w, &nt.ForgotPasswordPage{Sent: true})
+}
+
+func (p webPasswordReset) resetPage(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger,
+) {
+	token := r.URL.Query().Get("token")
+	page := nt.ResetPasswordPage{Token: token}
+
+	username, err := p.resetSrv.CheckResetToken(ctx, token)
+	switch {
+	case err == nil:
+		page.UserName = username
+	case aerr.HasTag(err, aerr.ValidationError):
+		logger.Info().Err(err).Msgf("WebPasswordReset: invalid token error=%q", err)
+
+		page.Invalid = true
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("WebPasswordReset: check token error=%q", err)
+
+		return
+	}
+
+	p.renderer.WritePage(ctx, w, &page)
+}
+
+func (p webPasswordReset) reset(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("WebPasswordReset: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	cmd := command.ResetPasswordCmd{
+		Token:    r.PostFormValue("token"),
+		Password: r.PostFormValue("password"),
+	}
+	page := nt.ResetPasswordPage{Token: cmd.Token, UserName: r.PostFormValue("username")}
+
+	if cmd.Password != r.PostFormValue("password2") {
+		page.Msg = "Error: passwords not match"
+
+		w.WriteHeader(http.StatusBadRequest)
+		p.renderer.WritePage(ctx, w, &page)
+
+		return
+	}
+
+	err := p.resetSrv.ResetPassword(ctx, &cmd)
+	switch {
+	case err == nil:
+		page.Done = true
+	case aerr.HasTag(err, aerr.ValidationError):
+		logger.Info().Err(err).Msgf("WebPasswordReset: reset password failed error=%q", err)
+
+		page.Msg = "Error: " + aerr.GetUserMessageOr(err, "invalid data")
+		page.Invalid = errors.Is(err, common.ErrInvalidResetToken)
+
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("WebPasswordReset: reset password error=%q", err)
+
+		return
+	}
+
+	p.renderer.WritePage(ctx, w, &page)
+}
+
+func (p webPasswordReset) sendResetMessage(ctx context.Context, user *model.User, token string) {
+	if err := p.sender.Send(ctx, p.resetMessage(user, token)); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str(common.LogKeyUserName, user.UserName).
+			Msgf("WebPasswordReset: send reset link user_name=%s error=%q", user.UserName, err)
+	}
+}
+
+func (p webPasswordReset) resetMessage(user *model.User, token string) *mailer.Message {
+	link := p.publicURL + "/web/reset-password?token=" + url.QueryEscape(token)
+
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: "go-gpo: password reset",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"password reset was requested for your account. Use link below to set new password:\n\n"+
+			"%s\n\n"+
+			"Link is valid for %d minutes. If you didn't request password reset, ignore this message.\n",
+			user.UserName, link, int(service.PasswordResetTokenTTL.Minutes())),
+	}
+}
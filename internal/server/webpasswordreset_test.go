@@ -0,0 +1,257 @@
+package server
+
+//
+// webpasswordreset_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"gitea.com/go-chi/session"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/mailer"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+func TestWebPasswordReset(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+
+	smtpSrv := newMockSMTPServer(t)
+	srv := newWebPasswordResetTestServer(t, i, smtpSrv)
+	client := newTestClient(t)
+
+	status, body := doGet(t, client, srv.URL+"/web/forgot-password")
+	if status != http.StatusOK || !strings.Contains(body, `name="username"`) {
+		t.Fatalf("expected forgot password form: %d, body: %q", status, body)
+	}
+
+	// unknown user get the same response and no mail is sent
+	status, body = doPostForm(t, client, srv.URL+"/web/forgot-password", url.Values{"username": {"unknown"}})
+	if status != http.StatusOK || !strings.Contains(body, "link for setting new password was sent") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	// locked account also
+	prepareTestUser(ctx, t, i, "user2")
+
+	if err := do.MustInvoke[*service.UsersSrv](i).LockAccount(ctx,
+		command.LockAccountCmd{UserName: "user2"}); err != nil {
+		t.Fatalf("lock account error: %#+v", err)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/forgot-password", url.Values{"username": {"user2"}})
+	if status != http.StatusOK || !strings.Contains(body, "link for setting new password was sent") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/forgot-password", url.Values{"username": {"user1"}})
+	if status != http.StatusOK || !strings.Contains(body, "link for setting new password was sent") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	mail := smtpSrv.waitMail(t)
+	if mail.from != "gpo@example.com" || mail.to != "user1@example.com" ||
+		!strings.Contains(mail.data, "Subject: go-gpo: password reset") {
+		t.Fatalf("invalid mail: %+v", mail)
+	}
+
+	link := regexp.MustCompile(`https://gpo\.example\.com/web/reset-password\?token=\S+`).FindString(mail.data)
+	if link == "" {
+		t.Fatalf("missing reset link in mail: %q", mail.data)
+	}
+
+	select {
+	case m := <-smtpSrv.mails:
+		t.Fatalf("unexpected mail: %+v", m)
+	default:
+	}
+
+	link = strings.Replace(link, "https://gpo.example.com", srv.URL, 1)
+	lu, _ := url.Parse(link)
+	token := lu.Query().Get("token")
+
+	status, body = doGet(t, client, link)
+	if status != http.StatusOK || !strings.Contains(body, `name="password2"`) || !strings.Contains(body, "user1") {
+		t.Fatalf("expected reset password form: %d, body: %q", status, body)
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/reset-password",
+		url.Values{"token": {token}, "password": {"newpass"}, "password2": {"newpass"}})
+	if status != http.StatusOK || !strings.Contains(body, "Password changed") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	if _, err := do.MustInvoke[*service.UsersSrv](i).LoginUser(ctx, "user1", "newpass"); err != nil {
+		t.Fatalf("login with new password error: %#+v", err)
+	}
+
+	// link can't be used again
+	status, body = doGet(t, client, link)
+	if status != http.StatusOK || !strings.Contains(body, "Invalid or expired password reset link") {
+		t.Fatalf("invalid response for used link: %d, body: %q", status, body)
+	}
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/reset-password",
+		url.Values{"token": {token}, "password": {"other"}, "password2": {"other"}})
+	if status != http.StatusBadRequest {
+		t.Fatalf("invalid status for used token: %d", status)
+	}
+}
+
+//-------------------------------------------------------------
+
+func newWebPasswordResetTestServer(t *testing.T, i do.Injector, smtpSrv *mockSMTPServer) *httptest.Server {
+	t.Helper()
+
+	do.ProvideNamedValue(i, "server.webroot", "")
+
+	renderer, err := nt.NewRenderer(i)
+	if err != nil {
+		t.Fatalf("create renderer failed: %#+v", err)
+	}
+
+	smtpConf := config.SMTPConf{Address: smtpSrv.listener.Addr().String(), From: "gpo@example.com"}
+	if err := smtpConf.Validate(); err != nil {
+		t.Fatalf("validate smtp config error: %#+v", err)
+	}
+
+	reset := webPasswordReset{
+		resetSrv:  do.MustInvoke[*service.PasswordResetSrv](i),
+		sender:    mailer.NewSMTPSender(&smtpConf),
+		renderer:  renderer,
+		publicURL: "https://gpo.example.com",
+	}
+
+	sess, err := session.Sessioner(session.Options{Provider: "memory"})
+	if err != nil {
+		t.Fatalf("start session manager failed: %#+v", err)
+	}
+
+	router := chi.NewRouter()
+	router.Use(hlog.NewHandler(log.Logger))
+	router.Use(sess)
+	router.Group(reset.routes)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// mockSMTPMail is mail received by mockSMTPServer.
+type mockSMTPMail struct {
+	from string
+	to   string
+	data string
+}
+
+// mockSMTPServer is minimal smtp server without tls and authentication that capture received mails.
+type mockSMTPServer struct {
+	listener net.Listener
+	mails    chan mockSMTPMail
+}
+
+func newMockSMTPServer(t *testing.T) *mockSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %#+v", err)
+	}
+
+	srv := &mockSMTPServer{listener: listener, mails: make(chan mockSMTPMail, 10)} //nolint:mnd
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go srv.serve(conn)
+		}
+	}()
+
+	return srv
+}
+
+func (s *mockSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP mock")
+
+	mail := mockSMTPMail{}
+
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL":
+			mail.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			mail.to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 send data")
+
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+
+			mail.data = string(data)
+			s.mails <- mail
+			mail = mockSMTPMail{}
+
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *mockSMTPServer) waitMail(t *testing.T) mockSMTPMail {
+	t.Helper()
+
+	select {
+	case mail := <-s.mails:
+		return mail
+	case <-time.After(5 * time.Second): //nolint:mnd
+		t.Fatalf("mail not received")
+	}
+
+	return mockSMTPMail{}
+}
@@ -0,0 +1,122 @@
+package server
+
+//
+// webregister.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+// webRegister serve public registration form for new users. Depending on mode registration require
+// invite code or admin approval.
+type webRegister struct {
+	registrationSrv *service.RegistrationSrv
+	renderer        *nt.Renderer
+	webroot         string
+	mode            string
+}
+
+func newWebRegister(i do.Injector) webRegister {
+	cfg := do.MustInvoke[*config.ServerConf](i)
+
+	return webRegister{
+		registrationSrv: do.MustInvoke[*service.RegistrationSrv](i),
+		renderer:        do.MustInvoke[*nt.Renderer](i),
+		webroot:         cfg.MainServer.WebRoot,
+		mode:            cfg.Registration,
+	}
+}
+
+func (g webRegister) routes(router chi.Router) {
+	router.Get(g.webroot+"/web/register", srvsupport.WrapNamed(g.registerPage, "web_register"))
+	router.Post(g.webroot+"/web/register", srvsupport.WrapNamed(g.register, "web_register_post"))
+}
+
+func (g webRegister) registerPage(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	_ *zerolog.Logger,
+) {
+	g.renderer.WritePage(ctx, w, &nt.RegisterPage{
+		RequireInvite: g.mode == config.RegistrationInvite,
+		InviteCode:    r.URL.Query().Get("invite"),
+	})
+}
+
+func (g webRegister) register(ctx context.Context, w http.ResponseWriter, r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("WebRegister: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	cmd := command.RegisterUserCmd{
+		UserName:        r.PostFormValue("username"),
+		Password:        r.PostFormValue("password"),
+		Email:           r.PostFormValue("email"),
+		Name:            r.PostFormValue("name"),
+		InviteCode:      r.PostFormValue("invite"),
+		RequireInvite:   g.mode == config.RegistrationInvite,
+		RequireApproval: g.mode == config.RegistrationApproval,
+	}
+
+	page := nt.RegisterPage{
+		UserName:      cmd.UserName,
+		Email:         cmd.Email,
+		Name:          cmd.Name,
+		InviteCode:    cmd.InviteCode,
+		RequireInvite: cmd.RequireInvite,
+	}
+
+	if cmd.Password != r.PostFormValue("password2") {
+		page.Msg = "Error: passwords not match"
+
+		w.WriteHeader(http.StatusBadRequest)
+		g.renderer.WritePage(ctx, w, &page)
+
+		return
+	}
+
+	res, err := g.registrationSrv.Register(ctx, &cmd)
+
+	switch {
+	case err == nil:
+		logger.Info().Object("cmd", &cmd).Msgf("WebRegister: user registered user_name=%s", cmd.UserName)
+
+		page.Registered = true
+		page.PendingApproval = res.PendingApproval
+	case aerr.HasTag(err, aerr.ValidationError), errors.Is(err, common.ErrUserExists):
+		logger.Info().Err(err).Object("cmd", &cmd).
+			Msgf("WebRegister: registration failed user_name=%s error=%q", cmd.UserName, err)
+
+		page.Msg = "Error: " + aerr.GetUserMessageOr(err, "invalid data")
+
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).Object("cmd", &cmd).
+			Msgf("WebRegister: registration error user_name=%s error=%q", cmd.UserName, err)
+
+		return
+	}
+
+	g.renderer.WritePage(ctx, w, &page)
+}
@@ -0,0 +1,161 @@
+package server
+
+//
+// webregister_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"gitea.com/go-chi/session"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/hlog"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/config"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+func TestWebRegisterApproval(t *testing.T) {
+	ctx, i := prepareTests(t)
+	srv := newWebRegisterTestServer(t, i, config.RegistrationApproval)
+	client := newTestClient(t)
+
+	status, body := doGet(t, client, srv.URL+"/web/register")
+	if status != http.StatusOK || !strings.Contains(body, `name="password2"`) || strings.Contains(body, `name="invite"`) {
+		t.Fatalf("expected register form: %d, body: %q", status, body)
+	}
+
+	form := url.Values{
+		"username":  {"user1"},
+		"email":     {"user1@example.com"},
+		"password":  {"secret123"},
+		"password2": {"other"},
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/register", form)
+	if status != http.StatusBadRequest || !strings.Contains(body, "passwords not match") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	form.Set("password2", "secret123")
+
+	status, body = doPostForm(t, client, srv.URL+"/web/register", form)
+	if status != http.StatusOK || !strings.Contains(body, "approv") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	usersSrv := do.MustInvoke[*service.UsersSrv](i)
+
+	// account is locked until approved
+	if _, err := usersSrv.LoginUser(ctx, "user1", "secret123"); err == nil {
+		t.Fatalf("login should fail before approval")
+	}
+
+	// user name is already taken
+	status, _ = doPostForm(t, client, srv.URL+"/web/register", form)
+	if status != http.StatusBadRequest {
+		t.Fatalf("invalid status for duplicated user: %d", status)
+	}
+
+	if err := do.MustInvoke[*service.RegistrationSrv](i).ApproveRegistration(ctx, "user1"); err != nil {
+		t.Fatalf("approve error: %#+v", err)
+	}
+
+	if _, err := usersSrv.LoginUser(ctx, "user1", "secret123"); err != nil {
+		t.Fatalf("login after approval error: %#+v", err)
+	}
+}
+
+func TestWebRegisterInvite(t *testing.T) {
+	ctx, i := prepareTests(t)
+	srv := newWebRegisterTestServer(t, i, config.RegistrationInvite)
+	client := newTestClient(t)
+
+	status, body := doGet(t, client, srv.URL+"/web/register")
+	if status != http.StatusOK || !strings.Contains(body, `name="invite"`) {
+		t.Fatalf("expected register form with invite: %d, body: %q", status, body)
+	}
+
+	form := url.Values{
+		"username":  {"user1"},
+		"email":     {"user1@example.com"},
+		"password":  {"secret123"},
+		"password2": {"secret123"},
+		"invite":    {"invalid"},
+	}
+
+	status, body = doPostForm(t, client, srv.URL+"/web/register", form)
+	if status != http.StatusBadRequest || !strings.Contains(body, "Error:") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	res, err := do.MustInvoke[*service.RegistrationSrv](i).CreateInviteCode(ctx,
+		&command.CreateInviteCodeCmd{CreatedBy: "admin"})
+	if err != nil {
+		t.Fatalf("create invite code error: %#+v", err)
+	}
+
+	form.Set("invite", res.Code)
+
+	status, body = doPostForm(t, client, srv.URL+"/web/register", form)
+	if status != http.StatusOK || strings.Contains(body, "Error:") {
+		t.Fatalf("invalid response: %d, body: %q", status, body)
+	}
+
+	if _, err := do.MustInvoke[*service.UsersSrv](i).LoginUser(ctx, "user1", "secret123"); err != nil {
+		t.Fatalf("login error: %#+v", err)
+	}
+
+	// code can be used only once
+	form.Set("username", "user2")
+	form.Set("email", "user2@example.com")
+
+	status, _ = doPostForm(t, client, srv.URL+"/web/register", form)
+	if status != http.StatusBadRequest {
+		t.Fatalf("invalid status for used invite code: %d", status)
+	}
+}
+
+//-------------------------------------------------------------
+
+func newWebRegisterTestServer(t *testing.T, i do.Injector, mode string) *httptest.Server {
+	t.Helper()
+
+	do.ProvideNamedValue(i, "server.webroot", "")
+
+	renderer, err := nt.NewRenderer(i)
+	if err != nil {
+		t.Fatalf("create renderer failed: %#+v", err)
+	}
+
+	reg := webRegister{
+		registrationSrv: do.MustInvoke[*service.RegistrationSrv](i),
+		renderer:        renderer,
+		mode:            mode,
+	}
+
+	sess, err := session.Sessioner(session.Options{Provider: "memory"})
+	if err != nil {
+		t.Fatalf("start session manager failed: %#+v", err)
+	}
+
+	router := chi.NewRouter()
+	router.Use(hlog.NewHandler(log.Logger))
+	router.Use(sess)
+	router.Group(reg.routes)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
@@ -0,0 +1,101 @@
+//
+// audit.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+// auditUserAgentMaxLen limit length of user agent stored in audit log.
+const auditUserAgentMaxLen = 256
+
+// AuditSrv manage security audit log.
+type AuditSrv struct {
+	dbi       repository.Database
+	auditRepo repository.AuditLog
+}
+
+func NewAuditSrv(i do.Injector) (*AuditSrv, error) {
+	return &AuditSrv{
+		dbi:       do.MustInvoke[repository.Database](i),
+		auditRepo: do.MustInvoke[repository.AuditLog](i),
+	}, nil
+}
+
+// Record save event in audit log. Client ip address and user agent are taken from context.
+// Errors are only logged, so failure of audit log never break audited operation. Must be called
+// outside of database transaction.
+func (a *AuditSrv) Record(ctx context.Context, action, username, details string) {
+	ip, useragent := common.ContextClientInfo(ctx)
+	if len(useragent) > auditUserAgentMaxLen {
+		useragent = useragent[:auditUserAgentMaxLen]
+	}
+
+	event := model.AuditEvent{
+		CreatedAt: time.Now().UTC(),
+		UserName:  username,
+		Action:    action,
+		IP:        ip,
+		UserAgent: useragent,
+		Details:   details,
+	}
+
+	err := db.InTransaction(ctx, a.dbi, func(ctx context.Context) error {
+		return a.auditRepo.SaveAuditEvent(ctx, &event)
+	})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Object("event", &event).
+			Msgf("AuditSrv: save audit event failed action=%s user_name=%s error=%q", action, username, err)
+	}
+}
+
+// ListEvents return events matching query from newest.
+func (a *AuditSrv) ListEvents(ctx context.Context, query *query.GetAuditEventsQuery) ([]model.AuditEvent, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	events, err := db.InConnectionR(ctx, a.dbi, func(ctx context.Context) ([]model.AuditEvent, error) {
+		return a.auditRepo.ListAuditEvents(ctx, query.UserName, query.Action, query.Since, query.Limit)
+	})
+	if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return events, nil
+}
+
+// PruneEvents delete events older than `olderThan`. Return number of deleted events.
+func (a *AuditSrv) PruneEvents(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if olderThan <= 0 {
+		return 0, aerr.ErrValidation.WithUserMsg("retention time must be greater than 0")
+	}
+
+	before := time.Now().UTC().Add(-olderThan)
+
+	deleted, err := db.InTransactionR(ctx, a.dbi, func(ctx context.Context) (int64, error) {
+		return a.auditRepo.DeleteAuditEvents(ctx, before)
+	})
+	if err != nil {
+		return 0, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	zerolog.Ctx(ctx).Info().Msgf("AuditSrv: pruned audit events before=%s deleted=%d", before, deleted)
+
+	return deleted, nil
+}
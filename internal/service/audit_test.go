@@ -0,0 +1,121 @@
+//nolint:nilaway
+package service
+
+//
+// audit_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+func TestAuditRecordAndList(t *testing.T) {
+	ctx, i := prepareTests(t)
+	auditSrv := do.MustInvoke[*AuditSrv](i)
+
+	ctx = common.ContextWithClientInfo(ctx, "10.0.0.1", "test-agent")
+
+	auditSrv.Record(ctx, model.AuditLogin, "user1", "web")
+	auditSrv.Record(ctx, model.AuditLoginFailed, "user1", "web: authorization failed")
+	auditSrv.Record(ctx, model.AuditLogin, "user2", "basic auth")
+
+	events, err := auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 3)
+	// newest first
+	assert.Equal(t, events[0].UserName, "user2")
+	assert.Equal(t, events[0].IP, "10.0.0.1")
+	assert.Equal(t, events[0].UserAgent, "test-agent")
+
+	events, err = auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 2)
+
+	events, err = auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{Action: model.AuditLogin})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 2)
+
+	events, err = auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{UserName: "user1", Action: model.AuditLogin})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].Details, "web")
+
+	events, err = auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{Limit: 1})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 1)
+
+	events, err = auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{Since: time.Now().Add(time.Hour)})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 0)
+
+	_, err = auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{Action: "invalid"})
+	assert.True(t, aerr.HasTag(err, aerr.ValidationError))
+}
+
+func TestAuditPrune(t *testing.T) {
+	ctx, i := prepareTests(t)
+	auditSrv := do.MustInvoke[*AuditSrv](i)
+	auditRepo := do.MustInvoke[repository.AuditLog](i)
+
+	err := db.InTransaction(ctx, do.MustInvoke[repository.Database](i), func(ctx context.Context) error {
+		return auditRepo.SaveAuditEvent(ctx, &model.AuditEvent{
+			CreatedAt: time.Now().Add(-48 * time.Hour),
+			UserName:  "user1",
+			Action:    model.AuditLogin,
+		})
+	})
+	assert.NoErr(t, err)
+
+	auditSrv.Record(ctx, model.AuditLogin, "user1", "")
+
+	deleted, err := auditSrv.PruneEvents(ctx, 24*time.Hour)
+	assert.NoErr(t, err)
+	assert.Equal(t, deleted, 1)
+
+	events, err := auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(events), 1)
+
+	_, err = auditSrv.PruneEvents(ctx, 0)
+	assert.True(t, aerr.HasTag(err, aerr.ValidationError))
+}
+
+func TestAuditRecordedActions(t *testing.T) {
+	ctx, i := prepareTests(t)
+	prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	auditSrv := do.MustInvoke[*AuditSrv](i)
+
+	assert.NoErr(t, usersSrv.LockAccount(ctx, command.LockAccountCmd{UserName: "user1"}))
+	assert.NoErr(t, usersSrv.ChangePassword(ctx, &command.ChangeUserPasswordCmd{UserName: "user1", Password: "new123"}))
+	assert.NoErr(t, do.MustInvoke[*DevicesSrv](i).DeleteDevice(ctx,
+		&command.DeleteDeviceCmd{UserName: "user1", DeviceName: "dev1"}))
+
+	events, err := auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+
+	actions := make([]string, len(events))
+	for idx, e := range events {
+		actions[idx] = e.Action
+	}
+
+	assert.Equal(t, actions, []string{
+		model.AuditDeviceDelete, model.AuditAccountUnlock, model.AuditPasswordChange, model.AuditAccountLock,
+	})
+}
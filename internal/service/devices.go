@@ -10,6 +10,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/samber/do/v2"
 	"gitlab.com/kabes/go-gpo/internal/aerr"
@@ -22,16 +23,20 @@ import (
 )
 
 type DevicesSrv struct {
-	dbi         repository.Database
-	usersRepo   repository.Users
-	devicesRepo repository.Devices
+	dbi               repository.Database
+	usersRepo         repository.Users
+	devicesRepo       repository.Devices
+	subscriptionsRepo repository.Subscriptions
+	auditSrv          *AuditSrv
 }
 
 func NewDevicesSrv(i do.Injector) (*DevicesSrv, error) {
 	return &DevicesSrv{
-		dbi:         do.MustInvoke[repository.Database](i),
-		usersRepo:   do.MustInvoke[repository.Users](i),
-		devicesRepo: do.MustInvoke[repository.Devices](i),
+		dbi:               do.MustInvoke[repository.Database](i),
+		usersRepo:         do.MustInvoke[repository.Users](i),
+		devicesRepo:       do.MustInvoke[repository.Devices](i),
+		subscriptionsRepo: do.MustInvoke[repository.Subscriptions](i),
+		auditSrv:          do.MustInvoke[*AuditSrv](i),
 	}, nil
 }
 
@@ -96,6 +101,20 @@ func (d *DevicesSrv) ListDevices(ctx context.Context, query *query.GetDevicesQue
 			return nil, aerr.ApplyFor(ErrRepositoryError, err, "get devices from db failed")
 		}
 
+		// synchronized devices have own set of subscriptions; other share user subscriptions
+		for idx, dev := range devices {
+			if dev.SyncGroup == 0 {
+				continue
+			}
+
+			podcasts, err := d.subscriptionsRepo.ListDeviceSubscriptions(ctx, user.ID, dev.ID, time.Time{})
+			if err != nil {
+				return nil, aerr.ApplyFor(ErrRepositoryError, err, "get device subscriptions failed")
+			}
+
+			devices[idx].Subscriptions = len(podcasts.SubscribedOnly())
+		}
+
 		return devices, nil
 	})
 	if err != nil {
@@ -110,8 +129,7 @@ func (d *DevicesSrv) DeleteDevice(ctx context.Context, cmd *command.DeleteDevice
 		return aerr.Wrapf(err, "validate cmd failed")
 	}
 
-	//nolint:wrapcheck
-	return db.InTransaction(ctx, d.dbi, func(ctx context.Context) error {
+	err := db.InTransaction(ctx, d.dbi, func(ctx context.Context) error {
 		user, err := d.usersRepo.GetUser(ctx, cmd.UserName)
 		if errors.Is(err, common.ErrNoData) {
 			return common.ErrUnknownUser
@@ -132,4 +150,11 @@ func (d *DevicesSrv) DeleteDevice(ctx context.Context, cmd *command.DeleteDevice
 
 		return nil
 	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	d.auditSrv.Record(ctx, model.AuditDeviceDelete, cmd.UserName, "device="+cmd.DeviceName)
+
+	return nil
 }
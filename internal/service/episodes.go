@@ -10,6 +10,7 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -30,6 +31,7 @@ type EpisodesSrv struct {
 	devicesRepo  repository.Devices
 	podcastsRepo repository.Podcasts
 	usersRepo    repository.Users
+	subsRepo     repository.Subscriptions
 }
 
 func NewEpisodesSrv(i do.Injector) (*EpisodesSrv, error) {
@@ -39,6 +41,7 @@ func NewEpisodesSrv(i do.Injector) (*EpisodesSrv, error) {
 		devicesRepo:  do.MustInvoke[repository.Devices](i),
 		podcastsRepo: do.MustInvoke[repository.Podcasts](i),
 		usersRepo:    do.MustInvoke[repository.Users](i),
+		subsRepo:     do.MustInvoke[repository.Subscriptions](i),
 	}, nil
 }
 
@@ -124,11 +127,30 @@ func (e *EpisodesSrv) AddAction(ctx context.Context, cmd *command.AddActionCmd)
 
 		common.TraceLazyPrintf(ctx, "AddAction: cache filled")
 
+		// actions for moved feeds are saved for podcast with current url
+		podcastsurls := make([]string, 0)
+		for _, act := range cmd.Actions {
+			if !slices.Contains(podcastsurls, act.Podcast.URL) {
+				podcastsurls = append(podcastsurls, act.Podcast.URL)
+			}
+		}
+
+		_, redirects, err := resolveFeedRedirects(ctx, e.podcastsRepo, podcastsurls)
+		if err != nil {
+			return err
+		}
+
 		episodes := make([]model.Episode, len(cmd.Actions))
 		for idx, act := range cmd.Actions {
 			episode := act
 
-			episode.Podcast.ID, err = podcastscache.GetOrCreate(act.Podcast.URL)
+			for _, r := range redirects {
+				if r[0] == episode.Podcast.URL {
+					episode.Podcast.URL = r[1]
+				}
+			}
+
+			episode.Podcast.ID, err = podcastscache.GetOrCreate(episode.Podcast.URL)
 			if err != nil {
 				return err
 			}
@@ -154,6 +176,7 @@ func (e *EpisodesSrv) AddAction(ctx context.Context, cmd *command.AddActionCmd)
 }
 
 // GetUpdates return list of EpisodeUpdate for `username` and optionally `devicename` and `since`.
+// Result include also new episodes from feeds of subscribed podcasts (with status "new").
 // if `includeActions` add to each episode last action.
 // devicename is ignored but checked
 // Used by /api/2/updates.
@@ -167,7 +190,40 @@ func (e *EpisodesSrv) GetUpdates(ctx context.Context, query *query.GetEpisodeUpd
 	}
 
 	episodes, err := db.InConnectionR(ctx, e.dbi, func(ctx context.Context) ([]model.Episode, error) {
-		return e.getEpisodes(ctx, query.UserName, query.DeviceName, "", query.Since, true, false, 0)
+		episodes, err := e.getEpisodes(ctx, query.UserName, query.DeviceName, "", query.Since, true, false, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := e.usersRepo.GetUser(ctx, query.UserName)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		// synchronized device has own subscriptions; other devices share user subscriptions
+		var deviceid *int64
+
+		if query.DeviceName != "" {
+			device, err := e.devicesRepo.GetDevice(ctx, user.ID, query.DeviceName)
+			if err != nil {
+				return nil, aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			if device.SyncGroup > 0 {
+				deviceid = &device.ID
+			}
+		}
+
+		// episodes found in feeds of podcasts subscribed by device but not touched by user yet
+		newEpisodes, err := e.episodesRepo.ListNewEpisodes(ctx, user.ID, deviceid, query.Since)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		episodes = append(episodes, newEpisodes...)
+		slices.SortStableFunc(episodes, func(a, b model.Episode) int { return a.Timestamp.Compare(b.Timestamp) })
+
+		return episodes, nil
 	})
 	if err != nil {
 		return nil, err //nolint:wrapcheck
@@ -227,6 +283,69 @@ func (e *EpisodesSrv) GetFavorites(ctx context.Context, username string) ([]mode
 	return common.Map(episodes, model.NewFavoriteFromModel), nil
 }
 
+// GetEpisodeData return episode metadata collected from all users.
+func (e *EpisodesSrv) GetEpisodeData(ctx context.Context, query *query.GetEpisodeDataQuery,
+) (*model.EpisodeUpdate, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, e.dbi, func(ctx context.Context) (*model.EpisodeUpdate, error) {
+		user, err := e.usersRepo.GetUser(ctx, query.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownUser
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		episode, err := e.episodesRepo.GetCatalogEpisode(ctx, query.PodcastURL, query.EpisodeURL)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownEpisode
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		// link to podcast page only when user has this podcast
+		own, err := e.podcastsRepo.GetPodcast(ctx, user.ID, query.PodcastURL)
+		if err == nil {
+			episode.Podcast.ID = own.ID
+		} else if !errors.Is(err, common.ErrNoData) {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		data := model.NewEpisodeUpdate(episode)
+		data.Title = common.Coalesce(episode.Title, episode.URL)
+
+		return &data, nil
+	})
+}
+
+// GetFeedEpisodes return episodes loaded from podcast feed, newest first.
+func (e *EpisodesSrv) GetFeedEpisodes(ctx context.Context, query *query.GetFeedEpisodesQuery,
+) ([]model.Episode, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, e.dbi, func(ctx context.Context) ([]model.Episode, error) {
+		_, err := e.usersRepo.GetUser(ctx, query.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownUser
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		episodes, err := e.episodesRepo.ListFeedEpisodes(ctx, query.PodcastURL, query.Since, query.Limit)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return episodes, nil
+	})
+}
+
 // ------------------------------------------------------
 
 func (e *EpisodesSrv) getEpisodes(
@@ -305,6 +424,12 @@ func (e *EpisodesSrv) createPodcastsCache(ctx context.Context, user *model.User)
 				return 0, aerr.Wrapf(err, "create new podcast failed").WithMeta("podcast_url", key, "user_id", user.ID)
 			}
 
+			// podcast is subscribed on all devices
+			change := model.NewSubscriptionChange(id, nil, true, podcast.UpdatedAt)
+			if err := e.subsRepo.SaveSubscriptionChanges(ctx, change); err != nil {
+				return 0, aerr.Wrapf(err, "save subscription change failed").WithMeta("podcast_url", key, "user_id", user.ID)
+			}
+
 			return id, nil
 		},
 	}
@@ -9,14 +9,18 @@ package service
 //
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/samber/do/v2"
 	"gitlab.com/kabes/go-gpo/internal/assert"
 	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
 	"gitlab.com/kabes/go-gpo/internal/model"
 	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
 )
 
 func TestEpisodesServiceSave(t *testing.T) {
@@ -267,6 +271,181 @@ func TestEpisodesServiceUpdates(t *testing.T) {
 	compareEpisodes(t, *updates[1].Episode, episodeActions[2])
 }
 
+func TestEpisodesServiceUpdatesNewEpisodes(t *testing.T) {
+	ctx, i := prepareTests(t)
+	episodesSrv := do.MustInvoke[*EpisodesSrv](i)
+	subsSrv := do.MustInvoke[*SubscriptionsSrv](i)
+	episodesRepo := do.MustInvoke[repository.Episodes](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user1", "dev2")
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2")
+
+	episodeActions := prepareEpisodes()
+	err := episodesSrv.AddAction(ctx, &command.AddActionCmd{UserName: "user1", Actions: episodeActions})
+	assert.NoErr(t, err)
+
+	released := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	err = db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		err := episodesRepo.UpdateEpisodeInfo(ctx, "http://example.com/p1",
+			// episode with user action
+			model.Episode{URL: "http://example.com/p1/ep1", Title: "Episode 1", Released: released},
+			model.Episode{URL: "http://example.com/p1/ep3", Title: "Episode 3", Released: released},
+			// archive loaded on first fetch of feed
+			model.Episode{URL: "http://example.com/p1/ep0", Title: "Episode 0", Released: released.Add(-240 * time.Hour)},
+		)
+		if err != nil {
+			return err
+		}
+
+		return episodesRepo.UpdateEpisodeInfo(ctx, "http://example.com/p2",
+			model.Episode{URL: "http://example.com/p2/ep2", Title: "Episode 2", Released: released.Add(time.Hour)})
+	})
+	assert.NoErr(t, err)
+
+	// action made by other device after episodes were found in feeds
+	lastAction := model.Episode{
+		Podcast:   &model.Podcast{URL: "http://example.com/p1"},
+		URL:       "http://example.com/p1/ep1",
+		Device:    &model.Device{Name: "dev2"},
+		Action:    "play",
+		Timestamp: time.Now().Add(time.Hour),
+	}
+	err = episodesSrv.AddAction(ctx, &command.AddActionCmd{UserName: "user1", Actions: []model.Episode{lastAction}})
+	assert.NoErr(t, err)
+
+	q := query.GetEpisodeUpdatesQuery{
+		UserName:   "user1",
+		DeviceName: "dev1",
+		Since:      time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+	}
+	updates, err := episodesSrv.GetUpdates(ctx, &q)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(updates), 4)
+	assert.Equal(t, updates[0].URL, episodeActions[3].URL)
+	assert.Equal(t, updates[1].URL, "http://example.com/p1/ep3")
+	assert.Equal(t, updates[1].Status, model.ActionNew)
+	assert.Equal(t, updates[1].Title, "Episode 3")
+	assert.Equal(t, updates[1].PodcastURL, "http://example.com/p1")
+	assert.Equal(t, updates[1].Released, released)
+	assert.True(t, updates[1].MygpoLink != "")
+	// episodes published before `since` are not new even when found in feed after it
+	assert.Equal(t, updates[2].URL, "http://example.com/p2/ep2")
+	assert.Equal(t, updates[2].Status, model.ActionNew)
+	// updates are ordered by time of action or publication
+	assert.Equal(t, updates[3].URL, lastAction.URL)
+	assert.Equal(t, updates[3].Status, lastAction.Action)
+
+	// episodes published before `since` are not new
+	updates, err = episodesSrv.GetUpdates(ctx, &query.GetEpisodeUpdatesQuery{
+		UserName:   "user1",
+		DeviceName: "dev1",
+		Since:      time.Now().Add(time.Minute),
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(updates), 1)
+	assert.Equal(t, updates[0].URL, lastAction.URL)
+
+	// dev2 share user subscriptions
+	updates, err = episodesSrv.GetUpdates(ctx, &query.GetEpisodeUpdatesQuery{
+		UserName:   "user1",
+		DeviceName: "dev2",
+		Since:      q.Since,
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, countNewEpisodes(updates), 2)
+
+	// new episodes are not reported for unsubscribed podcasts
+	_, err = subsSrv.ChangeSubscriptions(ctx, &command.ChangeSubscriptionsCmd{
+		UserName:   "user1",
+		DeviceName: "dev1",
+		Remove:     []string{"http://example.com/p2"},
+		Timestamp:  time.Now(),
+	})
+	assert.NoErr(t, err)
+
+	q.IncludeActions = true
+	updates, err = episodesSrv.GetUpdates(ctx, &q)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(updates), 3)
+	assert.Equal(t, updates[1].URL, "http://example.com/p1/ep3")
+	assert.Equal(t, updates[1].Episode, nil)
+	assert.Equal(t, updates[2].URL, lastAction.URL)
+
+	// synchronized devices see only podcasts subscribed in group
+	prepareTestDevice(ctx, t, i, "user1", "dev3")
+	_, err = subsSrv.UpdateSyncStatus(ctx, &command.UpdateSyncDevicesCmd{
+		UserName:    "user1",
+		Synchronize: [][]string{{"dev1", "dev3"}},
+	})
+	assert.NoErr(t, err)
+
+	_, err = subsSrv.ChangeSubscriptions(ctx, &command.ChangeSubscriptionsCmd{
+		UserName:   "user1",
+		DeviceName: "dev3",
+		Remove:     []string{"http://example.com/p1"},
+		Timestamp:  time.Now(),
+	})
+	assert.NoErr(t, err)
+
+	updates, err = episodesSrv.GetUpdates(ctx, &q)
+	assert.NoErr(t, err)
+	assert.Equal(t, countNewEpisodes(updates), 0)
+
+	updates, err = episodesSrv.GetUpdates(ctx, &query.GetEpisodeUpdatesQuery{
+		UserName:   "user1",
+		DeviceName: "dev2",
+		Since:      q.Since,
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, countNewEpisodes(updates), 1)
+}
+
+func TestEpisodesServiceUpdatesFeedArchive(t *testing.T) {
+	ctx, i := prepareTests(t)
+	episodesSrv := do.MustInvoke[*EpisodesSrv](i)
+	episodesRepo := do.MustInvoke[repository.Episodes](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1")
+
+	since := time.Now().Add(-time.Minute).UTC()
+
+	// first fetch of feed load whole archive
+	err := db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		return episodesRepo.UpdateEpisodeInfo(ctx, "http://example.com/p1",
+			model.Episode{URL: "http://example.com/p1/ep1", Title: "Episode 1", Released: since.AddDate(-1, 0, 0)},
+			model.Episode{URL: "http://example.com/p1/ep2", Title: "Episode 2", Released: since.AddDate(0, -1, 0)},
+			model.Episode{URL: "http://example.com/p1/ep3", Title: "Episode 3", Released: since.Add(time.Second)},
+		)
+	})
+	assert.NoErr(t, err)
+
+	updates, err := episodesSrv.GetUpdates(ctx, &query.GetEpisodeUpdatesQuery{
+		UserName:   "user1",
+		DeviceName: "dev1",
+		Since:      since,
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(updates), 1)
+	assert.Equal(t, updates[0].URL, "http://example.com/p1/ep3")
+	assert.Equal(t, updates[0].Status, model.ActionNew)
+}
+
+func countNewEpisodes(updates []model.EpisodeUpdate) int {
+	cnt := 0
+
+	for _, u := range updates {
+		if u.Status == model.ActionNew {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
 func TestEpisodesServiceLastEpisodes(t *testing.T) {
 	ctx, i := prepareTests(t)
 	episodesSrv := do.MustInvoke[*EpisodesSrv](i)
@@ -370,6 +549,102 @@ func TestEpisodesServiceFavorites(t *testing.T) {
 	assert.Equal(t, len(favs), 2)
 	assert.Equal(t, favs[0].URL, episodeActions[1].URL)
 	assert.Equal(t, favs[1].URL, episodeActions[3].URL)
+	assert.True(t, favs[0].MygpoLink != "")
+}
+
+func TestEpisodesServiceFeedEpisodes(t *testing.T) {
+	ctx, i := prepareTests(t)
+	episodesSrv := do.MustInvoke[*EpisodesSrv](i)
+	settSrv := do.MustInvoke[*SettingsSrv](i)
+	episodesRepo := do.MustInvoke[repository.Episodes](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user1", "dev2")
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2")
+
+	episodeActions := prepareEpisodes()
+	err := episodesSrv.AddAction(ctx, &command.AddActionCmd{UserName: "user1", Actions: episodeActions})
+	assert.NoErr(t, err)
+
+	released := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	guid := "ep1-guid"
+	feedEpisodes := []model.Episode{
+		{
+			URL: "http://example.com/p1/ep1", Title: "Episode 1", GUID: &guid, Released: released,
+			Description: "Description 1", Link: "http://example.com/p1/ep1.html", ImageURL: "http://example.com/ep1.jpg",
+			EnclosureType: "audio/mpeg", EnclosureSize: 123456, Duration: 3600,
+		},
+		{URL: "http://example.com/p1/ep2", Title: "Episode 2", Released: released.Add(24 * time.Hour)},
+		{URL: "http://example.com/p1/ep3", Title: "Episode 3", Released: released.Add(48 * time.Hour)},
+	}
+
+	err = db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		return episodesRepo.UpdateEpisodeInfo(ctx, "http://example.com/p1", feedEpisodes...)
+	})
+	assert.NoErr(t, err)
+
+	// update existing items
+	feedEpisodes[0].Duration = 3700
+	err = db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		return episodesRepo.UpdateEpisodeInfo(ctx, "http://example.com/p1", feedEpisodes[0])
+	})
+	assert.NoErr(t, err)
+
+	episodes, err := episodesSrv.GetFeedEpisodes(ctx, &query.GetFeedEpisodesQuery{
+		UserName: "user1", PodcastURL: "http://example.com/p1",
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(episodes), 3)
+	assert.Equal(t, episodes[0].URL, "http://example.com/p1/ep3")
+	assert.Equal(t, episodes[2].URL, "http://example.com/p1/ep1")
+	assert.Equal(t, episodes[2].Title, "Episode 1")
+	assert.Equal(t, episodes[2].Released, released)
+	assert.Equal(t, episodes[2].Description, "Description 1")
+	assert.Equal(t, episodes[2].Link, "http://example.com/p1/ep1.html")
+	assert.Equal(t, episodes[2].ImageURL, "http://example.com/ep1.jpg")
+	assert.Equal(t, episodes[2].EnclosureType, "audio/mpeg")
+	assert.Equal(t, episodes[2].EnclosureSize, 123456)
+	assert.Equal(t, episodes[2].Duration, 3700)
+	assert.Equal(t, episodes[2].Podcast.URL, "http://example.com/p1")
+
+	episodes, err = episodesSrv.GetFeedEpisodes(ctx, &query.GetFeedEpisodesQuery{
+		UserName: "user1", PodcastURL: "http://example.com/p1", Since: released, Limit: 1,
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(episodes), 1)
+	assert.Equal(t, episodes[0].URL, "http://example.com/p1/ep3")
+
+	// metadata from feed are visible in user episodes
+	userEpisodes, err := episodesSrv.GetEpisodes(ctx, &query.GetEpisodesQuery{
+		UserName: "user1", Podcast: "http://example.com/p1", Aggregated: true,
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(userEpisodes), 2)
+
+	for _, e := range userEpisodes {
+		if e.URL == "http://example.com/p1/ep1" {
+			assert.Equal(t, e.Released, released)
+			assert.Equal(t, e.Duration, 3700)
+		}
+	}
+
+	// favorites use release date from feed
+	cmd := command.NewSetFavoriteEpisodeCmd("user1", "http://example.com/p1", "http://example.com/p1/ep1")
+	err = settSrv.SaveSettings(ctx, &cmd)
+	assert.NoErr(t, err)
+
+	favs, err := episodesSrv.GetFavorites(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.Equal(t, len(favs), 1)
+	assert.Equal(t, favs[0].Released, released)
+
+	data, err := episodesSrv.GetEpisodeData(ctx, &query.GetEpisodeDataQuery{
+		UserName: "user1", PodcastURL: "http://example.com/p1", EpisodeURL: "http://example.com/p1/ep1",
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, data.Released, released)
+	assert.Equal(t, data.Description, "Description 1")
 }
 
 func TestEpisodesServiceNewDevPodcast(t *testing.T) {
@@ -571,3 +846,32 @@ func compareEpisodes(t *testing.T, got, want model.Episode) {
 
 	assert.Equal(t, got.Device.Name, want.Device.Name)
 }
+
+func TestEpisodesServiceEpisodeData(t *testing.T) {
+	ctx, i := prepareTests(t)
+	episodesSrv := do.MustInvoke[*EpisodesSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user2", "dev1")
+	prepareTestEpisode(ctx, t, i, "user2", "dev1", "http://example.com/p1", "http://example.com/p1/ep1")
+
+	// episode known only by other user
+	data, err := episodesSrv.GetEpisodeData(ctx, &query.GetEpisodeDataQuery{
+		UserName: "user1", PodcastURL: "http://example.com/p1", EpisodeURL: "http://example.com/p1/ep1",
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, data.URL, "http://example.com/p1/ep1")
+	assert.Equal(t, data.PodcastURL, "http://example.com/p1")
+	assert.Equal(t, data.MygpoLink, "")
+
+	data, err = episodesSrv.GetEpisodeData(ctx, &query.GetEpisodeDataQuery{
+		UserName: "user2", PodcastURL: "http://example.com/p1", EpisodeURL: "http://example.com/p1/ep1",
+	})
+	assert.NoErr(t, err)
+	assert.True(t, data.MygpoLink != "")
+
+	_, err = episodesSrv.GetEpisodeData(ctx, &query.GetEpisodeDataQuery{
+		UserName: "user1", PodcastURL: "http://example.com/p1", EpisodeURL: "http://example.com/p1/ep2",
+	})
+	assert.ErrSpec(t, err, common.ErrUnknownEpisode)
+}
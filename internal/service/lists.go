@@ -0,0 +1,213 @@
+//
+// lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+type PodcastListsSrv struct {
+	dbi       repository.Database
+	usersRepo repository.Users
+	listsRepo repository.PodcastLists
+}
+
+func NewPodcastListsSrv(i do.Injector) (*PodcastListsSrv, error) {
+	return &PodcastListsSrv{
+		dbi:       do.MustInvoke[repository.Database](i),
+		usersRepo: do.MustInvoke[repository.Users](i),
+		listsRepo: do.MustInvoke[repository.PodcastLists](i),
+	}, nil
+}
+
+// GetPodcastLists return all lists created by user; podcasts are not loaded.
+func (p *PodcastListsSrv) GetPodcastLists(ctx context.Context, query *query.GetPodcastListsQuery,
+) ([]model.PodcastList, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) ([]model.PodcastList, error) {
+		user, err := p.getUser(ctx, query.UserName)
+		if err != nil {
+			return nil, err
+		}
+
+		lists, err := p.listsRepo.ListPodcastLists(ctx, user.ID)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return lists, nil
+	})
+}
+
+// GetPodcastList return one list with podcasts.
+func (p *PodcastListsSrv) GetPodcastList(ctx context.Context, query *query.GetPodcastListQuery,
+) (*model.PodcastList, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (*model.PodcastList, error) {
+		user, err := p.getUser(ctx, query.UserName)
+		if err != nil {
+			return nil, err
+		}
+
+		return p.getList(ctx, user, query.ListName)
+	})
+}
+
+// CreatePodcastList create new list. Fail when list with the same name already exists.
+func (p *PodcastListsSrv) CreatePodcastList(ctx context.Context, cmd *command.CreatePodcastListCmd,
+) (command.CreatePodcastListCmdResult, error) {
+	cmd.Sanitize()
+
+	if err := cmd.Validate(); err != nil {
+		return command.CreatePodcastListCmdResult{}, aerr.Wrapf(err, "validate command failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, p.dbi, func(ctx context.Context) (command.CreatePodcastListCmdResult, error) {
+		user, err := p.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return command.CreatePodcastListCmdResult{}, err
+		}
+
+		name := model.PodcastListName(cmd.Title)
+
+		_, err = p.listsRepo.GetPodcastList(ctx, user.ID, name)
+		if err == nil {
+			return command.CreatePodcastListCmdResult{}, common.ErrPodcastListExists
+		} else if !errors.Is(err, common.ErrNoData) {
+			return command.CreatePodcastListCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		common.TraceLazyPrintf(ctx, "CreatePodcastList: list name checked")
+
+		list := model.PodcastList{
+			User:     user,
+			Name:     name,
+			Title:    cmd.Title,
+			Podcasts: podcastsFromURLs(cmd.Podcasts),
+		}
+
+		if _, err := p.listsRepo.SavePodcastList(ctx, &list); err != nil {
+			return command.CreatePodcastListCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return command.CreatePodcastListCmdResult{Name: name}, nil
+	})
+}
+
+// UpdatePodcastList replace podcasts in existing list.
+func (p *PodcastListsSrv) UpdatePodcastList(ctx context.Context, cmd *command.UpdatePodcastListCmd) error {
+	cmd.Sanitize()
+
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate command failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, p.dbi, func(ctx context.Context) error {
+		user, err := p.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return err
+		}
+
+		list, err := p.getList(ctx, user, cmd.ListName)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Title != "" {
+			list.Title = cmd.Title
+		}
+
+		list.Podcasts = podcastsFromURLs(cmd.Podcasts)
+
+		if _, err := p.listsRepo.SavePodcastList(ctx, list); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return nil
+	})
+}
+
+func (p *PodcastListsSrv) DeletePodcastList(ctx context.Context, cmd *command.DeletePodcastListCmd) error {
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate command failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, p.dbi, func(ctx context.Context) error {
+		user, err := p.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return err
+		}
+
+		list, err := p.getList(ctx, user, cmd.ListName)
+		if err != nil {
+			return err
+		}
+
+		if err := p.listsRepo.DeletePodcastList(ctx, list.ID); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return nil
+	})
+}
+
+//------------------------------------------------------------------------------
+
+func (p *PodcastListsSrv) getUser(ctx context.Context, username string) (*model.User, error) {
+	user, err := p.usersRepo.GetUser(ctx, username)
+	if errors.Is(err, common.ErrNoData) {
+		return nil, common.ErrUnknownUser
+	} else if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return user, nil
+}
+
+func (p *PodcastListsSrv) getList(ctx context.Context, user *model.User, name string) (*model.PodcastList, error) {
+	list, err := p.listsRepo.GetPodcastList(ctx, user.ID, name)
+	if errors.Is(err, common.ErrNoData) {
+		return nil, common.ErrUnknownPodcastList
+	} else if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	list.User = user
+
+	return list, nil
+}
+
+func podcastsFromURLs(urls []string) model.Podcasts {
+	podcasts := make(model.Podcasts, len(urls))
+	for i, u := range urls {
+		podcasts[i] = model.Podcast{URL: u}
+	}
+
+	return podcasts
+}
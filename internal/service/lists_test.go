@@ -0,0 +1,83 @@
+//nolint:nilaway
+package service
+
+//
+// lists_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"testing"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+)
+
+func TestPodcastListsService(t *testing.T) {
+	ctx, i := prepareTests(t)
+	listsSrv := do.MustInvoke[*PodcastListsSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user2", "dev1")
+	prepareTestSub(ctx, t, i, "user2", "dev1", "http://example.com/p2")
+
+	cmd := command.CreatePodcastListCmd{
+		UserName: "user1",
+		Title:    "My Best Podcasts!",
+		Podcasts: []string{"http://example.com/p1", "http://example.com/p2", "http://example.com/p1", "bad url"},
+	}
+	res, err := listsSrv.CreatePodcastList(ctx, &cmd)
+	assert.NoErr(t, err)
+	assert.Equal(t, res.Name, "my-best-podcasts")
+
+	_, err = listsSrv.CreatePodcastList(ctx, &cmd)
+	assert.ErrSpec(t, err, common.ErrPodcastListExists)
+
+	lists, err := listsSrv.GetPodcastLists(ctx, &query.GetPodcastListsQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(lists), 1)
+	assert.Equal(t, lists[0].Title, "My Best Podcasts!")
+
+	lists, err = listsSrv.GetPodcastLists(ctx, &query.GetPodcastListsQuery{UserName: "user2"})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(lists), 0)
+
+	list, err := listsSrv.GetPodcastList(ctx, &query.GetPodcastListQuery{UserName: "user1", ListName: res.Name})
+	assert.NoErr(t, err)
+	assert.Equal(t, model.PodcastsToUrls(list.Podcasts), []string{"http://example.com/p1", "http://example.com/p2"})
+	// metadata are taken from podcasts of other users
+	assert.Equal(t, list.Podcasts[0].Subscribers, 0)
+	assert.Equal(t, list.Podcasts[1].Subscribers, 1)
+
+	err = listsSrv.UpdatePodcastList(ctx, &command.UpdatePodcastListCmd{
+		UserName: "user1",
+		ListName: res.Name,
+		Podcasts: []string{"http://example.com/p3", "http://example.com/p1"},
+	})
+	assert.NoErr(t, err)
+
+	list, err = listsSrv.GetPodcastList(ctx, &query.GetPodcastListQuery{UserName: "user1", ListName: res.Name})
+	assert.NoErr(t, err)
+	assert.Equal(t, list.Title, "My Best Podcasts!")
+	assert.Equal(t, model.PodcastsToUrls(list.Podcasts), []string{"http://example.com/p3", "http://example.com/p1"})
+
+	_, err = listsSrv.GetPodcastList(ctx, &query.GetPodcastListQuery{UserName: "user2", ListName: res.Name})
+	assert.ErrSpec(t, err, common.ErrUnknownPodcastList)
+
+	err = listsSrv.DeletePodcastList(ctx, &command.DeletePodcastListCmd{UserName: "user1", ListName: res.Name})
+	assert.NoErr(t, err)
+
+	_, err = listsSrv.GetPodcastList(ctx, &query.GetPodcastListQuery{UserName: "user1", ListName: res.Name})
+	assert.ErrSpec(t, err, common.ErrUnknownPodcastList)
+
+	err = listsSrv.DeletePodcastList(ctx, &command.DeletePodcastListCmd{UserName: "user1", ListName: res.Name})
+	assert.ErrSpec(t, err, common.ErrUnknownPodcastList)
+
+	_, err = listsSrv.GetPodcastLists(ctx, &query.GetPodcastListsQuery{UserName: "user3"})
+	assert.ErrSpec(t, err, common.ErrUnknownUser)
+}
@@ -0,0 +1,241 @@
+//
+// loginguard.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+const (
+	// loginUserFailuresLimit is number of failed logins for user name before lockout.
+	loginUserFailuresLimit = 5
+	// loginIPFailuresLimit is number of failed logins from one ip address before lockout.
+	loginIPFailuresLimit = 20
+	// loginLockoutBase is duration of first lockout; each next failure double it.
+	loginLockoutBase = time.Minute
+	loginLockoutMax  = time.Hour
+	// loginFailuresResetAfter is time after last failure when counter is reset.
+	loginFailuresResetAfter = 24 * time.Hour
+)
+
+//nolint:gochecknoglobals
+var (
+	metricLoginFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "auth_login_failures_total",
+		Help: "Number of failed logins.",
+	})
+	metricLoginLockouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_login_lockouts_total",
+		Help: "Number of temporary lockouts after too many failed logins.",
+	}, []string{"kind"})
+	metricLoginRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_login_rejected_total",
+		Help: "Number of logins rejected because of lockout.",
+	}, []string{"kind"})
+)
+
+// LoginGuardSrv protect authentication against brute-force attacks. Failed logins are counted per user
+// name and per client ip address; after limit login is locked with exponential backoff.
+type LoginGuardSrv struct {
+	dbi          repository.Database
+	failuresRepo repository.LoginFailures
+}
+
+func NewLoginGuardSrv(i do.Injector) (*LoginGuardSrv, error) {
+	return &LoginGuardSrv{
+		dbi:          do.MustInvoke[repository.Database](i),
+		failuresRepo: do.MustInvoke[repository.LoginFailures](i),
+	}, nil
+}
+
+// CheckLogin check is login for user from ip address allowed. Return time to wait when login is locked
+// or 0.
+func (l *LoginGuardSrv) CheckLogin(ctx context.Context, username, ip string) (time.Duration, error) {
+	ctx, end := common.NewTask(ctx, "CheckLogin")
+	defer end()
+
+	now := time.Now().UTC()
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, l.dbi, func(ctx context.Context) (time.Duration, error) {
+		var wait time.Duration
+
+		for _, s := range loginSubjects(username, ip) {
+			failure, err := l.failuresRepo.GetLoginFailure(ctx, s.kind, s.subject)
+			if errors.Is(err, common.ErrNoData) {
+				continue
+			} else if err != nil {
+				return 0, aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			if failure.IsLocked(now) {
+				metricLoginRejected.WithLabelValues(s.kind).Inc()
+
+				wait = max(wait, failure.LockedUntil.Sub(now))
+			}
+		}
+
+		return wait, nil
+	})
+}
+
+// LoginFailed register failed login for user from ip address. Lock login when there is too many failures.
+func (l *LoginGuardSrv) LoginFailed(ctx context.Context, username, ip string) error {
+	now := time.Now().UTC()
+
+	metricLoginFailures.Inc()
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, l.dbi, func(ctx context.Context) error {
+		for _, s := range loginSubjects(username, ip) {
+			failure, err := l.failuresRepo.GetLoginFailure(ctx, s.kind, s.subject)
+			if errors.Is(err, common.ErrNoData) {
+				failure = &model.LoginFailure{Kind: s.kind, Subject: s.subject}
+			} else if err != nil {
+				return aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			if now.Sub(failure.LastFailureAt) > loginFailuresResetAfter {
+				failure.Failures = 0
+				failure.LockedUntil = time.Time{}
+			}
+
+			failure.Failures++
+			failure.LastFailureAt = now
+
+			if failure.Failures >= s.limit {
+				failure.LockedUntil = now.Add(lockoutDuration(failure.Failures - s.limit))
+
+				metricLoginLockouts.WithLabelValues(s.kind).Inc()
+				zerolog.Ctx(ctx).Warn().Object("login_failure", failure).
+					Msgf("LoginGuardSrv: login locked %s=%s failures=%d until=%s",
+						s.kind, s.subject, failure.Failures, failure.LockedUntil)
+			}
+
+			if err := l.failuresRepo.SaveLoginFailure(ctx, failure); err != nil {
+				return aerr.ApplyFor(ErrRepositoryError, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// LoginSucceeded reset failed logins counter for user. Failures for ip address are kept, so one valid
+// account can't be used to reset them.
+func (l *LoginGuardSrv) LoginSucceeded(ctx context.Context, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, l.dbi, func(ctx context.Context) error {
+		if err := l.failuresRepo.DeleteLoginFailures(ctx, model.LoginFailureUser, username); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return nil
+	})
+}
+
+// PruneLoginFailures delete failures older than reset time that don't lock login. Return number of
+// deleted failures.
+func (l *LoginGuardSrv) PruneLoginFailures(ctx context.Context) (int64, error) {
+	before := time.Now().UTC().Add(-loginFailuresResetAfter)
+
+	deleted, err := db.InTransactionR(ctx, l.dbi, func(ctx context.Context) (int64, error) {
+		return l.failuresRepo.DeleteExpiredLoginFailures(ctx, before)
+	})
+	if err != nil {
+		return 0, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	zerolog.Ctx(ctx).Info().Msgf("LoginGuardSrv: pruned login failures before=%s deleted=%d", before, deleted)
+
+	return deleted, nil
+}
+
+// ListLoginFailures return all registered login failures, also locked.
+func (l *LoginGuardSrv) ListLoginFailures(ctx context.Context) ([]model.LoginFailure, error) {
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, l.dbi, func(ctx context.Context) ([]model.LoginFailure, error) {
+		failures, err := l.failuresRepo.ListLoginFailures(ctx)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return failures, nil
+	})
+}
+
+// ClearLoginFailures remove failures and lockouts for `kind` (user or ip) and `subject`. Empty subject
+// clear all failures for kind; empty kind clear everything.
+func (l *LoginGuardSrv) ClearLoginFailures(ctx context.Context, kind, subject string) error {
+	switch kind {
+	case "", model.LoginFailureUser, model.LoginFailureIP:
+	default:
+		return aerr.ErrValidation.WithUserMsg("invalid kind %q; expected user or ip", kind)
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, l.dbi, func(ctx context.Context) error {
+		if err := l.failuresRepo.DeleteLoginFailures(ctx, kind, subject); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Msgf("LoginGuardSrv: login failures cleared kind=%s subject=%s", kind, subject)
+
+		return nil
+	})
+}
+
+//------------------------------------------------------------------------------
+
+type loginSubject struct {
+	kind    string
+	subject string
+	limit   int
+}
+
+func loginSubjects(username, ip string) []loginSubject {
+	subjects := make([]loginSubject, 0, 2) //nolint:mnd
+
+	if username != "" {
+		subjects = append(subjects, loginSubject{model.LoginFailureUser, username, loginUserFailuresLimit})
+	}
+
+	if ip != "" {
+		subjects = append(subjects, loginSubject{model.LoginFailureIP, ip, loginIPFailuresLimit})
+	}
+
+	return subjects
+}
+
+// lockoutDuration calculate lockout time for n-th failure over limit.
+func lockoutDuration(n int) time.Duration {
+	d := loginLockoutBase
+	for range n {
+		if d *= 2; d >= loginLockoutMax {
+			return loginLockoutMax
+		}
+	}
+
+	return d
+}
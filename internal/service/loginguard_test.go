@@ -0,0 +1,171 @@
+//nolint:nilaway
+package service
+
+//
+// loginguard_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+func TestLoginGuardUserLockout(t *testing.T) {
+	ctx, i := prepareTests(t)
+	guardSrv := do.MustInvoke[*LoginGuardSrv](i)
+
+	for range loginUserFailuresLimit - 1 {
+		assert.NoErr(t, guardSrv.LoginFailed(ctx, "user1", "10.0.0.1"))
+	}
+
+	wait, err := guardSrv.CheckLogin(ctx, "user1", "10.0.0.1")
+	assert.NoErr(t, err)
+	assert.Equal(t, wait, 0)
+
+	assert.NoErr(t, guardSrv.LoginFailed(ctx, "user1", "10.0.0.1"))
+
+	wait, err = guardSrv.CheckLogin(ctx, "user1", "10.0.0.2")
+	assert.NoErr(t, err)
+	assert.True(t, wait > 0 && wait <= loginLockoutBase)
+
+	// other users are not affected
+	wait, err = guardSrv.CheckLogin(ctx, "user2", "10.0.0.1")
+	assert.NoErr(t, err)
+	assert.Equal(t, wait, 0)
+
+	failures, err := guardSrv.ListLoginFailures(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(failures), 2)
+	assert.Equal(t, failures[0].Kind, model.LoginFailureIP)
+	assert.Equal(t, failures[0].Failures, loginUserFailuresLimit)
+	assert.Equal(t, failures[1].Kind, model.LoginFailureUser)
+	assert.Equal(t, failures[1].Subject, "user1")
+
+	assert.NoErr(t, guardSrv.ClearLoginFailures(ctx, model.LoginFailureUser, "user1"))
+
+	wait, err = guardSrv.CheckLogin(ctx, "user1", "10.0.0.1")
+	assert.NoErr(t, err)
+	assert.Equal(t, wait, 0)
+
+	failures, err = guardSrv.ListLoginFailures(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(failures), 1)
+}
+
+func TestLoginGuardIPLockout(t *testing.T) {
+	ctx, i := prepareTests(t)
+	guardSrv := do.MustInvoke[*LoginGuardSrv](i)
+
+	for range loginIPFailuresLimit {
+		assert.NoErr(t, guardSrv.LoginFailed(ctx, "", "10.0.0.1"))
+	}
+
+	wait, err := guardSrv.CheckLogin(ctx, "user1", "10.0.0.1")
+	assert.NoErr(t, err)
+	assert.True(t, wait > 0)
+
+	// successful login don't reset ip failures
+	assert.NoErr(t, guardSrv.LoginSucceeded(ctx, "user1"))
+
+	wait, err = guardSrv.CheckLogin(ctx, "user2", "10.0.0.1")
+	assert.NoErr(t, err)
+	assert.True(t, wait > 0)
+
+	assert.NoErr(t, guardSrv.ClearLoginFailures(ctx, "", ""))
+
+	wait, err = guardSrv.CheckLogin(ctx, "user2", "10.0.0.1")
+	assert.NoErr(t, err)
+	assert.Equal(t, wait, 0)
+
+	assert.Err(t, guardSrv.ClearLoginFailures(ctx, "invalid", ""))
+}
+
+func TestLoginGuardSuccessResetUser(t *testing.T) {
+	ctx, i := prepareTests(t)
+	guardSrv := do.MustInvoke[*LoginGuardSrv](i)
+
+	for range loginUserFailuresLimit - 1 {
+		assert.NoErr(t, guardSrv.LoginFailed(ctx, "user1", ""))
+	}
+
+	assert.NoErr(t, guardSrv.LoginSucceeded(ctx, "user1"))
+	assert.NoErr(t, guardSrv.LoginFailed(ctx, "user1", ""))
+
+	wait, err := guardSrv.CheckLogin(ctx, "user1", "")
+	assert.NoErr(t, err)
+	assert.Equal(t, wait, 0)
+}
+
+func TestLoginGuardPruneFailures(t *testing.T) {
+	ctx, i := prepareTests(t)
+	guardSrv := do.MustInvoke[*LoginGuardSrv](i)
+	maintSrv := do.MustInvoke[*MaintenanceSrv](i)
+	rdb := do.MustInvoke[repository.Database](i)
+	failuresRepo := do.MustInvoke[repository.LoginFailures](i)
+
+	now := time.Now().UTC()
+	old := now.Add(-loginFailuresResetAfter - time.Hour)
+
+	err := db.InTransaction(ctx, rdb, func(ctx context.Context) error {
+		for _, f := range []model.LoginFailure{
+			{Kind: model.LoginFailureUser, Subject: "expired", Failures: 2, LastFailureAt: old},
+			{Kind: model.LoginFailureIP, Subject: "10.0.0.1", Failures: 30, LastFailureAt: old,
+				LockedUntil: now.Add(-time.Minute)},
+			// still locked
+			{Kind: model.LoginFailureIP, Subject: "10.0.0.2", Failures: 30, LastFailureAt: old,
+				LockedUntil: now.Add(time.Hour)},
+			{Kind: model.LoginFailureUser, Subject: "recent", Failures: 2, LastFailureAt: now.Add(-time.Hour)},
+		} {
+			if err := failuresRepo.SaveLoginFailure(ctx, &f); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	assert.NoErr(t, err)
+
+	deleted, err := guardSrv.PruneLoginFailures(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, deleted, 2)
+
+	failures, err := guardSrv.ListLoginFailures(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(failures), 2)
+	assert.Equal(t, failures[0].Subject, "10.0.0.2")
+	assert.Equal(t, failures[1].Subject, "recent")
+
+	// database maintenance prune failures too
+	assert.NoErr(t, guardSrv.LoginFailed(ctx, "", "10.0.0.3"))
+
+	err = db.InTransaction(ctx, rdb, func(ctx context.Context) error {
+		return failuresRepo.SaveLoginFailure(ctx, &model.LoginFailure{
+			Kind: model.LoginFailureUser, Subject: "recent", Failures: 2, LastFailureAt: old,
+		})
+	})
+	assert.NoErr(t, err)
+
+	assert.NoErr(t, maintSrv.MaintainDatabase(ctx))
+
+	failures, err = guardSrv.ListLoginFailures(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(failures), 2)
+	assert.Equal(t, failures[0].Subject, "10.0.0.2")
+	assert.Equal(t, failures[1].Subject, "10.0.0.3")
+}
+
+func TestLockoutDuration(t *testing.T) {
+	assert.Equal(t, lockoutDuration(0), loginLockoutBase)
+	assert.Equal(t, lockoutDuration(1), 2*loginLockoutBase)
+	assert.Equal(t, lockoutDuration(3), 8*loginLockoutBase)
+	assert.Equal(t, lockoutDuration(100), loginLockoutMax)
+}
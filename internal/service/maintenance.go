@@ -27,6 +27,8 @@ type MaintenanceSrv struct {
 	podcastsRepo repository.Podcasts
 	episodesRepo repository.Episodes
 	settingsRepo repository.Settings
+	auditSrv     *AuditSrv
+	guardSrv     *LoginGuardSrv
 }
 
 func NewMaintenanceSrv(i do.Injector) (*MaintenanceSrv, error) {
@@ -38,17 +40,25 @@ func NewMaintenanceSrv(i do.Injector) (*MaintenanceSrv, error) {
 		podcastsRepo: do.MustInvoke[repository.Podcasts](i),
 		episodesRepo: do.MustInvoke[repository.Episodes](i),
 		settingsRepo: do.MustInvoke[repository.Settings](i),
+		auditSrv:     do.MustInvoke[*AuditSrv](i),
+		guardSrv:     do.MustInvoke[*LoginGuardSrv](i),
 	}, nil
 }
 
+// MaintainDatabase run database maintenance scripts and prune expired login failures. Scripts are
+// run outside transaction because VACUUM is not allowed in transaction.
 func (m *MaintenanceSrv) MaintainDatabase(ctx context.Context) error {
-	_, err := db.InTransactionR(ctx, m.dbi, func(ctx context.Context) (any, error) {
+	_, err := db.InConnectionR(ctx, m.dbi, func(ctx context.Context) (any, error) {
 		return nil, m.maintRepo.Maintenance(ctx)
 	})
 	if err != nil {
 		return aerr.ApplyFor(ErrRepositoryError, err)
 	}
 
+	if _, err := m.guardSrv.PruneLoginFailures(ctx); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -97,6 +107,10 @@ func (m *MaintenanceSrv) ExportAll(ctx context.Context) ([]model.ExportStruct, e
 		return nil, aerr.ApplyFor(ErrRepositoryError, err)
 	}
 
+	for _, r := range res {
+		m.auditSrv.Record(ctx, model.AuditDataExport, r.User.UserName, "")
+	}
+
 	return res, nil
 }
 
@@ -149,6 +163,10 @@ func (m *MaintenanceSrv) ImportAll(ctx context.Context, data []model.ExportStruc
 		return aerr.ApplyFor(ErrRepositoryError, err)
 	}
 
+	for _, record := range data {
+		m.auditSrv.Record(ctx, model.AuditDataImport, record.User.UserName, "")
+	}
+
 	return nil
 }
 
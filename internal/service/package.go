@@ -9,11 +9,19 @@ import "github.com/samber/do/v2"
 
 //nolint:gochecknoglobals
 var Package = do.Package(
+	do.Lazy(NewAuditSrv),
 	do.Lazy(NewUsersSrv),
 	do.Lazy(NewDevicesSrv),
 	do.Lazy(NewEpisodesSrv),
 	do.Lazy(NewPodcastsSrv),
 	do.Lazy(NewSettingsSrv),
 	do.Lazy(NewSubscriptionsSrv),
+	do.Lazy(NewPodcastListsSrv),
+	do.Lazy(NewAppTokensSrv),
+	do.Lazy(NewRememberTokensSrv),
+	do.Lazy(NewTOTPSrv),
+	do.Lazy(NewLoginGuardSrv),
+	do.Lazy(NewRegistrationSrv),
+	do.Lazy(NewPasswordResetSrv),
 	do.Lazy(NewMaintenanceSrv),
 )
@@ -0,0 +1,186 @@
+//
+// passwordreset.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+// PasswordResetTokenTTL is time when password reset token is valid.
+const PasswordResetTokenTTL = time.Hour
+
+// PasswordResetSrv create and verify time-limited tokens for resetting forgotten password. Token is
+// signed by current password hash of user, so it can be used only once - until password is changed.
+type PasswordResetSrv struct {
+	dbi          repository.Database
+	usersRepo    repository.Users
+	sessionsRepo repository.Sessions
+	tokensRepo   repository.RememberTokens
+	auditSrv     *AuditSrv
+	passHasher   PasswordHasher
+}
+
+func NewPasswordResetSrv(i do.Injector) (*PasswordResetSrv, error) {
+	return &PasswordResetSrv{
+		dbi:          do.MustInvoke[repository.Database](i),
+		usersRepo:    do.MustInvoke[repository.Users](i),
+		sessionsRepo: do.MustInvoke[repository.Sessions](i),
+		tokensRepo:   do.MustInvoke[repository.RememberTokens](i),
+		auditSrv:     do.MustInvoke[*AuditSrv](i),
+		passHasher:   BCryptPasswordHasher{},
+	}, nil
+}
+
+// CreateResetToken generate password reset token for user. Locked accounts and accounts without email
+// are rejected. Return user (with email) and token.
+func (p *PasswordResetSrv) CreateResetToken(ctx context.Context, username string) (*model.User, string, error) {
+	if username == "" {
+		return nil, "", common.ErrEmptyUsername
+	}
+
+	user, err := db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (*model.User, error) {
+		return p.usersRepo.GetUser(ctx, username)
+	})
+
+	switch {
+	case errors.Is(err, common.ErrNoData):
+		return nil, "", common.ErrUnknownUser
+	case err != nil:
+		return nil, "", aerr.ApplyFor(ErrRepositoryError, err)
+	case user.Locked:
+		return nil, "", common.ErrUserAccountLocked
+	case user.Email == "":
+		return nil, "", common.ErrUserNoEmail
+	}
+
+	expires := time.Now().Add(PasswordResetTokenTTL).Unix()
+	token := base64.RawURLEncoding.EncodeToString([]byte(user.UserName)) + "." +
+		strconv.FormatInt(expires, 36) + "." + signResetToken(user, expires) //nolint:mnd
+
+	zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, username).
+		Msgf("PasswordResetSrv: reset token created user_name=%s", username)
+
+	return user, token, nil
+}
+
+// CheckResetToken verify is token valid; return name of user that token was created for.
+func (p *PasswordResetSrv) CheckResetToken(ctx context.Context, token string) (string, error) {
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (string, error) {
+		user, err := p.getUserByToken(ctx, token)
+		if err != nil {
+			return "", err
+		}
+
+		return user.UserName, nil
+	})
+}
+
+// ResetPassword set new password for user identified by token. All web sessions and "remember me" tokens
+// of user are revoked.
+func (p *PasswordResetSrv) ResetPassword(ctx context.Context, cmd *command.ResetPasswordCmd) error {
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate cmd failed")
+	}
+
+	username, err := db.InTransactionR(ctx, p.dbi, func(ctx context.Context) (string, error) {
+		user, err := p.getUserByToken(ctx, cmd.Token)
+		if err != nil {
+			return "", err
+		}
+
+		user.Password, err = p.passHasher.HashPassword(cmd.Password)
+		if err != nil {
+			return "", aerr.Wrapf(err, "hash password failed")
+		}
+
+		if _, err = p.usersRepo.SaveUser(ctx, user); err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if err := p.tokensRepo.DeleteUserRememberTokens(ctx, user.ID); err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		sessions, err := p.sessionsRepo.DeleteUserSessions(ctx, user.UserName)
+		if err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, user.UserName).
+			Msgf("PasswordResetSrv: password reset user_name=%s revoked_sessions=%d", user.UserName, sessions)
+
+		return user.UserName, nil
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	p.auditSrv.Record(ctx, model.AuditPasswordChange, username, "reset by email")
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// getUserByToken parse and verify token; return user that token was created for.
+func (p *PasswordResetSrv) getUserByToken(ctx context.Context, token string) (*model.User, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:mnd
+		return nil, common.ErrInvalidResetToken
+	}
+
+	username, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, common.ErrInvalidResetToken
+	}
+
+	expires, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return nil, common.ErrInvalidResetToken
+	}
+
+	user, err := p.usersRepo.GetUser(ctx, string(username))
+	if errors.Is(err, common.ErrNoData) {
+		return nil, common.ErrInvalidResetToken
+	} else if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	if user.Locked || !hmac.Equal([]byte(signResetToken(user, expires)), []byte(parts[2])) {
+		return nil, common.ErrInvalidResetToken
+	}
+
+	return user, nil
+}
+
+// signResetToken create signature for user and expiration time. Current password hash is used as
+// key, so signature change after each password change.
+func signResetToken(user *model.User, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(user.Password))
+	fmt.Fprintf(mac, "%d:%s:%d", user.ID, user.UserName, expires)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,145 @@
+//nolint:nilaway
+package service
+
+//
+// passwordreset_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+func TestPasswordReset(t *testing.T) {
+	ctx, i := prepareTests(t)
+	resetSrv := do.MustInvoke[*PasswordResetSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+
+	_, _, err := resetSrv.CreateResetToken(ctx, "unknown")
+	assert.ErrSpec(t, err, common.ErrUnknownUser)
+
+	user, token, err := resetSrv.CreateResetToken(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.Equal(t, user.Email, "user1@example.com")
+
+	username, err := resetSrv.CheckResetToken(ctx, token)
+	assert.NoErr(t, err)
+	assert.Equal(t, username, "user1")
+
+	// modified token
+	_, err = resetSrv.CheckResetToken(ctx, token+"x")
+	assert.ErrSpec(t, err, common.ErrInvalidResetToken)
+
+	err = resetSrv.ResetPassword(ctx, &command.ResetPasswordCmd{Token: token, Password: "newpass"})
+	assert.NoErr(t, err)
+
+	_, err = usersSrv.LoginUser(ctx, "user1", "newpass")
+	assert.NoErr(t, err)
+
+	// token is valid only until password change
+	err = resetSrv.ResetPassword(ctx, &command.ResetPasswordCmd{Token: token, Password: "other"})
+	assert.ErrSpec(t, err, common.ErrInvalidResetToken)
+}
+
+func TestPasswordResetRevokeLogins(t *testing.T) {
+	ctx, i := prepareTests(t)
+	resetSrv := do.MustInvoke[*PasswordResetSrv](i)
+	rememberSrv := do.MustInvoke[*RememberTokensSrv](i)
+	sessionsRepo := do.MustInvoke[repository.Sessions](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+
+	remember1, err := rememberSrv.CreateRememberToken(ctx, "user1")
+	assert.NoErr(t, err)
+	remember2, err := rememberSrv.CreateRememberToken(ctx, "user2")
+	assert.NoErr(t, err)
+
+	sessions := map[string]string{"sid1": "user1", "sid2": "user2"}
+
+	err = db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		for sid, user := range sessions {
+			if _, err := sessionsRepo.ReadOrCreate(ctx, sid, time.Hour); err != nil {
+				return err
+			}
+
+			if err := sessionsRepo.SaveSession(ctx, sid, map[any]any{"user": user}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	assert.NoErr(t, err)
+
+	_, token, err := resetSrv.CreateResetToken(ctx, "user1")
+	assert.NoErr(t, err)
+
+	err = resetSrv.ResetPassword(ctx, &command.ResetPasswordCmd{Token: token, Password: "newpass"})
+	assert.NoErr(t, err)
+
+	// logins of user1 are revoked
+	_, err = rememberSrv.LoginWithRememberToken(ctx, remember1)
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+
+	_, err = rememberSrv.LoginWithRememberToken(ctx, remember2)
+	assert.NoErr(t, err)
+
+	for sid, want := range map[string]bool{"sid1": false, "sid2": true} {
+		exists, err := db.InConnectionR(ctx, dbi, func(ctx context.Context) (bool, error) {
+			return sessionsRepo.SessionExists(ctx, sid)
+		})
+		assert.NoErr(t, err)
+		assert.Equal(t, exists, want)
+	}
+}
+
+func TestPasswordResetExpired(t *testing.T) {
+	ctx, i := prepareTests(t)
+	resetSrv := do.MustInvoke[*PasswordResetSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+
+	user, err := usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+
+	expires := time.Now().Add(-time.Minute).Unix()
+	token := base64.RawURLEncoding.EncodeToString([]byte("user1")) + "." + strconv.FormatInt(expires, 36) + "." +
+		signResetToken(user, expires)
+
+	_, err = resetSrv.CheckResetToken(ctx, token)
+	assert.ErrSpec(t, err, common.ErrInvalidResetToken)
+}
+
+func TestPasswordResetLocked(t *testing.T) {
+	ctx, i := prepareTests(t)
+	resetSrv := do.MustInvoke[*PasswordResetSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+
+	_, token, err := resetSrv.CreateResetToken(ctx, "user1")
+	assert.NoErr(t, err)
+
+	err = usersSrv.LockAccount(ctx, command.LockAccountCmd{UserName: "user1"})
+	assert.NoErr(t, err)
+
+	// locked account can't be unlocked by reset
+	_, _, err = resetSrv.CreateResetToken(ctx, "user1")
+	assert.ErrSpec(t, err, common.ErrUserAccountLocked)
+
+	err = resetSrv.ResetPassword(ctx, &command.ResetPasswordCmd{Token: token, Password: "newpass"})
+	assert.ErrSpec(t, err, common.ErrInvalidResetToken)
+}
@@ -10,11 +10,17 @@ package service
 import (
 	"context"
 	"errors"
+	"math"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"github.com/mmcdole/gofeed/rss"
 	"github.com/rs/xid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
@@ -23,6 +29,7 @@ import (
 	"gitlab.com/kabes/go-gpo/internal/common"
 	"gitlab.com/kabes/go-gpo/internal/db"
 	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
 	"gitlab.com/kabes/go-gpo/internal/repository"
 	"gitlab.com/kabes/go-gpo/internal/validators"
 )
@@ -32,6 +39,7 @@ type PodcastsSrv struct {
 	usersRepo    repository.Users
 	podcastsRepo repository.Podcasts
 	episodesRepo repository.Episodes
+	auditSrv     *AuditSrv
 }
 
 func NewPodcastsSrv(i do.Injector) (*PodcastsSrv, error) {
@@ -40,6 +48,7 @@ func NewPodcastsSrv(i do.Injector) (*PodcastsSrv, error) {
 		usersRepo:    do.MustInvoke[repository.Users](i),
 		podcastsRepo: do.MustInvoke[repository.Podcasts](i),
 		episodesRepo: do.MustInvoke[repository.Episodes](i),
+		auditSrv:     do.MustInvoke[*AuditSrv](i),
 	}, nil
 }
 
@@ -120,6 +129,13 @@ func (p *PodcastsSrv) GetPodcastsWithLastEpisode(ctx context.Context, username s
 
 		common.TraceLazyPrintf(ctx, "GetPodcastsWithLastEpisode: podcasts loaded")
 
+		tags, err := p.podcastsRepo.ListPodcastsTags(ctx, user.ID)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		common.TraceLazyPrintf(ctx, "GetPodcastsWithLastEpisode: tags loaded")
+
 		podcasts := make([]model.PodcastWithLastEpisode, len(subs))
 		for idx, s := range subs {
 			podcasts[idx] = model.PodcastWithLastEpisode{
@@ -129,6 +145,7 @@ func (p *PodcastsSrv) GetPodcastsWithLastEpisode(ctx context.Context, username s
 				Website:     s.Website,
 				Description: s.Description,
 				Subscribed:  s.Subscribed,
+				Tags:        tags[s.URL],
 			}
 
 			lastEpisode, err := p.episodesRepo.GetLastEpisodeAction(ctx, user.ID, s.ID, false)
@@ -149,30 +166,285 @@ func (p *PodcastsSrv) GetPodcastsWithLastEpisode(ctx context.Context, username s
 
 //------------------------------------------------------------------------------
 
+// SearchPodcasts find podcasts known to instance (subscribed by any user) by title, description or url.
+func (p *PodcastsSrv) SearchPodcasts(ctx context.Context, query *query.SearchPodcastsQuery,
+) (model.Podcasts, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (model.Podcasts, error) {
+		podcasts, err := p.podcastsRepo.SearchPodcasts(ctx, query.Text, query.Limit)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return podcasts, nil
+	})
+}
+
+// GetToplist return podcasts with the biggest number of subscribers on this instance.
+func (p *PodcastsSrv) GetToplist(ctx context.Context, query *query.GetToplistQuery) (model.Podcasts, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (model.Podcasts, error) {
+		podcasts, err := p.podcastsRepo.ListTopPodcasts(ctx, query.Limit)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return podcasts, nil
+	})
+}
+
+// GetSuggestions return podcasts that are subscribed together with user's podcasts by other users.
+func (p *PodcastsSrv) GetSuggestions(ctx context.Context, query *query.GetSuggestionsQuery,
+) (model.Podcasts, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (model.Podcasts, error) {
+		user, err := p.usersRepo.GetUser(ctx, query.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownUser
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		podcasts, err := p.podcastsRepo.ListSuggestedPodcasts(ctx, user.ID, query.Limit)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return podcasts, nil
+	})
+}
+
+// GetTags return most used tags of podcasts subscribed by users.
+func (p *PodcastsSrv) GetTags(ctx context.Context, query *query.GetTagsQuery) ([]model.PodcastTag, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) ([]model.PodcastTag, error) {
+		tags, err := p.podcastsRepo.ListTags(ctx, query.Limit)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return tags, nil
+	})
+}
+
+// GetTagPodcasts return the most popular podcasts with given tag.
+func (p *PodcastsSrv) GetTagPodcasts(ctx context.Context, query *query.GetTagPodcastsQuery,
+) (model.Podcasts, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (model.Podcasts, error) {
+		podcasts, err := p.podcastsRepo.ListPodcastsByTag(ctx, query.Tag, query.Limit)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return podcasts, nil
+	})
+}
+
+// GetPodcastData return podcast metadata collected from all users. MygpoLink point to user podcast page
+// when user has this podcast.
+func (p *PodcastsSrv) GetPodcastData(ctx context.Context, query *query.GetPodcastDataQuery,
+) (*model.Podcast, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (*model.Podcast, error) {
+		user, err := p.usersRepo.GetUser(ctx, query.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownUser
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		podcast, err := p.podcastsRepo.GetCatalogPodcast(ctx, query.PodcastURL)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownPodcast
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		own, err := p.podcastsRepo.GetPodcast(ctx, user.ID, query.PodcastURL)
+		if err == nil {
+			podcast.MygpoLink = own.WebPath()
+		} else if !errors.Is(err, common.ErrNoData) {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return podcast, nil
+	})
+}
+
 func (p *PodcastsSrv) DeletePodcast(ctx context.Context, username string, podcastid int64) error {
 	logger := zerolog.Ctx(ctx)
 	logger.Debug().Int64("podcast_id", podcastid).
 		Msgf("PodcastsSrv: delete podcast user_name=%s podcast_id=%d", username, podcastid)
 
+	url, err := db.InTransactionR(ctx, p.dbi, func(ctx context.Context) (string, error) {
+		user, err := p.usersRepo.GetUser(ctx, username)
+		if errors.Is(err, common.ErrNoData) {
+			return "", common.ErrUnknownUser
+		} else if err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		podcast, err := p.podcastsRepo.GetPodcastByID(ctx, user.ID, podcastid)
+		if errors.Is(err, common.ErrNoData) {
+			return "", common.ErrUnknownPodcast
+		} else if err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if err := p.podcastsRepo.DeletePodcast(ctx, podcast.ID); err != nil {
+			return "", err
+		}
+
+		return podcast.URL, nil
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	p.auditSrv.Record(ctx, model.AuditPodcastDelete, username, "podcast="+url)
+
+	return nil
+}
+
+// GetFeedState return state of fetching feed `podcasturl`.
+func (p *PodcastsSrv) GetFeedState(ctx context.Context, podcasturl string) (*model.FeedState, error) {
+	if podcasturl == "" {
+		return nil, common.ErrInvalidPodcast
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) (*model.FeedState, error) {
+		state, err := p.podcastsRepo.GetFeedState(ctx, podcasturl)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnknownPodcast
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return state, nil
+	})
+}
+
+// GetFeedsHealth return state of fetching feeds of subscribed podcasts; optionally only failing and dead.
+func (p *PodcastsSrv) GetFeedsHealth(ctx context.Context, failingOnly bool) ([]model.FeedState, error) {
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, p.dbi, func(ctx context.Context) ([]model.FeedState, error) {
+		states, err := p.podcastsRepo.ListFeedStates(ctx, failingOnly)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return states, nil
+	})
+}
+
+// ResetFeedState clear failures and dead flag of feed `podcasturl`; feed will be fetched in next run.
+func (p *PodcastsSrv) ResetFeedState(ctx context.Context, podcasturl string) error {
+	if podcasturl == "" {
+		return common.ErrInvalidPodcast
+	}
+
 	//nolint:wrapcheck
 	return db.InTransaction(ctx, p.dbi, func(ctx context.Context) error {
-		user, err := p.usersRepo.GetUser(ctx, username)
+		state, err := p.podcastsRepo.GetFeedState(ctx, podcasturl)
 		if errors.Is(err, common.ErrNoData) {
-			return common.ErrUnknownUser
+			return common.ErrUnknownPodcast
 		} else if err != nil {
 			return aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
-		podcast, err := p.podcastsRepo.GetPodcastByID(ctx, user.ID, podcastid)
+		state.Failures = 0
+		state.Dead = false
+		state.NextRetryAt = time.Time{}
+		state.NextCheckAt = time.Time{}
+
+		if err := p.podcastsRepo.SaveFeedState(ctx, state); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Msgf("PodcastsSrv: feed state reset podcast_url=%q", podcasturl)
+
+		return nil
+	})
+}
+
+// GetFeedsSchedule return schedule of checking feeds of subscribed podcasts ordered by time of next
+// check. Dead feeds are skipped.
+func (p *PodcastsSrv) GetFeedsSchedule(ctx context.Context) ([]model.FeedState, error) {
+	states, err := p.GetFeedsHealth(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	states = slices.DeleteFunc(states, func(s model.FeedState) bool { return s.Dead })
+	slices.SortStableFunc(states, func(a, b model.FeedState) int { return a.NextCheckAt.Compare(b.NextCheckAt) })
+
+	return states, nil
+}
+
+// feedMinIntervalOverride is minimal interval of checking feed that can be set manually.
+const feedMinIntervalOverride = 15 * time.Minute
+
+// SetFeedCheckInterval override computed interval of checking feed `podcasturl`. Zero `interval`
+// restore computed interval.
+func (p *PodcastsSrv) SetFeedCheckInterval(ctx context.Context, podcasturl string, interval time.Duration) error {
+	if podcasturl == "" {
+		return common.ErrInvalidPodcast
+	}
+
+	if interval < 0 || (interval > 0 && interval < feedMinIntervalOverride) {
+		return aerr.ErrValidation.WithUserMsg("check interval must be at least %s", feedMinIntervalOverride)
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, p.dbi, func(ctx context.Context) error {
+		state, err := p.podcastsRepo.GetFeedState(ctx, podcasturl)
 		if errors.Is(err, common.ErrNoData) {
 			return common.ErrUnknownPodcast
 		} else if err != nil {
 			return aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
-		err = p.podcastsRepo.DeletePodcast(ctx, podcast.ID)
+		state.IntervalOverride = interval
 
-		return err
+		// next check is moved according to new interval
+		if i, _ := state.Interval(); i > 0 && !state.LastFetchAt.IsZero() {
+			state.NextCheckAt = state.LastFetchAt.Add(i)
+		}
+
+		if err := p.podcastsRepo.SaveFeedState(ctx, state); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Msgf("PodcastsSrv: feed check interval set podcast_url=%q interval=%s",
+			podcasturl, interval)
+
+		return nil
 	})
 }
 
@@ -218,13 +490,17 @@ func (p *PodcastsSrv) ResolvePodcastsURL(ctx context.Context, urls []string) map
 
 //------------------------------------------------------------------------------
 
-func (p *PodcastsSrv) DownloadPodcastsInfo(ctx context.Context, since time.Time, loadepisodes, missingonly bool) error {
+// DownloadPodcastsInfo fetch feeds of subscribed podcasts which metadata are older than `since` and update
+// podcasts metadata. When `scheduled` is set, only feeds which time of next check passed are fetched.
+func (p *PodcastsSrv) DownloadPodcastsInfo(ctx context.Context, since time.Time,
+	loadepisodes, missingonly, scheduled bool,
+) error {
 	logger := zerolog.Ctx(ctx)
 	logger.Debug().Msgf("PodcastsSrv: start downloading podcasts info; since=%s", since)
 
 	// get podcasts to update
 	urls, err := db.InConnectionR(ctx, p.dbi, func(ctx context.Context) ([]model.PodcastToUpdate, error) {
-		return p.podcastsRepo.ListPodcastsToUpdate(ctx, since)
+		return p.podcastsRepo.ListPodcastsToUpdate(ctx, since, scheduled)
 	})
 	if err != nil {
 		return aerr.ApplyFor(ErrRepositoryError, err)
@@ -287,6 +563,7 @@ func (p *PodcastsSrv) downloadPodcastInfoWorker(
 	}
 
 	fp := gofeed.NewParser()
+	fp.RSSTranslator = &rssTranslator{}
 	fp.UserAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:146.0) Gecko/20100101 Firefox/146.0"
 
 	for task := range tasks {
@@ -318,49 +595,84 @@ func (p *PodcastsSrv) downloadPodcastInfo(ctx context.Context, //nolint: cyclop
 	logger.Debug().Msgf("PodcastsSrv: downloading podcast_url=%q", task.URL)
 
 	var (
-		update   model.PodcastMetaUpdate
+		update   *model.PodcastMetaUpdate
 		episodes []model.Episode
 	)
 
-	feed, status, err := parseFeedURLWithContext(ctx, feedparser, task)
-	eventlog.Printf("download url=%q got status=%d error=%q", task.URL, status, err)
+	res, err := fetchFeed(ctx, feedparser, task)
+	eventlog.Printf("download url=%q got status=%d error=%q", task.URL, res.status, err)
+
+	state := feedStateAfterFetch(task, &res, err, time.Now().UTC())
+
+	// feed moved; data are saved under new url
+	podcasturl := task.URL
+	if err == nil && res.newURL != "" && res.newURL != task.URL {
+		podcasturl = res.newURL
+		state.URL = podcasturl
+
+		eventlog.Printf("feed url=%q moved to url=%q", task.URL, podcasturl)
+		logger.Info().Msgf("PodcastsSrv: podcast_url=%q moved to new_url=%q", task.URL, podcasturl)
+	}
 
 	switch {
 	case err != nil:
+		if state.Dead {
+			eventlog.Errorf("feed url=%q marked as dead after failures=%d", task.URL, state.Failures)
+			logger.Warn().Msgf("PodcastsSrv: podcast_url=%q marked as dead; failures=%d", task.URL, state.Failures)
+		}
+
+		if serr := db.InTransaction(ctx, p.dbi, func(ctx context.Context) error {
+			return p.podcastsRepo.SaveFeedState(ctx, &state)
+		}); serr != nil {
+			logger.Error().Err(serr).Msgf("PodcastsSrv: save feed state podcast_url=%q error=%q", task.URL, serr)
+		}
+
 		return err
-	case status == http.StatusNotModified:
-		logger.Debug().Err(err).Msgf("PodcastsSrv: podcast_url=%q not modified", task.URL)
+	case res.status == http.StatusNotModified:
+		logger.Debug().Msgf("PodcastsSrv: podcast_url=%q not modified", task.URL)
 
-		update = model.PodcastMetaUpdate{URL: task.URL, MetaUpdatedAt: time.Now().UTC(), NotModified: true}
-	case feed != nil:
+		update = &model.PodcastMetaUpdate{URL: podcasturl, MetaUpdatedAt: time.Now().UTC(), NotModified: true}
+	case res.feed != nil:
+		feed := res.feed
 		logger.Debug().Msgf("PodcastsSrv: for podcast_url=%q got podcast title=%q published=%s updated=%s",
 			task.URL, feed.Title, feed.UpdatedParsed, feed.PublishedParsed)
 
 		if !feedNeedToBeUpdated(feed, task.MetaUpdatedAt) {
 			logger.Debug().Msgf("PodcastsSrv: podcast_url=%q not updated, skipping", task.URL)
 
-			return nil
+			break
 		}
 
-		update = podcastToUpdate(task.URL, feed)
+		u := podcastToUpdate(podcasturl, feed)
+		update = &u
+
 		if loadepisodes {
 			episodes = episodesToUpdate(feed, since, task.MetaUpdatedAt)
 		}
-	default:
-		logger.Info().Int("status_code", status).
-			Msgf("PodcastsSrv: download podcast_url=%q unknown status=%d", task.URL, status)
-
-		return nil
 	}
 
 	//nolint:wrapcheck
 	return db.InTransaction(ctx, p.dbi, func(ctx context.Context) error {
-		if err := p.podcastsRepo.UpdatePodcastsInfo(ctx, &update); err != nil {
+		if podcasturl != task.URL {
+			if err := p.moveFeed(ctx, task.URL, podcasturl); err != nil {
+				return aerr.Wrapf(err, "move feed failed")
+			}
+		}
+
+		if err := p.podcastsRepo.SaveFeedState(ctx, &state); err != nil {
+			return aerr.Wrapf(err, "save feed state failed")
+		}
+
+		if update == nil {
+			return nil
+		}
+
+		if err := p.podcastsRepo.UpdatePodcastsInfo(ctx, update); err != nil {
 			return aerr.Wrapf(err, "update podcast info failed")
 		}
 
 		if len(episodes) > 0 {
-			if err := p.episodesRepo.UpdateEpisodeInfo(ctx, episodes...); err != nil {
+			if err := p.episodesRepo.UpdateEpisodeInfo(ctx, podcasturl, episodes...); err != nil {
 				return aerr.Wrapf(err, "update episodes info failed")
 			}
 		}
@@ -369,48 +681,375 @@ func (p *PodcastsSrv) downloadPodcastInfo(ctx context.Context, //nolint: cyclop
 	})
 }
 
-func parseFeedURLWithContext(ctx context.Context, feedparser *gofeed.Parser, //nolint:cyclop
-	ptu *model.PodcastToUpdate,
-) (*gofeed.Feed, int, error) {
+// moveFeed change url of feed and podcasts of all users from `oldurl` to `newurl`. Users subscribing
+// old podcast are subscribed to new one (also on devices); episodes and settings are moved. Old
+// podcast stay unsubscribed so clients get information about change.
+func (p *PodcastsSrv) moveFeed(ctx context.Context, oldurl, newurl string) error {
+	podcasts, err := p.podcastsRepo.ListPodcastsByURL(ctx, oldurl)
+	if err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	now := time.Now().UTC()
+
+	for _, oldpodcast := range podcasts {
+		podcast, err := p.podcastsRepo.GetPodcast(ctx, oldpodcast.User.ID, newurl)
+		if errors.Is(err, common.ErrNoData) {
+			podcast = &model.Podcast{User: oldpodcast.User, URL: newurl}
+		} else if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if oldpodcast.Subscribed {
+			podcast.SetSubscribed(now)
+		}
+
+		if podcast.ID, err = p.podcastsRepo.SavePodcast(ctx, podcast); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if err := p.podcastsRepo.MovePodcastData(ctx, oldpodcast.ID, podcast.ID, now); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if oldpodcast.SetUnsubscribed(now) {
+			if _, err := p.podcastsRepo.SavePodcast(ctx, &oldpodcast); err != nil {
+				return aerr.ApplyFor(ErrRepositoryError, err)
+			}
+		}
+	}
+
+	if err := p.podcastsRepo.MoveFeed(ctx, oldurl, newurl); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return nil
+}
+
+// resolveFeedRedirects replace urls of moved feeds by its current urls. Return updated urls and list
+// of changes [[old url, new url]].
+func resolveFeedRedirects(ctx context.Context, podcastsRepo repository.Podcasts, urls []string,
+) ([]string, [][]string, error) {
+	var changes [][]string
+
+	for i, u := range urls {
+		newurl, err := podcastsRepo.GetFeedRedirect(ctx, u)
+		if errors.Is(err, common.ErrNoData) {
+			continue
+		} else if err != nil {
+			return nil, nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		urls[i] = newurl
+		changes = append(changes, []string{u, newurl})
+	}
+
+	return urls, changes, nil
+}
+
+// feedResponse is result of fetching podcast feed.
+type feedResponse struct {
+	feed         *gofeed.Feed
+	etag         string
+	lastModified string
+	// newURL is new location of feed given by permanent redirect or itunes:new-feed-url.
+	newURL string
+	status int
+}
+
+// fetchFeed download and parse feed. Use conditional request when previous ETag or Last-Modified
+// are known. Not modified feed is returned with status 304 and without feed. New location of feed
+// is detected when all redirects are permanent or feed declare itunes:new-feed-url.
+func fetchFeed(ctx context.Context, feedparser *gofeed.Parser, ptu *model.PodcastToUpdate,
+) (feedResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, downloadPodcastInfoTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ptu.URL, nil)
 	if err != nil {
-		return nil, 0, aerr.Wrapf(err, "create request failed")
+		return feedResponse{}, aerr.Wrapf(err, "create request failed")
 	}
 
-	if !ptu.MetaUpdatedAt.IsZero() {
+	switch {
+	case ptu.LastModified != "":
+		req.Header.Add("If-Modified-Since", ptu.LastModified)
+	case !ptu.MetaUpdatedAt.IsZero():
 		req.Header.Add("If-Modified-Since", ptu.MetaUpdatedAt.Format(time.RFC1123))
 	}
 
+	if ptu.ETag != "" {
+		req.Header.Add("If-None-Match", ptu.ETag)
+	}
+
 	req.Header.Set("User-Agent", feedparser.UserAgent)
 
-	resp, err := http.DefaultClient.Do(req)
+	var (
+		redirectURL string
+		permanent   = true
+	)
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxFeedRedirects {
+				return aerr.New("stopped after %d redirects", maxFeedRedirects)
+			}
+
+			permanent = permanent && isPermanentRedirect(req.Response)
+			if permanent {
+				redirectURL = req.URL.String()
+			}
+
+			return nil
+		},
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
-		return nil, 0, aerr.Wrapf(err, "make request failed")
+		return feedResponse{}, aerr.Wrapf(err, "make request failed")
 	} else if resp == nil {
-		return nil, 0, aerr.New("empty response when get feed")
+		return feedResponse{}, aerr.New("empty response when get feed")
 	}
 
 	defer resp.Body.Close()
 
+	res := feedResponse{
+		status:       resp.StatusCode,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+
+	if permanent && redirectURL != "" {
+		res.newURL = validators.SanitizeURL(redirectURL)
+	}
+
 	switch {
 	case resp.StatusCode == http.StatusNotModified:
-		return nil, http.StatusNotModified, nil
+		return res, nil
 	case resp.StatusCode >= 200 && resp.StatusCode < 300:
-		res, err := feedparser.Parse(resp.Body)
+		res.feed, err = feedparser.Parse(resp.Body)
 		if err != nil {
-			return nil, resp.StatusCode, aerr.Wrapf(err, "parse feed body failed")
+			return res, aerr.Wrapf(err, "parse feed body failed")
 		}
 
-		return res, resp.StatusCode, nil
-	case resp.StatusCode >= 400: //nolint:mnd
-		return nil, resp.StatusCode, aerr.New("invalid response from when get feed").
+		if res.feed.ITunesExt != nil && res.feed.ITunesExt.NewFeedURL != "" {
+			if newurl := validators.SanitizeURL(res.feed.ITunesExt.NewFeedURL); newurl != "" {
+				res.newURL = newurl
+			}
+		}
+
+		return res, nil
+	default:
+		return res, aerr.New("invalid response from when get feed").
 			WithMeta("status_code", resp.StatusCode, "status", resp.Status)
+	}
+}
+
+// maxFeedRedirects is maximal number of redirects followed when fetching feed.
+const maxFeedRedirects = 10
+
+// isPermanentRedirect check is `resp` moved feed permanently.
+func isPermanentRedirect(resp *http.Response) bool {
+	return resp != nil &&
+		(resp.StatusCode == http.StatusMovedPermanently || resp.StatusCode == http.StatusPermanentRedirect)
+}
+
+const (
+	// feedMaxFailures is number of consecutive failed fetches after which feed is marked as dead.
+	feedMaxFailures = 10
+	// feedRetryMinDelay is delay of next fetch after first failure; doubled on each next failure.
+	feedRetryMinDelay = 30 * time.Minute
+	feedRetryMaxDelay = 7 * 24 * time.Hour
+)
+
+// feedStateAfterFetch create new state of feed `task` according to fetch result.
+func feedStateAfterFetch(task *model.PodcastToUpdate, res *feedResponse, fetchErr error, now time.Time,
+) model.FeedState {
+	state := model.FeedState{
+		URL:                 task.URL,
+		ETag:                task.ETag,
+		LastModified:        task.LastModified,
+		LastFetchAt:         now,
+		LastStatus:          res.status,
+		CheckInterval:       task.CheckInterval,
+		CheckIntervalSource: task.CheckIntervalSource,
+		IntervalOverride:    task.IntervalOverride,
+	}
+
+	// schedule is computed only from loaded feed; otherwise previous is kept
+	if res.feed != nil {
+		state.CheckInterval, state.CheckIntervalSource = feedCheckInterval(res.feed, now)
+	} else if state.CheckInterval <= 0 {
+		state.CheckInterval, state.CheckIntervalSource = feedDefaultCheckInterval, model.FeedScheduleDefault
+	}
+
+	interval, _ := state.Interval()
+
+	if fetchErr != nil {
+		state.Failures = task.Failures + 1
+		state.LastError = fetchErr.Error()
+		state.NextRetryAt = now.Add(feedRetryDelay(state.Failures))
+		state.NextCheckAt = now.Add(max(interval, feedRetryDelay(state.Failures)))
+		state.Dead = state.Failures >= feedMaxFailures
+
+		return state
+	}
+
+	state.NextCheckAt = now.Add(interval)
+
+	// not modified response may not contain validators
+	if res.status != http.StatusNotModified || res.etag != "" {
+		state.ETag = res.etag
+	}
+
+	if res.status != http.StatusNotModified || res.lastModified != "" {
+		state.LastModified = res.lastModified
+	}
+
+	return state
+}
+
+// feedRetryDelay return delay of next fetch after `failures` consecutive failures.
+func feedRetryDelay(failures int) time.Duration {
+	delay := feedRetryMinDelay
+	for i := 1; i < failures && delay < feedRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, feedRetryMaxDelay)
+}
+
+const (
+	feedMinCheckInterval     = time.Hour
+	feedMaxCheckInterval     = 24 * time.Hour
+	feedDefaultCheckInterval = 6 * time.Hour
+	feedDormantCheckInterval = 7 * 24 * time.Hour
+	// feedDormantAfter is time from last episode after which feed is considered as dormant.
+	feedDormantAfter = 90 * 24 * time.Hour
+	// feedChecksPerEpisode is number of checks in typical interval between episodes.
+	feedChecksPerEpisode = 24
+	// feedCadenceEpisodes is number of latest episodes used to find publishing cadence.
+	feedCadenceEpisodes = 10
+)
+
+// feedCheckInterval compute interval of checking `feed` and its source. Publisher hints (<ttl>,
+// <sy:updatePeriod>) are preferred when present (limited to feedMinCheckInterval..feedDormantCheckInterval);
+// otherwise interval depend on publishing cadence of latest episodes.
+func feedCheckInterval(feed *gofeed.Feed, now time.Time) (time.Duration, string) {
+	if hint, hintsource := feedHintInterval(feed); hint > 0 {
+		return min(max(hint, feedMinCheckInterval), feedDormantCheckInterval), hintsource
+	}
+
+	return feedCadenceInterval(feed, now)
+}
+
+// feedCadenceInterval find check interval from median interval between latest episodes. Feed without
+// new episodes for long time is checked rarely.
+func feedCadenceInterval(feed *gofeed.Feed, now time.Time) (time.Duration, string) {
+	dates := make([]time.Time, 0, len(feed.Items))
+
+	for _, item := range feed.Items {
+		switch {
+		case item.PublishedParsed != nil:
+			dates = append(dates, *item.PublishedParsed)
+		case item.UpdatedParsed != nil:
+			dates = append(dates, *item.UpdatedParsed)
+		}
+	}
+
+	if len(dates) == 0 {
+		return feedDefaultCheckInterval, model.FeedScheduleDefault
+	}
+
+	slices.SortFunc(dates, func(a, b time.Time) int { return b.Compare(a) })
+
+	if now.Sub(dates[0]) > feedDormantAfter {
+		return feedDormantCheckInterval, model.FeedScheduleDormant
+	}
+
+	dates = dates[:min(len(dates), feedCadenceEpisodes)]
+	if len(dates) < 2 { //nolint:mnd
+		return feedDefaultCheckInterval, model.FeedScheduleDefault
+	}
+
+	gaps := make([]time.Duration, 0, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		gaps = append(gaps, dates[i-1].Sub(dates[i]))
+	}
+
+	slices.Sort(gaps)
+
+	interval := gaps[len(gaps)/2] / feedChecksPerEpisode
+
+	return min(max(interval, feedMinCheckInterval), feedMaxCheckInterval), model.FeedScheduleCadence
+}
+
+// feedHintInterval return minimal interval of checking feed declared by publisher in <ttl> or
+// <sy:updatePeriod> and <sy:updateFrequency>. Return 0 when feed has no hints.
+func feedHintInterval(feed *gofeed.Feed) (time.Duration, string) {
+	if ttl, err := strconv.Atoi(strings.TrimSpace(feed.Custom[feedCustomTTL])); err == nil && ttl > 0 {
+		return time.Duration(ttl) * time.Minute, model.FeedScheduleTTL
+	}
+
+	sy := feed.Extensions["sy"]
+	if sy == nil {
+		return 0, ""
+	}
+
+	var period time.Duration
+
+	switch extensionValue(sy, "updatePeriod") {
+	case "hourly":
+		period = time.Hour
+	case "daily":
+		period = 24 * time.Hour
+	case "weekly":
+		period = 7 * 24 * time.Hour
+	case "monthly":
+		period = 30 * 24 * time.Hour
+	case "yearly":
+		period = 365 * 24 * time.Hour
 	default:
-		return nil, resp.StatusCode, nil
+		return 0, ""
+	}
+
+	if freq, err := strconv.Atoi(extensionValue(sy, "updateFrequency")); err == nil && freq > 1 {
+		period /= time.Duration(freq)
+	}
+
+	return period, model.FeedScheduleUpdatePeriod
+}
+
+func extensionValue(exts map[string][]ext.Extension, name string) string {
+	if e := exts[name]; len(e) > 0 {
+		return strings.TrimSpace(e[0].Value)
 	}
+
+	return ""
+}
+
+// feedCustomTTL is key in gofeed.Feed.Custom where rss <ttl> is stored.
+const feedCustomTTL = "ttl"
+
+// rssTranslator keep rss <ttl> (not supported by gofeed universal feed) in feed Custom fields.
+type rssTranslator struct {
+	gofeed.DefaultRSSTranslator
+}
+
+func (t *rssTranslator) Translate(feed any) (*gofeed.Feed, error) {
+	res, err := t.DefaultRSSTranslator.Translate(feed)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if rssfeed, ok := feed.(*rss.Feed); ok && rssfeed.TTL != "" {
+		if res.Custom == nil {
+			res.Custom = make(map[string]string)
+		}
+
+		res.Custom[feedCustomTTL] = rssfeed.TTL
+	}
+
+	return res, nil
 }
 
 func podcastToUpdate(url string, feed *gofeed.Feed) model.PodcastMetaUpdate {
@@ -419,25 +1058,51 @@ func podcastToUpdate(url string, feed *gofeed.Feed) model.PodcastMetaUpdate {
 		title = "<no title>"
 	}
 
+	logo := ""
+	if feed.Image != nil {
+		logo = feed.Image.URL
+	}
+
 	return model.PodcastMetaUpdate{
 		URL:           url,
 		Title:         title,
 		Description:   feed.Description,
 		Website:       feed.Link,
+		LogoURL:       logo,
+		Tags:          feedTags(feed),
 		MetaUpdatedAt: time.Now().UTC(),
 	}
 }
 
+// feedTags collect unique categories from feed (including itunes categories and subcategories).
+func feedTags(feed *gofeed.Feed) model.PodcastTags {
+	categories := slices.Clone(feed.Categories)
+
+	if feed.ITunesExt != nil {
+		for _, c := range feed.ITunesExt.Categories {
+			for ; c != nil; c = c.Subcategory {
+				categories = append(categories, c.Text)
+			}
+		}
+	}
+
+	tags := make(model.PodcastTags, 0, len(categories))
+
+	for _, c := range categories {
+		if tag, ok := model.NewPodcastTag(c); ok && !tags.Contains(tag.Tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
 func episodesToUpdate(feed *gofeed.Feed, since, metadataUpdatedAt time.Time) []model.Episode {
 	episodes := make([]model.Episode, 0, len(feed.Items))
 	for _, item := range feed.Items {
 		if item.Title != "" && itemNeedToBeUpdated(item, since, metadataUpdatedAt) {
-			if url := findEpisodeURL(item); url != "" {
-				episodes = append(episodes, model.Episode{
-					Title: item.Title,
-					GUID:  &item.GUID,
-					URL:   url,
-				})
+			if url, enclosure := findEpisodeEnclosure(item); url != "" {
+				episodes = append(episodes, episodeFromFeedItem(item, url, enclosure))
 			}
 		}
 	}
@@ -445,14 +1110,92 @@ func episodesToUpdate(feed *gofeed.Feed, since, metadataUpdatedAt time.Time) []m
 	return episodes
 }
 
-func findEpisodeURL(item *gofeed.Item) string {
+// findEpisodeEnclosure return sanitized url and first valid enclosure of feed item.
+func findEpisodeEnclosure(item *gofeed.Item) (string, *gofeed.Enclosure) {
 	for _, e := range item.Enclosures {
 		if u := validators.SanitizeURL(e.URL); u != "" {
-			return u
+			return u, e
 		}
 	}
 
-	return ""
+	return "", nil
+}
+
+// episodeFromFeedItem create episode with metadata from feed `item`.
+func episodeFromFeedItem(item *gofeed.Item, url string, enclosure *gofeed.Enclosure) model.Episode {
+	episode := model.Episode{
+		Title:       item.Title,
+		GUID:        &item.GUID,
+		URL:         url,
+		Description: item.Description,
+		Link:        validators.SanitizeURL(item.Link),
+	}
+
+	switch {
+	case item.PublishedParsed != nil:
+		episode.Released = item.PublishedParsed.UTC()
+	case item.UpdatedParsed != nil:
+		episode.Released = item.UpdatedParsed.UTC()
+	}
+
+	if item.Image != nil {
+		episode.ImageURL = validators.SanitizeURL(item.Image.URL)
+	}
+
+	if item.ITunesExt != nil {
+		if episode.ImageURL == "" {
+			episode.ImageURL = validators.SanitizeURL(item.ITunesExt.Image)
+		}
+
+		if episode.Description == "" {
+			episode.Description = item.ITunesExt.Summary
+		}
+
+		episode.Duration = parseItemDuration(item.ITunesExt.Duration)
+	}
+
+	if enclosure != nil {
+		episode.EnclosureType = enclosure.Type
+		episode.EnclosureSize, _ = strconv.ParseInt(strings.TrimSpace(enclosure.Length), 10, 64)
+	}
+
+	return episode
+}
+
+// parseItemDuration parse itunes:duration value in format [[HH:]MM:]SS to seconds. Return 0 for invalid
+// values.
+func parseItemDuration(duration string) int32 {
+	duration = strings.TrimSpace(duration)
+	if duration == "" {
+		return 0
+	}
+
+	parts := strings.Split(duration, ":")
+	if len(parts) > 3 { //nolint:mnd
+		return 0
+	}
+
+	var res int64
+
+	for _, p := range parts {
+		// seconds may contain fraction
+		if val, _, found := strings.Cut(p, "."); found {
+			p = val
+		}
+
+		v, err := strconv.ParseInt(p, 10, 32)
+		if err != nil || v < 0 {
+			return 0
+		}
+
+		res = res*60 + v //nolint:mnd
+	}
+
+	if res > math.MaxInt32 {
+		return 0
+	}
+
+	return int32(res)
 }
 
 func feedNeedToBeUpdated(feed *gofeed.Feed, since time.Time) bool {
@@ -8,13 +8,24 @@ package service
 // Distributed under terms of the GPLv3 license.
 //
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
 	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
 	"gitlab.com/kabes/go-gpo/internal/assert"
 	"gitlab.com/kabes/go-gpo/internal/command"
 	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
 	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
 )
 
 func TestPodcastsServiceUserPodcasts(t *testing.T) {
@@ -102,3 +113,645 @@ func TestPodcastsServiceDelete(t *testing.T) {
 	_, err = podcastsSrv.GetPodcast(ctx, "user1", pid)
 	assert.ErrSpec(t, err, common.ErrUnknownPodcast)
 }
+
+func TestPodcastsServiceSharedFeeds(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+	podcastsRepo := do.MustInvoke[repository.Podcasts](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user2", "dev1")
+
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2")
+	prepareTestSub(ctx, t, i, "user2", "dev1", "http://example.com/p2")
+
+	// each feed is updated once regardless of number of subscribers
+	toupdate, err := db.InConnectionR(ctx, dbi, func(ctx context.Context) ([]model.PodcastToUpdate, error) {
+		return podcastsRepo.ListPodcastsToUpdate(ctx, time.Now(), false)
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(toupdate), 2)
+
+	err = db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		return podcastsRepo.UpdatePodcastsInfo(ctx, &model.PodcastMetaUpdate{
+			URL:           "http://example.com/p2",
+			Title:         "Podcast 2",
+			MetaUpdatedAt: time.Now().UTC(),
+		})
+	})
+	assert.NoErr(t, err)
+
+	// metadata are visible for all subscribers
+	for _, user := range []string{"user1", "user2"} {
+		podcasts, err := podcastsSrv.GetPodcasts(ctx, user)
+		assert.NoErr(t, err)
+
+		podcast, ok := model.Podcasts(podcasts).FindPodcastByURL("http://example.com/p2")
+		assert.True(t, ok)
+		assert.Equal(t, podcast.Title, "Podcast 2")
+		assert.Equal(t, podcast.Subscribers, 2)
+	}
+
+	toupdate, err = db.InConnectionR(ctx, dbi, func(ctx context.Context) ([]model.PodcastToUpdate, error) {
+		return podcastsRepo.ListPodcastsToUpdate(ctx, time.Now().Add(-time.Hour), false)
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(toupdate), 1)
+	assert.Equal(t, toupdate[0].URL, "http://example.com/p1")
+}
+
+func TestPodcastsServiceSearch(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+	podcastsRepo := do.MustInvoke[repository.Podcasts](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user2", "dev1")
+
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2")
+	prepareTestSub(ctx, t, i, "user2", "dev1", "http://example.com/p2", "http://other.org/feed")
+
+	// metadata downloaded for podcast
+	err := db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		return podcastsRepo.UpdatePodcastsInfo(ctx, &model.PodcastMetaUpdate{
+			URL:           "http://example.com/p2",
+			Title:         "Linux weekly news",
+			Description:   "Everything about penguins",
+			MetaUpdatedAt: time.Now(),
+		})
+	})
+	assert.NoErr(t, err)
+
+	podcasts, err := podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: "linux"})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcasts.ToURLs(), []string{"http://example.com/p2"})
+	assert.Equal(t, podcasts[0].Subscribers, 2)
+
+	podcasts, err = podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: "PENGUINS"})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcasts.ToURLs(), []string{"http://example.com/p2"})
+
+	podcasts, err = podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: "example"})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcasts.ToURLs(), []string{"http://example.com/p2", "http://example.com/p1"})
+
+	podcasts, err = podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: `other "feed`})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcasts.ToURLs(), []string{"http://other.org/feed"})
+
+	podcasts, err = podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: "missing"})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(podcasts), 0)
+
+	_, err = podcastsSrv.SearchPodcasts(ctx, &query.SearchPodcastsQuery{Text: " "})
+	assert.Err(t, err)
+}
+
+func TestPodcastsServiceToplistSuggestions(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+
+	for _, u := range []string{"user1", "user2", "user3", "user4"} {
+		_ = prepareTestUser(ctx, t, i, u)
+		prepareTestDevice(ctx, t, i, u, "dev1")
+	}
+
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2")
+	prepareTestSub(ctx, t, i, "user2", "dev1", "http://example.com/p1", "http://example.com/p3")
+	prepareTestSub(ctx, t, i, "user3", "dev1", "http://example.com/p1", "http://example.com/p3",
+		"http://example.com/p4")
+	prepareTestSub(ctx, t, i, "user4", "dev1", "http://example.com/p5", "http://example.com/p4")
+
+	toplist, err := podcastsSrv.GetToplist(ctx, &query.GetToplistQuery{Limit: 3})
+	assert.NoErr(t, err)
+	assert.Equal(t, toplist.ToURLs(),
+		[]string{"http://example.com/p1", "http://example.com/p3", "http://example.com/p4"})
+	assert.Equal(t, toplist[0].Subscribers, 3)
+
+	_, err = podcastsSrv.GetToplist(ctx, &query.GetToplistQuery{Limit: 0})
+	assert.Err(t, err)
+
+	// user1 share p1 with user2 and user3; p5 is subscribed only by not related user4
+	suggestions, err := podcastsSrv.GetSuggestions(ctx, &query.GetSuggestionsQuery{UserName: "user1", Limit: 10})
+	assert.NoErr(t, err)
+	assert.Equal(t, suggestions.ToURLs(), []string{"http://example.com/p3", "http://example.com/p4"})
+
+	suggestions, err = podcastsSrv.GetSuggestions(ctx, &query.GetSuggestionsQuery{UserName: "user4", Limit: 10})
+	assert.NoErr(t, err)
+	assert.Equal(t, suggestions.ToURLs(), []string{"http://example.com/p1", "http://example.com/p3"})
+
+	_, err = podcastsSrv.GetSuggestions(ctx, &query.GetSuggestionsQuery{UserName: "user9", Limit: 10})
+	assert.ErrSpec(t, err, common.ErrUnknownUser)
+}
+
+func TestPodcastsServicePodcastData(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+	podcastsRepo := do.MustInvoke[repository.Podcasts](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user2", "dev1")
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1")
+	prepareTestSub(ctx, t, i, "user2", "dev1", "http://example.com/p1", "http://example.com/p2")
+
+	err := db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		return podcastsRepo.UpdatePodcastsInfo(ctx, &model.PodcastMetaUpdate{
+			URL:           "http://example.com/p1",
+			Title:         "Podcast 1",
+			Description:   "Description 1",
+			Website:       "http://example.com/",
+			LogoURL:       "http://example.com/logo.png",
+			MetaUpdatedAt: time.Now(),
+		})
+	})
+	assert.NoErr(t, err)
+
+	podcast, err := podcastsSrv.GetPodcastData(ctx,
+		&query.GetPodcastDataQuery{UserName: "user1", PodcastURL: "http://example.com/p1"})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcast.Title, "Podcast 1")
+	assert.Equal(t, podcast.Description, "Description 1")
+	assert.Equal(t, podcast.Website, "http://example.com/")
+	assert.Equal(t, podcast.LogoURL, "http://example.com/logo.png")
+	assert.Equal(t, podcast.Subscribers, 2)
+	assert.True(t, podcast.MygpoLink != "")
+
+	// podcast known only by other user - no link to user page
+	podcast, err = podcastsSrv.GetPodcastData(ctx,
+		&query.GetPodcastDataQuery{UserName: "user1", PodcastURL: "http://example.com/p2"})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcast.Subscribers, 1)
+	assert.Equal(t, podcast.MygpoLink, "")
+
+	_, err = podcastsSrv.GetPodcastData(ctx,
+		&query.GetPodcastDataQuery{UserName: "user1", PodcastURL: "http://example.com/p3"})
+	assert.ErrSpec(t, err, common.ErrUnknownPodcast)
+}
+
+func TestPodcastsServiceTags(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+	podcastsRepo := do.MustInvoke[repository.Podcasts](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user2", "dev1")
+	prepareTestSub(ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2")
+	prepareTestSub(ctx, t, i, "user2", "dev1", "http://example.com/p1", "http://example.com/p3")
+
+	tag := func(title string) model.PodcastTag {
+		tag, _ := model.NewPodcastTag(title)
+
+		return tag
+	}
+
+	err := db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		for url, tags := range map[string]model.PodcastTags{
+			"http://example.com/p1": {tag("Technology"), tag("Open Source")},
+			"http://example.com/p2": {tag("Technology")},
+			"http://example.com/p3": {tag("Technology"), tag("News")},
+		} {
+			err := podcastsRepo.UpdatePodcastsInfo(ctx, &model.PodcastMetaUpdate{
+				URL:           url,
+				Title:         url,
+				Tags:          tags,
+				MetaUpdatedAt: time.Now(),
+			})
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+
+		return nil
+	})
+	assert.NoErr(t, err)
+
+	tags, err := podcastsSrv.GetTags(ctx, &query.GetTagsQuery{Limit: 2})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(tags), 2)
+	assert.Equal(t, tags[0].Tag, "technology")
+	assert.Equal(t, tags[0].Title, "Technology")
+	assert.Equal(t, tags[0].Usage, 3)
+
+	// p1 is subscribed by two users
+	podcasts, err := podcastsSrv.GetTagPodcasts(ctx, &query.GetTagPodcastsQuery{Tag: "technology", Limit: 10})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(podcasts), 3)
+	assert.Equal(t, podcasts[0].URL, "http://example.com/p1")
+
+	podcasts, err = podcastsSrv.GetTagPodcasts(ctx, &query.GetTagPodcastsQuery{Tag: "open-source", Limit: 10})
+	assert.NoErr(t, err)
+	assert.Equal(t, podcasts.ToURLs(), []string{"http://example.com/p1"})
+
+	podcasts, err = podcastsSrv.GetTagPodcasts(ctx, &query.GetTagPodcastsQuery{Tag: "missing", Limit: 10})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(podcasts), 0)
+
+	_, err = podcastsSrv.GetTagPodcasts(ctx, &query.GetTagPodcastsQuery{Tag: "", Limit: 10})
+	assert.Err(t, err)
+
+	// tags are attached to user podcasts
+	userPodcasts, err := podcastsSrv.GetPodcastsWithLastEpisode(ctx, "user1", true)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(userPodcasts), 2)
+
+	for _, p := range userPodcasts {
+		assert.True(t, p.Tags.Contains("technology"))
+		assert.Equal(t, p.Tags.Contains("open-source"), p.URL == "http://example.com/p1")
+	}
+}
+
+func TestFeedTags(t *testing.T) {
+	feed := gofeed.Feed{
+		Categories: []string{"Technology", " ", "News"},
+		ITunesExt: &ext.ITunesFeedExtension{
+			Categories: []*ext.ITunesCategory{
+				{Text: "Technology", Subcategory: &ext.ITunesCategory{Text: "Tech News"}},
+			},
+		},
+	}
+
+	tags := feedTags(&feed)
+	assert.Equal(t, len(tags), 3)
+	assert.Equal(t, tags[0], model.PodcastTag{Tag: "technology", Title: "Technology"})
+	assert.Equal(t, tags[1], model.PodcastTag{Tag: "news", Title: "News"})
+	assert.Equal(t, tags[2], model.PodcastTag{Tag: "tech-news", Title: "Tech News"})
+}
+
+func TestEpisodeFromFeedItem(t *testing.T) {
+	published := time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)
+	item := gofeed.Item{
+		Title:           "Episode 1",
+		GUID:            "guid1",
+		Description:     "Description",
+		Link:            "http://example.com/ep1",
+		PublishedParsed: &published,
+		Enclosures: []*gofeed.Enclosure{
+			{URL: "", Type: "text/html"},
+			{URL: "http://example.com/ep1.mp3", Type: "audio/mpeg", Length: "12345"},
+		},
+		ITunesExt: &ext.ITunesItemExtension{Duration: "1:02:03", Image: "http://example.com/ep1.jpg"},
+	}
+
+	url, enclosure := findEpisodeEnclosure(&item)
+	assert.Equal(t, url, "http://example.com/ep1.mp3")
+
+	episode := episodeFromFeedItem(&item, url, enclosure)
+	assert.Equal(t, episode.Title, "Episode 1")
+	assert.Equal(t, *episode.GUID, "guid1")
+	assert.Equal(t, episode.URL, "http://example.com/ep1.mp3")
+	assert.Equal(t, episode.Released, published)
+	assert.Equal(t, episode.Description, "Description")
+	assert.Equal(t, episode.Link, "http://example.com/ep1")
+	assert.Equal(t, episode.ImageURL, "http://example.com/ep1.jpg")
+	assert.Equal(t, episode.EnclosureType, "audio/mpeg")
+	assert.Equal(t, episode.EnclosureSize, 12345)
+	assert.Equal(t, episode.Duration, 3723)
+}
+
+func TestParseItemDuration(t *testing.T) {
+	cases := []struct {
+		val string
+		exp int32
+	}{
+		{"", 0},
+		{"125", 125},
+		{"2:05", 125},
+		{"01:02:05", 3725},
+		{"125.5", 125},
+		{"1:2:3:4", 0},
+		{"abc", 0},
+		{"-10", 0},
+	}
+
+	for _, c := range cases {
+		if res := parseItemDuration(c.val); res != c.exp {
+			t.Errorf("parseItemDuration(%q): %d, expected: %d", c.val, res, c.exp)
+		}
+	}
+}
+
+func TestPodcastsServiceFeedState(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+
+	var requests, notModified int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Podcast OK</title>` +
+			`<item><title>Episode 1</title><enclosure url="http://example.com/ep1.mp3"/></item></channel></rss>`))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	okURL, failURL := srv.URL+"/ok", srv.URL+"/fail"
+
+	// feeds are downloaded one by one; concurrent workers do not share in-memory database
+	prepareTestSub(ctx, t, i, "user1", "dev1", okURL)
+
+	err := podcastsSrv.DownloadPodcastsInfo(ctx, time.Now(), false, false, false)
+	assert.NoErr(t, err)
+
+	prepareTestSub(ctx, t, i, "user1", "dev1", okURL, failURL)
+
+	err = podcastsSrv.DownloadPodcastsInfo(ctx, time.Now(), false, true, false)
+	assert.NoErr(t, err)
+
+	state, err := podcastsSrv.GetFeedState(ctx, okURL)
+	assert.NoErr(t, err)
+	assert.Equal(t, state.Health(), model.FeedHealthOK)
+	assert.Equal(t, state.ETag, `"v1"`)
+	assert.Equal(t, state.LastModified, "Mon, 02 Jan 2006 15:04:05 GMT")
+	assert.Equal(t, state.LastStatus, http.StatusOK)
+
+	state, err = podcastsSrv.GetFeedState(ctx, failURL)
+	assert.NoErr(t, err)
+	assert.Equal(t, state.Health(), model.FeedHealthFailing)
+	assert.Equal(t, state.Failures, 1)
+	assert.Equal(t, state.LastStatus, http.StatusInternalServerError)
+	assert.True(t, state.LastError != "")
+	assert.True(t, state.NextRetryAt.After(time.Now()))
+
+	// second run use conditional request; failing feed wait for retry
+	err = podcastsSrv.DownloadPodcastsInfo(ctx, time.Now().Add(time.Minute), false, false, false)
+	assert.NoErr(t, err)
+	assert.Equal(t, requests, 2)
+	assert.Equal(t, notModified, 1)
+
+	state, err = podcastsSrv.GetFeedState(ctx, okURL)
+	assert.NoErr(t, err)
+	assert.Equal(t, state.ETag, `"v1"`)
+	assert.Equal(t, state.LastStatus, http.StatusNotModified)
+
+	states, err := podcastsSrv.GetFeedsHealth(ctx, true)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(states), 1)
+	assert.Equal(t, states[0].URL, failURL)
+	assert.Equal(t, states[0].Failures, 1)
+
+	// feeds are not checked before scheduled time
+	err = podcastsSrv.DownloadPodcastsInfo(ctx, time.Now().Add(time.Minute), false, false, true)
+	assert.NoErr(t, err)
+	assert.Equal(t, requests, 2)
+
+	schedule, err := podcastsSrv.GetFeedsSchedule(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(schedule), 2)
+
+	err = podcastsSrv.SetFeedCheckInterval(ctx, okURL, time.Minute)
+	assert.True(t, aerr.HasTag(err, aerr.ValidationError))
+
+	err = podcastsSrv.SetFeedCheckInterval(ctx, okURL, 2*time.Hour)
+	assert.NoErr(t, err)
+
+	state, err = podcastsSrv.GetFeedState(ctx, okURL)
+	assert.NoErr(t, err)
+	assert.Equal(t, state.IntervalOverride, 2*time.Hour)
+	assert.Equal(t, state.NextCheckAt, state.LastFetchAt.Add(2*time.Hour))
+
+	err = podcastsSrv.ResetFeedState(ctx, failURL)
+	assert.NoErr(t, err)
+
+	states, err = podcastsSrv.GetFeedsHealth(ctx, true)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(states), 0)
+}
+
+func TestFeedStateAfterFetch(t *testing.T) {
+	now := time.Now().UTC()
+	task := model.PodcastToUpdate{URL: "http://example.com/p1", ETag: "e1", Failures: feedMaxFailures - 2}
+
+	state := feedStateAfterFetch(&task, &feedResponse{status: http.StatusNotFound}, errors.New("not found"), now)
+	assert.Equal(t, state.Failures, feedMaxFailures-1)
+	assert.Equal(t, state.ETag, "e1")
+	assert.True(t, !state.Dead)
+	assert.Equal(t, state.NextRetryAt, now.Add(feedRetryDelay(feedMaxFailures-1)))
+
+	task.Failures = state.Failures
+	state = feedStateAfterFetch(&task, &feedResponse{status: http.StatusNotFound}, errors.New("not found"), now)
+	assert.True(t, state.Dead)
+	assert.Equal(t, state.Health(), model.FeedHealthDead)
+
+	// success clear failures
+	state = feedStateAfterFetch(&task, &feedResponse{status: http.StatusNotModified}, nil, now)
+	assert.Equal(t, state.Failures, 0)
+	assert.Equal(t, state.ETag, "e1")
+	assert.True(t, state.NextRetryAt.IsZero())
+
+	state = feedStateAfterFetch(&task, &feedResponse{status: http.StatusOK, etag: "e2"}, nil, now)
+	assert.Equal(t, state.ETag, "e2")
+}
+
+func TestFeedRetryDelay(t *testing.T) {
+	assert.Equal(t, feedRetryDelay(1), feedRetryMinDelay)
+	assert.Equal(t, feedRetryDelay(2), 2*feedRetryMinDelay)
+	assert.Equal(t, feedRetryDelay(4), 8*feedRetryMinDelay)
+	assert.Equal(t, feedRetryDelay(100), feedRetryMaxDelay)
+}
+
+func TestPodcastsServiceMoveFeed(t *testing.T) {
+	ctx, i := prepareTests(t)
+	podcastsSrv := do.MustInvoke[*PodcastsSrv](i)
+	subsSrv := do.MustInvoke[*SubscriptionsSrv](i)
+	episodesSrv := do.MustInvoke[*EpisodesSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0"><channel><title>Podcast New</title>` +
+			`<item><title>Episode 1</title><enclosure url="http://example.com/ep1.mp3"/></item></channel></rss>`))
+	})
+	mux.HandleFunc("/itunes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0"?><rss version="2.0" ` +
+			`xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"><channel><title>Podcast iTunes</title>` +
+			`<itunes:new-feed-url>http://` + r.Host + `/new</itunes:new-feed-url></channel></rss>`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	oldURL, newURL, itunesURL := srv.URL+"/old", srv.URL+"/new", srv.URL+"/itunes"
+	prepareTestSub(ctx, t, i, "user1", "dev1", oldURL)
+	prepareTestEpisode(ctx, t, i, "user1", "dev1", oldURL, "http://example.com/ep1.mp3")
+
+	err := podcastsSrv.DownloadPodcastsInfo(ctx, time.Now(), true, false, false)
+	assert.NoErr(t, err)
+
+	_, err = podcastsSrv.GetFeedState(ctx, oldURL)
+	assert.ErrSpec(t, err, common.ErrUnknownPodcast)
+
+	state, err := podcastsSrv.GetFeedState(ctx, newURL)
+	assert.NoErr(t, err)
+	assert.Equal(t, state.Title, "Podcast New")
+
+	// episodes are moved to new podcast
+	episodes, err := episodesSrv.GetEpisodes(ctx, &query.GetEpisodesQuery{UserName: "user1", Podcast: newURL})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(episodes), 1)
+
+	// old url is replaced by new one
+	res, err := subsSrv.ChangeSubscriptions(ctx, &command.ChangeSubscriptionsCmd{
+		UserName: "user1", DeviceName: "dev1", Add: []string{oldURL, itunesURL}, Timestamp: time.Now(),
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, res.ChangedURLs, [][]string{{oldURL, newURL}})
+
+	// feed moved by itunes:new-feed-url is merged with existing one
+	err = podcastsSrv.DownloadPodcastsInfo(ctx, time.Now(), true, true, false)
+	assert.NoErr(t, err)
+
+	// device get information about changed subscriptions
+	changes, err := subsSrv.GetSubscriptionChanges(ctx, &query.GetSubscriptionChangesQuery{
+		UserName: "user1", DeviceName: "dev1",
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(changes.Added), 1)
+	assert.Equal(t, changes.Added[0].URL, newURL)
+	assert.Equal(t, len(changes.Removed), 2)
+
+	prepareTestEpisode(ctx, t, i, "user1", "dev1", itunesURL, "http://example.com/ep2.mp3")
+
+	episodes, err = episodesSrv.GetEpisodes(ctx, &query.GetEpisodesQuery{UserName: "user1", Podcast: newURL})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(episodes), 2)
+}
+
+func TestFeedCheckInterval(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	feedWithEpisodes := func(last time.Time, gap time.Duration, count int) *gofeed.Feed {
+		feed := &gofeed.Feed{}
+
+		for i := range count {
+			published := last.Add(-time.Duration(i) * gap)
+			feed.Items = append(feed.Items, &gofeed.Item{PublishedParsed: &published})
+		}
+
+		return feed
+	}
+
+	// daily show is checked hourly
+	interval, source := feedCheckInterval(feedWithEpisodes(now.Add(-time.Hour), 24*time.Hour, 10), now)
+	assert.Equal(t, interval, time.Hour)
+	assert.Equal(t, source, model.FeedScheduleCadence)
+
+	interval, _ = feedCheckInterval(feedWithEpisodes(now.Add(-time.Hour), 7*24*time.Hour, 10), now)
+	assert.Equal(t, interval, 7*time.Hour)
+
+	interval, _ = feedCheckInterval(feedWithEpisodes(now.Add(-time.Hour), 60*24*time.Hour, 3), now)
+	assert.Equal(t, interval, feedMaxCheckInterval)
+
+	// dormant feed is checked weekly
+	interval, source = feedCheckInterval(feedWithEpisodes(now.Add(-200*24*time.Hour), 24*time.Hour, 10), now)
+	assert.Equal(t, interval, feedDormantCheckInterval)
+	assert.Equal(t, source, model.FeedScheduleDormant)
+
+	interval, source = feedCheckInterval(&gofeed.Feed{}, now)
+	assert.Equal(t, interval, feedDefaultCheckInterval)
+	assert.Equal(t, source, model.FeedScheduleDefault)
+
+	// hints override cadence in both directions
+	feed := feedWithEpisodes(now.Add(-time.Hour), 24*time.Hour, 10)
+	feed.Custom = map[string]string{feedCustomTTL: "180"}
+	interval, source = feedCheckInterval(feed, now)
+	assert.Equal(t, interval, 3*time.Hour)
+	assert.Equal(t, source, model.FeedScheduleTTL)
+
+	weekly := feedWithEpisodes(now.Add(-time.Hour), 7*24*time.Hour, 10)
+	weekly.Custom = map[string]string{feedCustomTTL: "120"}
+	interval, source = feedCheckInterval(weekly, now)
+	assert.Equal(t, interval, 2*time.Hour)
+	assert.Equal(t, source, model.FeedScheduleTTL)
+
+	// hints are limited
+	feed.Custom = map[string]string{feedCustomTTL: "10"}
+	interval, source = feedCheckInterval(feed, now)
+	assert.Equal(t, interval, feedMinCheckInterval)
+	assert.Equal(t, source, model.FeedScheduleTTL)
+
+	feed.Custom = map[string]string{feedCustomTTL: "43200"}
+	interval, source = feedCheckInterval(feed, now)
+	assert.Equal(t, interval, feedDormantCheckInterval)
+	assert.Equal(t, source, model.FeedScheduleTTL)
+
+	feed.Custom = nil
+	feed.Extensions = ext.Extensions{"sy": {
+		"updatePeriod":    {{Value: "daily"}},
+		"updateFrequency": {{Value: "2"}},
+	}}
+	interval, source = feedCheckInterval(feed, now)
+	assert.Equal(t, interval, 12*time.Hour)
+	assert.Equal(t, source, model.FeedScheduleUpdatePeriod)
+}
+
+func TestFeedStateAfterFetchSchedule(t *testing.T) {
+	now := time.Now().UTC()
+	task := model.PodcastToUpdate{
+		URL: "http://example.com/p1", CheckInterval: 2 * time.Hour, CheckIntervalSource: model.FeedScheduleCadence,
+	}
+
+	// not modified feed keep schedule
+	state := feedStateAfterFetch(&task, &feedResponse{status: http.StatusNotModified}, nil, now)
+	assert.Equal(t, state.CheckInterval, 2*time.Hour)
+	assert.Equal(t, state.NextCheckAt, now.Add(2*time.Hour))
+
+	// failing feed is not checked more often than retry delay allow
+	task.Failures = 5
+	state = feedStateAfterFetch(&task, &feedResponse{status: http.StatusNotFound}, errors.New("not found"), now)
+	assert.Equal(t, state.NextCheckAt, now.Add(feedRetryDelay(6)))
+
+	// override replace computed interval
+	task.Failures = 0
+	task.IntervalOverride = 30 * time.Minute
+	state = feedStateAfterFetch(&task, &feedResponse{status: http.StatusOK, feed: &gofeed.Feed{}}, nil, now)
+	assert.Equal(t, state.CheckInterval, feedDefaultCheckInterval)
+	assert.Equal(t, state.NextCheckAt, now.Add(30*time.Minute))
+
+	interval, source := state.Interval()
+	assert.Equal(t, interval, 30*time.Minute)
+	assert.Equal(t, source, model.FeedScheduleOverride)
+}
+
+func TestRSSTranslatorTTL(t *testing.T) {
+	fp := gofeed.NewParser()
+	fp.RSSTranslator = &rssTranslator{}
+
+	feed, err := fp.ParseString(`<?xml version="1.0"?><rss version="2.0"><channel><title>P1</title>` +
+		`<ttl>60</ttl></channel></rss>`)
+	assert.NoErr(t, err)
+	assert.Equal(t, feed.Custom[feedCustomTTL], "60")
+
+	interval, source := feedHintInterval(feed)
+	assert.Equal(t, interval, time.Hour)
+	assert.Equal(t, source, model.FeedScheduleTTL)
+}
@@ -0,0 +1,274 @@
+//
+// registration.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+// inviteCodeLen is length of generated invite codes.
+const inviteCodeLen = 16
+
+// RegistrationSrv manage self-registration of users: invite codes and accounts waiting for admin
+// approval.
+type RegistrationSrv struct {
+	dbi               repository.Database
+	usersRepo         repository.Users
+	registrationsRepo repository.Registrations
+	usersSrv          *UsersSrv
+}
+
+func NewRegistrationSrv(i do.Injector) (*RegistrationSrv, error) {
+	return &RegistrationSrv{
+		dbi:               do.MustInvoke[repository.Database](i),
+		usersRepo:         do.MustInvoke[repository.Users](i),
+		registrationsRepo: do.MustInvoke[repository.Registrations](i),
+		usersSrv:          do.MustInvoke[*UsersSrv](i),
+	}, nil
+}
+
+// Register create account for new user. When invite is required, invite code is marked as used. When
+// approval is required, account is locked and password is kept until ApproveRegistration.
+func (r *RegistrationSrv) Register(ctx context.Context, cmd *command.RegisterUserCmd,
+) (command.RegisterUserCmdResult, error) {
+	cmd.Sanitize()
+
+	if err := cmd.Validate(); err != nil {
+		return command.RegisterUserCmdResult{}, aerr.Wrapf(err, "validate registration failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, r.dbi, func(ctx context.Context) (command.RegisterUserCmdResult, error) {
+		if cmd.RequireInvite {
+			if err := r.useInviteCode(ctx, cmd.InviteCode, cmd.UserName); err != nil {
+				return command.RegisterUserCmdResult{}, err
+			}
+		}
+
+		added, err := r.usersSrv.addUser(ctx, &command.NewUserCmd{
+			UserName: cmd.UserName,
+			Password: cmd.Password,
+			Email:    cmd.Email,
+			Name:     cmd.Name,
+		})
+		if err != nil {
+			return command.RegisterUserCmdResult{}, err
+		}
+
+		res := command.RegisterUserCmdResult{UserID: added.UserID, PendingApproval: cmd.RequireApproval}
+
+		if cmd.RequireApproval {
+			if err := r.holdForApproval(ctx, cmd.UserName); err != nil {
+				return res, err
+			}
+		}
+
+		zerolog.Ctx(ctx).Info().Object("cmd", cmd).
+			Msgf("RegistrationSrv: user registered user_name=%s pending_approval=%v", cmd.UserName, cmd.RequireApproval)
+
+		return res, nil
+	})
+}
+
+// ListRegistrations return accounts waiting for approval.
+func (r *RegistrationSrv) ListRegistrations(ctx context.Context) ([]model.UserRegistration, error) {
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, r.dbi, func(ctx context.Context) ([]model.UserRegistration, error) {
+		registrations, err := r.registrationsRepo.ListUserRegistrations(ctx)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return registrations, nil
+	})
+}
+
+// ApproveRegistration unlock account and restore password given on registration.
+func (r *RegistrationSrv) ApproveRegistration(ctx context.Context, username string) error {
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, r.dbi, func(ctx context.Context) error {
+		user, registration, err := r.getRegistration(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		user.Password = registration.Password
+
+		if _, err := r.usersRepo.SaveUser(ctx, user); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if err := r.registrationsRepo.DeleteUserRegistration(ctx, user.ID); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, username).
+			Msgf("RegistrationSrv: registration approved user_name=%s", username)
+
+		return nil
+	})
+}
+
+// RejectRegistration delete account waiting for approval.
+func (r *RegistrationSrv) RejectRegistration(ctx context.Context, username string) error {
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, r.dbi, func(ctx context.Context) error {
+		user, _, err := r.getRegistration(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		if err := r.usersRepo.DeleteUser(ctx, user.ID); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, username).
+			Msgf("RegistrationSrv: registration rejected user_name=%s", username)
+
+		return nil
+	})
+}
+
+//------------------------------------------------------------------------------
+
+// CreateInviteCode generate new invite code.
+func (r *RegistrationSrv) CreateInviteCode(ctx context.Context, cmd *command.CreateInviteCodeCmd,
+) (command.CreateInviteCodeCmdResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return command.CreateInviteCodeCmdResult{}, aerr.Wrapf(err, "validate command failed")
+	}
+
+	now := time.Now().UTC()
+	invite := model.InviteCode{
+		Code:      rand.Text()[:inviteCodeLen],
+		CreatedBy: cmd.CreatedBy,
+		CreatedAt: now,
+	}
+
+	if cmd.ValidFor > 0 {
+		invite.ExpiresAt = now.Add(cmd.ValidFor)
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, r.dbi, func(ctx context.Context) (command.CreateInviteCodeCmdResult, error) {
+		if err := r.registrationsRepo.SaveInviteCode(ctx, &invite); err != nil {
+			return command.CreateInviteCodeCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Object("invite_code", &invite).
+			Msgf("RegistrationSrv: invite code created by=%s", cmd.CreatedBy)
+
+		return command.CreateInviteCodeCmdResult{Code: invite.Code}, nil
+	})
+}
+
+func (r *RegistrationSrv) ListInviteCodes(ctx context.Context) ([]model.InviteCode, error) {
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, r.dbi, func(ctx context.Context) ([]model.InviteCode, error) {
+		codes, err := r.registrationsRepo.ListInviteCodes(ctx)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return codes, nil
+	})
+}
+
+func (r *RegistrationSrv) DeleteInviteCode(ctx context.Context, code string) error {
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, r.dbi, func(ctx context.Context) error {
+		if _, err := r.registrationsRepo.GetInviteCode(ctx, code); errors.Is(err, common.ErrNoData) {
+			return common.ErrUnknownInviteCode
+		} else if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if err := r.registrationsRepo.DeleteInviteCode(ctx, code); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return nil
+	})
+}
+
+//------------------------------------------------------------------------------
+
+// useInviteCode check is invite code valid and mark it as used by `username`.
+func (r *RegistrationSrv) useInviteCode(ctx context.Context, code, username string) error {
+	invite, err := r.registrationsRepo.GetInviteCode(ctx, code)
+	if errors.Is(err, common.ErrNoData) {
+		return common.ErrInvalidInviteCode
+	} else if err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	now := time.Now().UTC()
+	if !invite.IsValid(now) {
+		return common.ErrInvalidInviteCode
+	}
+
+	// code may be used concurrently; only one registration win
+	used, err := r.registrationsRepo.UseInviteCode(ctx, code, username, now)
+	if err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	} else if !used {
+		return common.ErrInvalidInviteCode
+	}
+
+	return nil
+}
+
+// holdForApproval keep user password in registration and lock account.
+func (r *RegistrationSrv) holdForApproval(ctx context.Context, username string) error {
+	user, err := r.usersRepo.GetUser(ctx, username)
+	if err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	registration := model.UserRegistration{User: user, Password: user.Password}
+	if err := r.registrationsRepo.SaveUserRegistration(ctx, &registration); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return r.usersSrv.lockAccount(ctx, username)
+}
+
+func (r *RegistrationSrv) getRegistration(ctx context.Context, username string,
+) (*model.User, *model.UserRegistration, error) {
+	if username == "" {
+		return nil, nil, common.ErrEmptyUsername
+	}
+
+	user, err := r.usersRepo.GetUser(ctx, username)
+	if errors.Is(err, common.ErrNoData) {
+		return nil, nil, common.ErrUnknownUser
+	} else if err != nil {
+		return nil, nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	registration, err := r.registrationsRepo.GetUserRegistration(ctx, user.ID)
+	if errors.Is(err, common.ErrNoData) {
+		return nil, nil, common.ErrNoRegistration
+	} else if err != nil {
+		return nil, nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return user, registration, nil
+}
@@ -0,0 +1,194 @@
+//nolint:nilaway
+package service
+
+//
+// registration_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+func TestRegistrationWithInvite(t *testing.T) {
+	ctx, i := prepareTests(t)
+	regSrv := do.MustInvoke[*RegistrationSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+
+	invite, err := regSrv.CreateInviteCode(ctx, &command.CreateInviteCodeCmd{CreatedBy: "admin", ValidFor: time.Hour})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(invite.Code), inviteCodeLen)
+
+	cmd := command.RegisterUserCmd{
+		UserName:      "user1",
+		Password:      "secret",
+		Email:         "user1@example.com",
+		RequireInvite: true,
+	}
+
+	// missing code
+	_, err = regSrv.Register(ctx, &cmd)
+	assert.Err(t, err)
+
+	cmd.InviteCode = "invalid"
+	_, err = regSrv.Register(ctx, &cmd)
+	assert.ErrSpec(t, err, common.ErrInvalidInviteCode)
+
+	cmd.InviteCode = invite.Code
+	res, err := regSrv.Register(ctx, &cmd)
+	assert.NoErr(t, err)
+	assert.True(t, res.UserID > 0)
+	assert.True(t, !res.PendingApproval)
+
+	_, err = usersSrv.LoginUser(ctx, "user1", "secret")
+	assert.NoErr(t, err)
+
+	codes, err := regSrv.ListInviteCodes(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(codes), 1)
+	assert.Equal(t, codes[0].UsedBy, "user1")
+	assert.Equal(t, codes[0].CreatedBy, "admin")
+	assert.True(t, !codes[0].UsedAt.IsZero())
+
+	// code can be used only once
+	cmd.UserName = "user2"
+	_, err = regSrv.Register(ctx, &cmd)
+	assert.ErrSpec(t, err, common.ErrInvalidInviteCode)
+
+	assert.NoErr(t, regSrv.DeleteInviteCode(ctx, invite.Code))
+	assert.ErrSpec(t, regSrv.DeleteInviteCode(ctx, invite.Code), common.ErrUnknownInviteCode)
+}
+
+func TestRegistrationInviteUsedConcurrently(t *testing.T) {
+	ctx, i := prepareTests(t)
+	regSrv := do.MustInvoke[*RegistrationSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	regRepo := do.MustInvoke[repository.Registrations](i)
+	dbi := do.MustInvoke[repository.Database](i)
+
+	invite, err := regSrv.CreateInviteCode(ctx, &command.CreateInviteCodeCmd{ValidFor: time.Hour})
+	assert.NoErr(t, err)
+
+	useCode := func(username string) bool {
+		used, err := db.InTransactionR(ctx, dbi, func(ctx context.Context) (bool, error) {
+			return regRepo.UseInviteCode(ctx, invite.Code, username, time.Now())
+		})
+		assert.NoErr(t, err)
+
+		return used
+	}
+
+	// code is marked as used only once
+	assert.True(t, useCode("user0"))
+	assert.True(t, !useCode("user2"))
+
+	_, err = regSrv.Register(ctx, &command.RegisterUserCmd{
+		UserName:      "user1",
+		Password:      "secret",
+		Email:         "user1@example.com",
+		InviteCode:    invite.Code,
+		RequireInvite: true,
+	})
+	assert.ErrSpec(t, err, common.ErrInvalidInviteCode)
+
+	_, err = usersSrv.LoginUser(ctx, "user1", "secret")
+	assert.Err(t, err)
+
+	codes, err := regSrv.ListInviteCodes(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(codes), 1)
+	assert.Equal(t, codes[0].UsedBy, "user0")
+}
+
+func TestRegistrationWithApproval(t *testing.T) {
+	ctx, i := prepareTests(t)
+	regSrv := do.MustInvoke[*RegistrationSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+
+	cmd := command.RegisterUserCmd{
+		UserName:        "user1",
+		Password:        "secret",
+		Email:           "user1@example.com",
+		RequireApproval: true,
+	}
+
+	res, err := regSrv.Register(ctx, &cmd)
+	assert.NoErr(t, err)
+	assert.True(t, res.PendingApproval)
+
+	_, err = regSrv.Register(ctx, &cmd)
+	assert.ErrSpec(t, err, common.ErrUserExists)
+
+	_, err = usersSrv.LoginUser(ctx, "user1", "secret")
+	assert.ErrSpec(t, err, common.ErrUserAccountLocked)
+
+	registrations, err := regSrv.ListRegistrations(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(registrations), 1)
+	assert.Equal(t, registrations[0].User.UserName, "user1")
+	assert.Equal(t, registrations[0].User.Email, "user1@example.com")
+
+	assert.NoErr(t, regSrv.ApproveRegistration(ctx, "user1"))
+
+	_, err = usersSrv.LoginUser(ctx, "user1", "secret")
+	assert.NoErr(t, err)
+
+	assert.ErrSpec(t, regSrv.ApproveRegistration(ctx, "user1"), common.ErrNoRegistration)
+
+	registrations, err = regSrv.ListRegistrations(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(registrations), 0)
+}
+
+func TestRegistrationReject(t *testing.T) {
+	ctx, i := prepareTests(t)
+	regSrv := do.MustInvoke[*RegistrationSrv](i)
+	prepareTestUser(ctx, t, i, "user2")
+
+	cmd := command.RegisterUserCmd{
+		UserName:        "user1",
+		Password:        "secret",
+		Email:           "user1@example.com",
+		RequireApproval: true,
+	}
+
+	_, err := regSrv.Register(ctx, &cmd)
+	assert.NoErr(t, err)
+
+	// only pending registrations can be rejected
+	assert.ErrSpec(t, regSrv.RejectRegistration(ctx, "user2"), common.ErrNoRegistration)
+
+	assert.NoErr(t, regSrv.RejectRegistration(ctx, "user1"))
+
+	_, err = do.MustInvoke[*UsersSrv](i).CheckUser(ctx, "user1")
+	assert.ErrSpec(t, err, common.ErrUserNotFound)
+}
+
+func TestRegistrationExpiredInvite(t *testing.T) {
+	ctx, i := prepareTests(t)
+	regSrv := do.MustInvoke[*RegistrationSrv](i)
+
+	invite, err := regSrv.CreateInviteCode(ctx, &command.CreateInviteCodeCmd{ValidFor: time.Nanosecond})
+	assert.NoErr(t, err)
+
+	time.Sleep(time.Millisecond)
+
+	_, err = regSrv.Register(ctx, &command.RegisterUserCmd{
+		UserName:      "user1",
+		Password:      "secret",
+		Email:         "user1@example.com",
+		InviteCode:    invite.Code,
+		RequireInvite: true,
+	})
+	assert.ErrSpec(t, err, common.ErrInvalidInviteCode)
+}
@@ -0,0 +1,136 @@
+//
+// remember.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+// RememberTokenLifetime is how long user stay logged in web gui when "remember me" was selected.
+const RememberTokenLifetime = 30 * 24 * time.Hour
+
+// RememberTokensSrv manage "remember me" tokens used to login into web gui without password.
+type RememberTokensSrv struct {
+	dbi        repository.Database
+	usersRepo  repository.Users
+	tokensRepo repository.RememberTokens
+}
+
+func NewRememberTokensSrv(i do.Injector) (*RememberTokensSrv, error) {
+	return &RememberTokensSrv{
+		dbi:        do.MustInvoke[repository.Database](i),
+		usersRepo:  do.MustInvoke[repository.Users](i),
+		tokensRepo: do.MustInvoke[repository.RememberTokens](i),
+	}, nil
+}
+
+// CreateRememberToken generate new token for user. Generated secret is returned only once; database keep
+// only hash.
+func (r *RememberTokensSrv) CreateRememberToken(ctx context.Context, username string) (string, error) {
+	if username == "" {
+		return "", common.ErrEmptyUsername
+	}
+
+	secret := rand.Text()
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, r.dbi, func(ctx context.Context) (string, error) {
+		user, err := r.usersRepo.GetUser(ctx, username)
+		if errors.Is(err, common.ErrNoData) {
+			return "", common.ErrUnknownUser
+		} else if err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		token := model.RememberToken{
+			User:      user,
+			Token:     hashSecret(secret),
+			ExpiresAt: time.Now().UTC().Add(RememberTokenLifetime),
+		}
+
+		if _, err := r.tokensRepo.SaveRememberToken(ctx, &token); err != nil {
+			return "", aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return secret, nil
+	})
+}
+
+// LoginWithRememberToken find user by `secret`. Expired tokens are removed. Errors are compatible with
+// UsersSrv.LoginUser.
+func (r *RememberTokensSrv) LoginWithRememberToken(ctx context.Context, secret string) (*model.User, error) {
+	ctx, end := common.NewTask(ctx, "LoginWithRememberToken")
+	defer end()
+
+	if secret == "" {
+		return nil, aerr.ErrValidation.WithMsg("token can't be empty")
+	}
+
+	hashed := hashSecret(secret)
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, r.dbi, func(ctx context.Context) (*model.User, error) {
+		token, err := r.tokensRepo.GetRememberToken(ctx, hashed)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUnauthorized
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if token.ExpiresAt.Before(time.Now()) {
+			if err := r.tokensRepo.DeleteRememberToken(ctx, hashed); err != nil {
+				return nil, aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			return nil, common.ErrUnauthorized.WithMsg("remember token expired")
+		}
+
+		user, err := r.usersRepo.GetUser(ctx, token.User.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUserNotFound
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if user.Password == model.UserLockedPassword {
+			return nil, common.ErrUserAccountLocked
+		}
+
+		zerolog.Ctx(ctx).Debug().Object("token", token).
+			Msgf("RememberTokensSrv: user_name=%s authenticated by remember token", user.UserName)
+
+		return user, nil
+	})
+}
+
+// DeleteRememberToken revoke token; unknown tokens are ignored.
+func (r *RememberTokensSrv) DeleteRememberToken(ctx context.Context, secret string) error {
+	if secret == "" {
+		return nil
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, r.dbi, func(ctx context.Context) error {
+		if err := r.tokensRepo.DeleteRememberToken(ctx, hashSecret(secret)); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return nil
+	})
+}
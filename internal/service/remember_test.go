@@ -0,0 +1,80 @@
+//nolint:nilaway
+package service
+
+//
+// remember_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+func TestRememberTokensService(t *testing.T) {
+	ctx, i := prepareTests(t)
+	rememberSrv := do.MustInvoke[*RememberTokensSrv](i)
+	userID := prepareTestUser(ctx, t, i, "user1")
+
+	secret, err := rememberSrv.CreateRememberToken(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.True(t, secret != "")
+
+	user, err := rememberSrv.LoginWithRememberToken(ctx, secret)
+	assert.NoErr(t, err)
+	assert.Equal(t, user.UserName, "user1")
+	assert.Equal(t, user.ID, userID)
+
+	_, err = rememberSrv.LoginWithRememberToken(ctx, "invalid")
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+
+	_, err = rememberSrv.CreateRememberToken(ctx, "user2")
+	assert.ErrSpec(t, err, common.ErrUnknownUser)
+
+	assert.NoErr(t, rememberSrv.DeleteRememberToken(ctx, secret))
+
+	_, err = rememberSrv.LoginWithRememberToken(ctx, secret)
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+}
+
+func TestRememberTokensServiceExpired(t *testing.T) {
+	ctx, i := prepareTests(t)
+	rememberSrv := do.MustInvoke[*RememberTokensSrv](i)
+	userID := prepareTestUser(ctx, t, i, "user1")
+
+	// insert expired token
+	err := db.InTransaction(ctx, do.MustInvoke[repository.Database](i), func(ctx context.Context) error {
+		_, err := do.MustInvoke[repository.RememberTokens](i).SaveRememberToken(ctx, &model.RememberToken{
+			User:      &model.User{ID: userID},
+			Token:     hashSecret("secret"),
+			ExpiresAt: time.Now().Add(-time.Hour),
+		})
+
+		return err //nolint:wrapcheck
+	})
+	assert.NoErr(t, err)
+
+	_, err = rememberSrv.LoginWithRememberToken(ctx, "secret")
+	assert.True(t, aerr.HasTag(err, common.AuthenticationError))
+
+	// locked user can't login; tokens are revoked on lock
+	secret, err := rememberSrv.CreateRememberToken(ctx, "user1")
+	assert.NoErr(t, err)
+
+	err = do.MustInvoke[*UsersSrv](i).LockAccount(ctx, command.LockAccountCmd{UserName: "user1"})
+	assert.NoErr(t, err)
+
+	_, err = rememberSrv.LoginWithRememberToken(ctx, secret)
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+}
@@ -1,6 +1,10 @@
 package service
 
-import "slices"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+)
 
 //
 // mod.go
@@ -45,3 +49,11 @@ func (c *DynamicCache[T, V]) GetUsedValues() []V {
 
 	return res
 }
+
+// hashSecret return hash of random secret (token, recovery code). Secrets are random so fast hash is
+// sufficient and allow to find item by its value.
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(hash[:])
+}
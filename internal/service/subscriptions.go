@@ -24,18 +24,20 @@ import (
 )
 
 type SubscriptionsSrv struct {
-	dbi          repository.Database
-	podcastsRepo repository.Podcasts
-	usersRepo    repository.Users
-	devicesRepo  repository.Devices
+	dbi               repository.Database
+	podcastsRepo      repository.Podcasts
+	usersRepo         repository.Users
+	devicesRepo       repository.Devices
+	subscriptionsRepo repository.Subscriptions
 }
 
 func NewSubscriptionsSrv(i do.Injector) (*SubscriptionsSrv, error) {
 	return &SubscriptionsSrv{
-		dbi:          do.MustInvoke[repository.Database](i),
-		podcastsRepo: do.MustInvoke[repository.Podcasts](i),
-		usersRepo:    do.MustInvoke[repository.Users](i),
-		devicesRepo:  do.MustInvoke[repository.Devices](i),
+		dbi:               do.MustInvoke[repository.Database](i),
+		podcastsRepo:      do.MustInvoke[repository.Podcasts](i),
+		usersRepo:         do.MustInvoke[repository.Users](i),
+		devicesRepo:       do.MustInvoke[repository.Devices](i),
+		subscriptionsRepo: do.MustInvoke[repository.Subscriptions](i),
 	}, nil
 }
 
@@ -60,7 +62,7 @@ func (s *SubscriptionsSrv) GetSubscriptions(ctx context.Context, query *query.Ge
 }
 
 // ReplaceSubscriptions replace all subscriptions for given user. Create device when no exists.
-func (s *SubscriptionsSrv) ReplaceSubscriptions( //nolint:cyclop
+func (s *SubscriptionsSrv) ReplaceSubscriptions(
 	ctx context.Context,
 	cmd *command.ReplaceSubscriptionsCmd,
 ) error {
@@ -76,59 +78,48 @@ func (s *SubscriptionsSrv) ReplaceSubscriptions( //nolint:cyclop
 		}
 
 		// check dev
-		_, err = s.getUserDevice(ctx, user.ID, cmd.DeviceName)
+		device, err := s.getUserDevice(ctx, user.ID, cmd.DeviceName)
 		if errors.Is(err, common.ErrUnknownDevice) {
-			_, err = s.createUserDevice(ctx, user, cmd.DeviceName)
+			device, err = s.createUserDevice(ctx, user, cmd.DeviceName)
 		}
 
 		if err != nil {
 			return err
 		}
 
-		// get all podcasts for user
-		subscribed, err := s.podcastsRepo.ListPodcasts(ctx, user.ID, time.Time{})
+		if cmd.Subscriptions, _, err = resolveFeedRedirects(ctx, s.podcastsRepo, cmd.Subscriptions); err != nil {
+			return err
+		}
+
+		// get podcasts currently subscribed by device
+		subscribed, err := s.deviceSubscriptions(ctx, user, device)
 		if err != nil {
-			return aerr.ApplyFor(ErrRepositoryError, err)
+			return err
 		}
 
-		changes := make([]model.Podcast, 0, len(cmd.Subscriptions))
+		var add, remove []string
+
 		// remove subscriptions found in db but not in currentSubs
 		for _, sub := range subscribed {
-			if sub.Subscribed && !slices.Contains(cmd.Subscriptions, sub.URL) {
-				sub.SetUnsubscribed(cmd.Timestamp)
-				changes = append(changes, sub)
+			if !slices.Contains(cmd.Subscriptions, sub.URL) {
+				remove = append(remove, sub.URL)
 			}
 		}
 
-		// add or set subscribed flag for podcast in currentSubs; update updated_at
+		// add subscriptions not found in db
 		for _, sub := range cmd.Subscriptions {
-			podcast, ok := subscribed.FindPodcastByURL(sub)
-			if !ok {
-				podcast = model.Podcast{User: user, URL: sub}
-			} else if podcast.Subscribed {
-				continue
-			}
-
-			if podcast.SetSubscribed(cmd.Timestamp) {
-				changes = append(changes, podcast)
+			if _, ok := subscribed.FindSubscribedPodcastByURL(sub); !ok {
+				add = append(add, sub)
 			}
 		}
 
 		common.TraceLazyPrintf(ctx, "ReplaceSubscriptions: changes prepared")
 
-		for _, p := range changes {
-			if _, err := s.podcastsRepo.SavePodcast(ctx, &p); err != nil {
-				return aerr.ApplyFor(ErrRepositoryError, err)
-			}
-		}
-
-		common.TraceLazyPrintf(ctx, "ReplaceSubscriptions: podcasts saved")
-
-		return nil
+		return s.applySubscriptionChanges(ctx, user, device, add, remove, cmd.Timestamp)
 	})
 }
 
-func (s *SubscriptionsSrv) ChangeSubscriptions( //nolint:cyclop,gocognit,funlen
+func (s *SubscriptionsSrv) ChangeSubscriptions(
 	ctx context.Context, cmd *command.ChangeSubscriptionsCmd,
 ) (command.ChangeSubscriptionsCmdResult, error) {
 	res := command.ChangeSubscriptionsCmdResult{
@@ -146,59 +137,31 @@ func (s *SubscriptionsSrv) ChangeSubscriptions( //nolint:cyclop,gocognit,funlen
 		}
 
 		// check device if given
+		var device *model.Device
 		if cmd.DeviceName != "" {
-			if _, err = s.getUserDevice(ctx, user.ID, cmd.DeviceName); err != nil {
+			if device, err = s.getUserDevice(ctx, user.ID, cmd.DeviceName); err != nil {
 				return err
 			}
 		}
 
-		userpodcasts, err := s.podcastsRepo.ListPodcasts(ctx, user.ID, time.Time{})
-		if err != nil {
-			return aerr.ApplyFor(ErrRepositoryError, err)
-		}
-
-		common.TraceLazyPrintf(ctx, "ChangeSubscriptions: podcasts loaded")
-
-		podchanges := make([]model.Podcast, 0, len(cmd.Add)+len(cmd.Remove))
-
-		// removed
-		for _, sub := range cmd.Remove {
-			if podcast, ok := userpodcasts.FindPodcastByURL(sub); ok {
-				if podcast.SetUnsubscribed(cmd.Timestamp) {
-					podchanges = append(podchanges, podcast)
-				}
-			}
-		}
-
-		for _, sub := range cmd.Add {
-			podcast, ok := userpodcasts.FindPodcastByURL(sub)
-			if !ok { // new
-				podcast = model.Podcast{User: user, URL: sub}
-			} else if podcast.Subscribed {
-				continue
+		// moved feeds are replaced by new url; clients get information about change in result
+		for _, urls := range []*[]string{&cmd.Add, &cmd.Remove} {
+			var changes [][]string
+			if *urls, changes, err = resolveFeedRedirects(ctx, s.podcastsRepo, *urls); err != nil {
+				return err
 			}
 
-			if podcast.SetSubscribed(cmd.Timestamp) {
-				podchanges = append(podchanges, podcast)
-			}
+			res.ChangedURLs = mergeChangedURLs(res.ChangedURLs, changes)
 		}
 
-		common.TraceLazyPrintf(ctx, "ChangeSubscriptions: changes prepared")
-
-		for _, p := range podchanges {
-			if _, err := s.podcastsRepo.SavePodcast(ctx, &p); err != nil {
-				return aerr.ApplyFor(ErrRepositoryError, err)
-			}
-		}
-
-		common.TraceLazyPrintf(ctx, "ChangeSubscriptions: podcast saved")
-
-		return nil
+		return s.applySubscriptionChanges(ctx, user, device, cmd.Add, cmd.Remove, cmd.Timestamp)
 	})
 
 	return res, err //nolint:wrapcheck
 }
 
+// GetSubscriptionChanges return podcasts added and removed since `query.Since`. When `query.DeviceName`
+// is empty, changes of all user podcasts are returned.
 func (s *SubscriptionsSrv) GetSubscriptionChanges(ctx context.Context, query *query.GetSubscriptionChangesQuery) (
 	model.SubscriptionState, error,
 ) {
@@ -229,6 +192,106 @@ func (s *SubscriptionsSrv) GetSubscriptionChanges(ctx context.Context, query *qu
 	return state, nil
 }
 
+// GetDevicePodcasts return all podcasts (subscribed and unsubscribed) visible for device.
+// `query.Since` is ignored.
+func (s *SubscriptionsSrv) GetDevicePodcasts(ctx context.Context, query *query.GetSubscriptionsQuery,
+) (model.Podcasts, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validation query failed")
+	}
+
+	return s.getPodcasts(ctx, query.UserName, query.DeviceName, time.Time{})
+}
+
+// GetSyncStatus return groups of synchronized devices and list of devices not synchronized.
+func (s *SubscriptionsSrv) GetSyncStatus(ctx context.Context, query *query.GetSyncStatusQuery,
+) (model.SyncStatus, error) {
+	if err := query.Validate(); err != nil {
+		return model.SyncStatus{}, aerr.Wrapf(err, "validation query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, s.dbi, func(ctx context.Context) (model.SyncStatus, error) {
+		user, err := s.getUser(ctx, query.UserName)
+		if err != nil {
+			return model.SyncStatus{}, err
+		}
+
+		devices, err := s.devicesRepo.ListDevices(ctx, user.ID)
+		if err != nil {
+			return model.SyncStatus{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return model.NewSyncStatus(devices), nil
+	})
+}
+
+// UpdateSyncStatus create or extend groups of synchronized devices and remove devices from groups.
+// Devices joining group get union of subscriptions of all group members.
+func (s *SubscriptionsSrv) UpdateSyncStatus(ctx context.Context, cmd *command.UpdateSyncDevicesCmd,
+) (model.SyncStatus, error) {
+	if err := cmd.Validate(); err != nil {
+		return model.SyncStatus{}, aerr.Wrapf(err, "validate command failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, s.dbi, func(ctx context.Context) (model.SyncStatus, error) {
+		user, err := s.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return model.SyncStatus{}, err
+		}
+
+		userdevices, err := s.devicesRepo.ListDevices(ctx, user.ID)
+		if err != nil {
+			return model.SyncStatus{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		devices := model.Devices(userdevices)
+		changed := make(map[int64]struct{})
+
+		for _, devname := range cmd.StopSynchronize {
+			idx := slices.IndexFunc(devices, func(d model.Device) bool { return d.Name == devname })
+			if idx < 0 {
+				return model.SyncStatus{}, common.ErrUnknownDevice
+			}
+
+			if devices[idx].SyncGroup > 0 {
+				devices[idx].SyncGroup = 0
+				changed[devices[idx].ID] = struct{}{}
+			}
+		}
+
+		for _, group := range cmd.Synchronize {
+			if err := s.synchronizeDevices(ctx, user, devices, group, changed); err != nil {
+				return model.SyncStatus{}, err
+			}
+		}
+
+		// group with only one device is no longer synchronized; device share user subscriptions again
+		for idx, dev := range devices {
+			if dev.SyncGroup > 0 && len(devices.SyncGroupMembers(dev.SyncGroup)) < 2 { //nolint:mnd
+				devices[idx].SyncGroup = 0
+				changed[dev.ID] = struct{}{}
+			}
+		}
+
+		for _, dev := range devices {
+			if _, ok := changed[dev.ID]; !ok {
+				continue
+			}
+
+			dev.User = user
+			if _, err := s.devicesRepo.SaveDevice(ctx, &dev); err != nil {
+				return model.SyncStatus{}, aerr.ApplyFor(ErrRepositoryError, err, "save device failed")
+			}
+		}
+
+		common.TraceLazyPrintf(ctx, "UpdateSyncStatus: devices saved")
+
+		return model.NewSyncStatus(devices), nil
+	})
+}
+
 // ------------------------------------------------------
 
 func (s *SubscriptionsSrv) getSubsctiptions(ctx context.Context, username, devicename string, since time.Time,
@@ -244,10 +307,19 @@ func (s *SubscriptionsSrv) getSubsctiptions(ctx context.Context, username, devic
 
 		if devicename != "" {
 			// validate is device exists when device name is given and mark is seen.
-			_, err := s.getUserDevice(ctx, user.ID, devicename)
+			device, err := s.getUserDevice(ctx, user.ID, devicename)
 			if err != nil {
 				return nil, err
 			}
+
+			if device.SyncGroup > 0 {
+				podcasts, err := s.subscriptionsRepo.ListDeviceSubscriptions(ctx, user.ID, device.ID, since)
+				if err != nil {
+					return nil, aerr.ApplyFor(ErrRepositoryError, err)
+				}
+
+				return podcasts.SubscribedOnly(), nil
+			}
 		}
 
 		podcasts, err := s.podcastsRepo.ListSubscribedPodcasts(ctx, user.ID, since)
@@ -315,18 +387,31 @@ func (s *SubscriptionsSrv) getPodcasts(
 	username, devicename string,
 	since time.Time,
 ) (
-	[]model.Podcast, error,
+	model.Podcasts, error,
 ) {
 	//nolint:wrapcheck
-	return db.InConnectionR(ctx, s.dbi, func(ctx context.Context) ([]model.Podcast, error) {
+	return db.InConnectionR(ctx, s.dbi, func(ctx context.Context) (model.Podcasts, error) {
 		user, err := s.getUser(ctx, username)
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = s.getUserDevice(ctx, user.ID, devicename)
-		if err != nil {
-			return nil, err
+		// without device return all user podcasts
+		if devicename != "" {
+			device, err := s.getUserDevice(ctx, user.ID, devicename)
+			if err != nil {
+				return nil, err
+			}
+
+			if device.SyncGroup > 0 {
+				// synchronized device see only changes made by devices in the same group
+				podcasts, err := s.subscriptionsRepo.ListDeviceSubscriptions(ctx, user.ID, device.ID, since)
+				if err != nil {
+					return nil, aerr.ApplyFor(ErrRepositoryError, err, "list device subscriptions failed")
+				}
+
+				return podcasts, nil
+			}
 		}
 
 		podcasts, err := s.podcastsRepo.ListPodcasts(ctx, user.ID, since)
@@ -337,3 +422,277 @@ func (s *SubscriptionsSrv) getPodcasts(
 		return podcasts, nil
 	})
 }
+
+// deviceSubscriptions return podcasts subscribed by device. Devices not synchronized with other devices
+// share user subscriptions.
+func (s *SubscriptionsSrv) deviceSubscriptions(ctx context.Context, user *model.User, device *model.Device,
+) (model.Podcasts, error) {
+	if device.SyncGroup == 0 {
+		podcasts, err := s.podcastsRepo.ListSubscribedPodcasts(ctx, user.ID, time.Time{})
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return podcasts, nil
+	}
+
+	podcasts, err := s.subscriptionsRepo.ListDeviceSubscriptions(ctx, user.ID, device.ID, time.Time{})
+	if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return podcasts.SubscribedOnly(), nil
+}
+
+// syncedDevicesIDs return ids of devices that should receive subscription changes made by `device`.
+// Changes made without device (i.e. in web) or by device that is not synchronized are shared
+// by all devices (nil id).
+func (s *SubscriptionsSrv) syncedDevicesIDs(ctx context.Context, user *model.User, device *model.Device,
+) ([]*int64, error) {
+	if device == nil || device.SyncGroup == 0 {
+		return []*int64{nil}, nil
+	}
+
+	devices, err := s.devicesRepo.ListDevices(ctx, user.ID)
+	if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	members := model.Devices(devices).SyncGroupMembers(device.SyncGroup)
+	ids := make([]*int64, 0, len(members))
+
+	for _, d := range members {
+		ids = append(ids, &d.ID)
+	}
+
+	return ids, nil
+}
+
+// mergeChangedURLs add `changes` to list of changed urls `changedurls`. Url changed twice (i.e. sanitized
+// and redirected) is reported once as [original url, final url].
+func mergeChangedURLs(changedurls, changes [][]string) [][]string {
+	for _, change := range changes {
+		idx := slices.IndexFunc(changedurls, func(c []string) bool { return c[1] == change[0] })
+		if idx >= 0 {
+			changedurls[idx][1] = change[1]
+		} else {
+			changedurls = append(changedurls, change)
+		}
+	}
+
+	return changedurls
+}
+
+// applySubscriptionChanges record changes in subscriptions history of devices synchronized with `device`
+// or, for not synchronized device, in history shared by all devices. User podcast subscription state
+// is updated to union of subscriptions of all devices.
+func (s *SubscriptionsSrv) applySubscriptionChanges(
+	ctx context.Context,
+	user *model.User,
+	device *model.Device,
+	add, remove []string,
+	timestamp time.Time,
+) error {
+	userpodcasts, err := s.podcastsRepo.ListPodcasts(ctx, user.ID, time.Time{})
+	if err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	common.TraceLazyPrintf(ctx, "applySubscriptionChanges: podcasts loaded")
+
+	devicesids, err := s.syncedDevicesIDs(ctx, user, device)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]model.SubscriptionChange, 0, (len(add)+len(remove))*len(devicesids))
+	changed := make([]model.Podcast, 0, len(add)+len(remove))
+
+	// removed
+	for _, sub := range remove {
+		podcast, ok := userpodcasts.FindPodcastByURL(sub)
+		if !ok {
+			continue
+		}
+
+		for _, did := range devicesids {
+			changes = append(changes, model.NewSubscriptionChange(podcast.ID, did, false, timestamp))
+		}
+
+		changed = append(changed, podcast)
+	}
+
+	for _, sub := range add {
+		podcast, ok := userpodcasts.FindPodcastByURL(sub)
+		if !ok { // new
+			podcast = model.Podcast{User: user, URL: sub}
+			podcast.SetSubscribed(timestamp)
+
+			id, err := s.podcastsRepo.SavePodcast(ctx, &podcast)
+			if err != nil {
+				return aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			podcast.ID = id
+		}
+
+		for _, did := range devicesids {
+			changes = append(changes, model.NewSubscriptionChange(podcast.ID, did, true, timestamp))
+		}
+
+		changed = append(changed, podcast)
+	}
+
+	if err := s.subscriptionsRepo.SaveSubscriptionChanges(ctx, changes...); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	common.TraceLazyPrintf(ctx, "applySubscriptionChanges: history saved")
+
+	return s.updatePodcastsSubscribed(ctx, changed, timestamp)
+}
+
+// updatePodcastsSubscribed set subscription state of user `podcasts` according to subscriptions
+// history of all devices.
+func (s *SubscriptionsSrv) updatePodcastsSubscribed(ctx context.Context, podcasts []model.Podcast,
+	timestamp time.Time,
+) error {
+	for _, podcast := range podcasts {
+		subscribed, err := s.subscriptionsRepo.IsPodcastSubscribed(ctx, podcast.ID)
+		if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		var modified bool
+		if subscribed {
+			modified = podcast.SetSubscribed(timestamp)
+		} else {
+			modified = podcast.SetUnsubscribed(timestamp)
+		}
+
+		if modified {
+			if _, err := s.podcastsRepo.SavePodcast(ctx, &podcast); err != nil {
+				return aerr.ApplyFor(ErrRepositoryError, err)
+			}
+		}
+	}
+
+	common.TraceLazyPrintf(ctx, "updatePodcastsSubscribed: podcast saved")
+
+	return nil
+}
+
+// synchronizeDevices put devices from `group` (and devices already synchronized with them) into one
+// sync group. All members get union of its current subscriptions.
+func (s *SubscriptionsSrv) synchronizeDevices(
+	ctx context.Context,
+	user *model.User,
+	devices model.Devices,
+	group []string,
+	changed map[int64]struct{},
+) error {
+	var (
+		syncgroup int64
+		maxgroup  int64
+	)
+
+	for _, dev := range devices {
+		maxgroup = max(maxgroup, dev.SyncGroup)
+	}
+
+	members := make(map[int64]int)
+
+	for _, devname := range group {
+		idx := slices.IndexFunc(devices, func(d model.Device) bool { return d.Name == devname })
+		if idx < 0 {
+			return common.ErrUnknownDevice
+		}
+
+		members[devices[idx].ID] = idx
+
+		if grp := devices[idx].SyncGroup; grp > 0 {
+			if syncgroup == 0 || grp < syncgroup {
+				syncgroup = grp
+			}
+
+			// merge with already synchronized devices
+			for _, d := range devices.SyncGroupMembers(grp) {
+				members[d.ID] = slices.IndexFunc(devices, func(dd model.Device) bool { return dd.ID == d.ID })
+			}
+		}
+	}
+
+	if syncgroup == 0 {
+		syncgroup = maxgroup + 1
+	}
+
+	// collect current subscriptions of all members
+	subscriptions := make(map[int64]map[int64]struct{}, len(members))
+	union := make(map[int64]struct{})
+
+	for _, idx := range members {
+		dev := &devices[idx]
+
+		subs, err := s.deviceSubscriptions(ctx, user, dev)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range subs {
+			union[p.ID] = struct{}{}
+		}
+
+		// subscriptions recorded for device
+		devsubs, err := s.subscriptionsRepo.ListDeviceSubscriptions(ctx, user.ID, dev.ID, time.Time{})
+		if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		subscriptions[dev.ID] = make(map[int64]struct{})
+		for _, p := range devsubs.SubscribedOnly() {
+			subscriptions[dev.ID][p.ID] = struct{}{}
+		}
+	}
+
+	now := time.Now().UTC()
+	changes := make([]model.SubscriptionChange, 0)
+
+	for _, idx := range members {
+		dev := &devices[idx]
+		changes = append(changes, subscriptionsDiff(&dev.ID, subscriptions[dev.ID], union, now)...)
+
+		if dev.SyncGroup != syncgroup {
+			dev.SyncGroup = syncgroup
+			changed[dev.ID] = struct{}{}
+		}
+	}
+
+	if err := s.subscriptionsRepo.SaveSubscriptionChanges(ctx, changes...); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	common.TraceLazyPrintf(ctx, "synchronizeDevices: subscriptions merged")
+
+	return nil
+}
+
+// subscriptionsDiff create changes that turn `current` set of podcasts subscribed by device
+// into `target`.
+func subscriptionsDiff(deviceid *int64, current, target map[int64]struct{}, ts time.Time,
+) []model.SubscriptionChange {
+	var changes []model.SubscriptionChange
+
+	for pid := range target {
+		if _, ok := current[pid]; !ok {
+			changes = append(changes, model.NewSubscriptionChange(pid, deviceid, true, ts))
+		}
+	}
+
+	for pid := range current {
+		if _, ok := target[pid]; !ok {
+			changes = append(changes, model.NewSubscriptionChange(pid, deviceid, false, ts))
+		}
+	}
+
+	return changes
+}
@@ -8,6 +8,7 @@ package service
 //
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -15,7 +16,11 @@ import (
 
 	"gitlab.com/kabes/go-gpo/internal/assert"
 	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
 	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
 )
 
 func TestSubsServiceUser(t *testing.T) {
@@ -287,3 +292,257 @@ func TestSubsServiceUpdateDevSubsChanges(t *testing.T) {
 	assert.EqualSorted(t, state.RemovedURLs(), []string{"http://example.com/p1"})
 	assert.EqualSorted(t, state.AddedURLs(), []string{"http://example.com/p4", "http://example.com/p5"})
 }
+
+func TestSubsServiceSyncDevices(t *testing.T) {
+	ctx, i := prepareTests(t)
+	subsSrv := do.MustInvoke[*SubscriptionsSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "phone")
+	prepareTestDevice(ctx, t, i, "user1", "work")
+	prepareTestDevice(ctx, t, i, "user1", "kids")
+
+	cmd := command.ReplaceSubscriptionsCmd{
+		UserName:      "user1",
+		DeviceName:    "phone",
+		Subscriptions: []string{"http://example.com/p1", "http://example.com/p2"},
+		Timestamp:     time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC),
+	}
+	err := subsSrv.ReplaceSubscriptions(ctx, &cmd)
+	assert.NoErr(t, err)
+
+	status, err := subsSrv.GetSyncStatus(ctx, &query.GetSyncStatusQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(status.Synchronized), 0)
+	assert.EqualSorted(t, status.NotSynchronized, []string{"kids", "phone", "work"})
+
+	// unknown device
+	_, err = subsSrv.UpdateSyncStatus(ctx, &command.UpdateSyncDevicesCmd{
+		UserName:    "user1",
+		Synchronize: [][]string{{"phone", "tv"}},
+	})
+	assert.ErrSpec(t, err, common.ErrUnknownDevice)
+
+	status, err = subsSrv.UpdateSyncStatus(ctx, &command.UpdateSyncDevicesCmd{
+		UserName:    "user1",
+		Synchronize: [][]string{{"phone", "work"}},
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(status.Synchronized), 1)
+	assert.EqualSorted(t, status.Synchronized[0], []string{"phone", "work"})
+	assert.EqualSorted(t, status.NotSynchronized, []string{"kids"})
+
+	// work subscribe podcast; should be visible for phone
+	changes := command.ChangeSubscriptionsCmd{
+		UserName:   "user1",
+		DeviceName: "work",
+		Add:        []string{"http://example.com/p4"},
+		Remove:     []string{"http://example.com/p1"},
+		Timestamp:  time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+	_, err = subsSrv.ChangeSubscriptions(ctx, &changes)
+	assert.NoErr(t, err)
+
+	for _, dev := range []string{"phone", "work"} {
+		subs, err := subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: dev})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, subs.ToURLs(), []string{"http://example.com/p2", "http://example.com/p4"})
+
+		state, err := subsSrv.GetSubscriptionChanges(ctx, &query.GetSubscriptionChangesQuery{
+			UserName: "user1", DeviceName: dev,
+		})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, state.AddedURLs(), []string{"http://example.com/p2", "http://example.com/p4"})
+		assert.EqualSorted(t, state.RemovedURLs(), []string{"http://example.com/p1"})
+
+		podcasts, err := subsSrv.GetDevicePodcasts(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: dev})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, podcasts.ToURLs(),
+			[]string{"http://example.com/p1", "http://example.com/p2", "http://example.com/p4"})
+	}
+
+	// stop synchronization; group with one device is removed
+	status, err = subsSrv.UpdateSyncStatus(ctx, &command.UpdateSyncDevicesCmd{
+		UserName:        "user1",
+		StopSynchronize: []string{"work"},
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(status.Synchronized), 0)
+	assert.EqualSorted(t, status.NotSynchronized, []string{"kids", "phone", "work"})
+
+	// not synchronized kids see merged user subscriptions
+	subs, err := subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: "kids"})
+	assert.NoErr(t, err)
+	assert.EqualSorted(t, subs.ToURLs(),
+		[]string{"http://example.com/p1", "http://example.com/p2", "http://example.com/p4"})
+
+	// stop synchronization; group with one device is removed
+	status, err = subsSrv.UpdateSyncStatus(ctx, &command.UpdateSyncDevicesCmd{
+		UserName:        "user1",
+		StopSynchronize: []string{"work"},
+	})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(status.Synchronized), 0)
+	assert.EqualSorted(t, status.NotSynchronized, []string{"kids", "phone", "work"})
+
+	// devices share user subscriptions after synchronization is stopped
+	for _, dev := range []string{"kids", "phone", "work"} {
+		subs, err := subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: dev})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, subs.ToURLs(),
+			[]string{"http://example.com/p1", "http://example.com/p2", "http://example.com/p4"})
+	}
+}
+
+func TestSubsServiceSyncGroupSubscriptions(t *testing.T) {
+	ctx, i := prepareTests(t)
+	subsSrv := do.MustInvoke[*SubscriptionsSrv](i)
+	deviceSrv := do.MustInvoke[*DevicesSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "phone")
+	prepareTestDevice(ctx, t, i, "user1", "work")
+	prepareTestDevice(ctx, t, i, "user1", "kids")
+	prepareTestDevice(ctx, t, i, "user1", "tv")
+
+	now := time.Now().UTC()
+
+	cmd := command.ReplaceSubscriptionsCmd{
+		UserName:      "user1",
+		DeviceName:    "kids",
+		Subscriptions: []string{"http://example.com/p1", "http://example.com/p2"},
+		Timestamp:     now.Add(-time.Hour),
+	}
+	err := subsSrv.ReplaceSubscriptions(ctx, &cmd)
+	assert.NoErr(t, err)
+
+	_, err = subsSrv.UpdateSyncStatus(ctx, &command.UpdateSyncDevicesCmd{
+		UserName:    "user1",
+		Synchronize: [][]string{{"phone", "work"}},
+	})
+	assert.NoErr(t, err)
+
+	// changes made in group are visible only for group members
+	changes := command.ChangeSubscriptionsCmd{
+		UserName:   "user1",
+		DeviceName: "work",
+		Add:        []string{"http://example.com/p3"},
+		Timestamp:  now.Add(time.Minute),
+	}
+	_, err = subsSrv.ChangeSubscriptions(ctx, &changes)
+	assert.NoErr(t, err)
+
+	changes = command.ChangeSubscriptionsCmd{
+		UserName:   "user1",
+		DeviceName: "phone",
+		Remove:     []string{"http://example.com/p2"},
+		Timestamp:  now.Add(2 * time.Minute),
+	}
+	_, err = subsSrv.ChangeSubscriptions(ctx, &changes)
+	assert.NoErr(t, err)
+
+	for _, dev := range []string{"phone", "work"} {
+		subs, err := subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: dev})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, subs.ToURLs(), []string{"http://example.com/p1", "http://example.com/p3"})
+
+		state, err := subsSrv.GetSubscriptionChanges(ctx, &query.GetSubscriptionChangesQuery{
+			UserName: "user1", DeviceName: dev, Since: now,
+		})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, state.AddedURLs(), []string{"http://example.com/p3"})
+		assert.EqualSorted(t, state.RemovedURLs(), []string{"http://example.com/p2"})
+	}
+
+	// not synchronized devices share merged user subscriptions
+	for _, dev := range []string{"kids", "tv"} {
+		subs, err := subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: dev})
+		assert.NoErr(t, err)
+		assert.EqualSorted(t, subs.ToURLs(),
+			[]string{"http://example.com/p1", "http://example.com/p2", "http://example.com/p3"})
+	}
+
+	subs, err := subsSrv.GetUserSubscriptions(ctx, &query.GetUserSubscriptionsQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+	assert.EqualSorted(t, subs.ToURLs(),
+		[]string{"http://example.com/p1", "http://example.com/p2", "http://example.com/p3"})
+
+	devices, err := deviceSrv.ListDevices(ctx, &query.GetDevicesQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+
+	for _, dev := range devices {
+		if dev.SyncGroup > 0 {
+			assert.Equal(t, dev.Subscriptions, 2)
+		} else {
+			assert.Equal(t, dev.Subscriptions, 3)
+		}
+	}
+
+	// changes made by not synchronized device apply also to group
+	changes = command.ChangeSubscriptionsCmd{
+		UserName:   "user1",
+		DeviceName: "tv",
+		Remove:     []string{"http://example.com/p1"},
+		Timestamp:  now.Add(3 * time.Minute),
+	}
+	_, err = subsSrv.ChangeSubscriptions(ctx, &changes)
+	assert.NoErr(t, err)
+
+	subs, err = subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: "phone"})
+	assert.NoErr(t, err)
+	assert.EqualSorted(t, subs.ToURLs(), []string{"http://example.com/p3"})
+
+	subs, err = subsSrv.GetSubscriptions(ctx, &query.GetSubscriptionsQuery{UserName: "user1", DeviceName: "kids"})
+	assert.NoErr(t, err)
+	assert.EqualSorted(t, subs.ToURLs(), []string{"http://example.com/p2", "http://example.com/p3"})
+}
+
+func TestSubsRepoIsPodcastSubscribed(t *testing.T) {
+	ctx, i := prepareTests(t)
+	subsRepo := do.MustInvoke[repository.Subscriptions](i)
+	podcastsRepo := do.MustInvoke[repository.Podcasts](i)
+	devicesRepo := do.MustInvoke[repository.Devices](i)
+	dbi := do.MustInvoke[repository.Database](i)
+	userid := prepareTestUser(ctx, t, i, "user1")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+
+	ts := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	err := db.InTransaction(ctx, dbi, func(ctx context.Context) error {
+		podcast := model.Podcast{User: &model.User{ID: userid}, URL: "http://example.com/p1"}
+		podcastid, err := podcastsRepo.SavePodcast(ctx, &podcast)
+		assert.NoErr(t, err)
+
+		dev1, err := devicesRepo.GetDevice(ctx, userid, "dev1")
+		assert.NoErr(t, err)
+
+		checkSubscribed := func(expected bool, changes ...model.SubscriptionChange) {
+			t.Helper()
+
+			err := subsRepo.SaveSubscriptionChanges(ctx, changes...)
+			assert.NoErr(t, err)
+
+			subscribed, err := subsRepo.IsPodcastSubscribed(ctx, podcastid)
+			assert.NoErr(t, err)
+			assert.Equal(t, subscribed, expected)
+		}
+
+		// subscribed for all devices; unsubscribed by the only device
+		checkSubscribed(true, model.NewSubscriptionChange(podcastid, nil, true, ts))
+		checkSubscribed(false, model.NewSubscriptionChange(podcastid, &dev1.ID, false, ts.Add(time.Minute)))
+
+		// subscribed for all devices; other device still subscribe podcast
+		dev2 := model.Device{User: &model.User{ID: userid}, Name: "dev2", DevType: "other"}
+		dev2.ID, err = devicesRepo.SaveDevice(ctx, &dev2)
+		assert.NoErr(t, err)
+
+		checkSubscribed(true, model.NewSubscriptionChange(podcastid, nil, true, ts.Add(2*time.Minute)))
+		checkSubscribed(true, model.NewSubscriptionChange(podcastid, &dev1.ID, false, ts.Add(3*time.Minute)))
+		checkSubscribed(false, model.NewSubscriptionChange(podcastid, &dev2.ID, false, ts.Add(4*time.Minute)))
+
+		// subscribed by device; unsubscribed for all devices
+		checkSubscribed(true, model.NewSubscriptionChange(podcastid, &dev2.ID, true, ts.Add(5*time.Minute)))
+		checkSubscribed(false, model.NewSubscriptionChange(podcastid, nil, false, ts.Add(6*time.Minute)))
+
+		return nil
+	})
+	assert.NoErr(t, err)
+}
@@ -0,0 +1,238 @@
+//
+// tokens.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+// appTokenLen is number of random bytes in app token.
+const appTokenLen = 20
+
+// AppTokensSrv manage application passwords (tokens) that can be used by clients instead of user password.
+type AppTokensSrv struct {
+	dbi        repository.Database
+	usersRepo  repository.Users
+	tokensRepo repository.AppTokens
+	passHasher PasswordHasher
+}
+
+func NewAppTokensSrv(i do.Injector) (*AppTokensSrv, error) {
+	return &AppTokensSrv{
+		dbi:        do.MustInvoke[repository.Database](i),
+		usersRepo:  do.MustInvoke[repository.Users](i),
+		tokensRepo: do.MustInvoke[repository.AppTokens](i),
+		passHasher: BCryptPasswordHasher{},
+	}, nil
+}
+
+// GetAppTokens return all user tokens.
+func (a *AppTokensSrv) GetAppTokens(ctx context.Context, query *query.GetAppTokensQuery) ([]model.AppToken, error) {
+	if err := query.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate query failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InConnectionR(ctx, a.dbi, func(ctx context.Context) ([]model.AppToken, error) {
+		user, err := a.getUser(ctx, query.UserName)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens, err := a.tokensRepo.ListAppTokens(ctx, user.ID)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return tokens, nil
+	})
+}
+
+// CreateAppToken generate new token for user. Generated token is returned only once; database keep
+// only hash.
+func (a *AppTokensSrv) CreateAppToken(ctx context.Context, cmd *command.CreateAppTokenCmd,
+) (command.CreateAppTokenCmdResult, error) {
+	cmd.Sanitize()
+
+	if err := cmd.Validate(); err != nil {
+		return command.CreateAppTokenCmdResult{}, aerr.Wrapf(err, "validate command failed")
+	}
+
+	secret, err := generateAppToken()
+	if err != nil {
+		return command.CreateAppTokenCmdResult{}, err
+	}
+
+	hashed, err := a.passHasher.HashPassword(secret)
+	if err != nil {
+		return command.CreateAppTokenCmdResult{}, aerr.Wrapf(err, "hash token failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, a.dbi, func(ctx context.Context) (command.CreateAppTokenCmdResult, error) {
+		user, err := a.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return command.CreateAppTokenCmdResult{}, err
+		}
+
+		tokens, err := a.tokensRepo.ListAppTokens(ctx, user.ID)
+		if err != nil {
+			return command.CreateAppTokenCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if findAppToken(tokens, cmd.Name) != nil {
+			return command.CreateAppTokenCmdResult{}, common.ErrAppTokenExists
+		}
+
+		token := model.AppToken{
+			User:       user,
+			Name:       cmd.Name,
+			Token:      hashed,
+			DeviceName: cmd.DeviceName,
+			ReadOnly:   cmd.ReadOnly,
+		}
+
+		if _, err := a.tokensRepo.SaveAppToken(ctx, &token); err != nil {
+			return command.CreateAppTokenCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return command.CreateAppTokenCmdResult{Token: secret}, nil
+	})
+}
+
+// DeleteAppToken revoke user token.
+func (a *AppTokensSrv) DeleteAppToken(ctx context.Context, cmd *command.DeleteAppTokenCmd) error {
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate command failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, a.dbi, func(ctx context.Context) error {
+		user, err := a.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return err
+		}
+
+		tokens, err := a.tokensRepo.ListAppTokens(ctx, user.ID)
+		if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		token := findAppToken(tokens, cmd.Name)
+		if token == nil {
+			return common.ErrUnknownAppToken
+		}
+
+		if err := a.tokensRepo.DeleteAppToken(ctx, token.ID); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return nil
+	})
+}
+
+// LoginWithToken check is `secret` valid token for user; return matching token. Errors are compatible
+// with UsersSrv.LoginUser.
+func (a *AppTokensSrv) LoginWithToken(ctx context.Context, username, secret string) (*model.AppToken, error) {
+	ctx, end := common.NewTask(ctx, "LoginWithToken")
+	defer end()
+
+	if username == "" {
+		return nil, common.ErrEmptyUsername
+	}
+
+	if secret == "" {
+		return nil, aerr.ErrValidation.WithMsg("token can't be empty")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, a.dbi, func(ctx context.Context) (*model.AppToken, error) {
+		user, err := a.usersRepo.GetUser(ctx, username)
+		if errors.Is(err, common.ErrNoData) {
+			return nil, common.ErrUserNotFound
+		} else if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if user.Password == model.UserLockedPassword {
+			return nil, common.ErrUserAccountLocked
+		}
+
+		tokens, err := a.tokensRepo.ListAppTokens(ctx, user.ID)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		for _, token := range tokens {
+			if !a.passHasher.CheckPassword(secret, token.Token) {
+				continue
+			}
+
+			common.TraceLazyPrintf(ctx, "LoginWithToken: token verified")
+			zerolog.Ctx(ctx).Debug().Object("token", &token).
+				Msgf("AppTokensSrv: user_name=%s authenticated by token=%s", username, token.Name)
+
+			token.User = user
+			token.LastUsedAt = time.Now().UTC()
+
+			if _, err := a.tokensRepo.SaveAppToken(ctx, &token); err != nil {
+				return nil, aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			return &token, nil
+		}
+
+		return nil, common.ErrUnauthorized
+	})
+}
+
+//------------------------------------------------------------------------------
+
+func (a *AppTokensSrv) getUser(ctx context.Context, username string) (*model.User, error) {
+	user, err := a.usersRepo.GetUser(ctx, username)
+	if errors.Is(err, common.ErrNoData) {
+		return nil, common.ErrUnknownUser
+	} else if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return user, nil
+}
+
+func findAppToken(tokens []model.AppToken, name string) *model.AppToken {
+	for i := range tokens {
+		if tokens[i].Name == name {
+			return &tokens[i]
+		}
+	}
+
+	return nil
+}
+
+func generateAppToken() (string, error) {
+	buf := make([]byte, appTokenLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", aerr.Wrapf(err, "generate token failed").WithTag(aerr.InternalError)
+	}
+
+	return model.AppTokenPrefix + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,80 @@
+//nolint:nilaway
+package service
+
+//
+// tokens_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"testing"
+
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+)
+
+func TestAppTokensService(t *testing.T) {
+	ctx, i := prepareTests(t)
+	tokensSrv := do.MustInvoke[*AppTokensSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+
+	cmd := command.CreateAppTokenCmd{UserName: "user1", Name: " phone ", DeviceName: "dev1", ReadOnly: true}
+	res, err := tokensSrv.CreateAppToken(ctx, &cmd)
+	assert.NoErr(t, err)
+	assert.True(t, model.IsAppToken(res.Token))
+
+	_, err = tokensSrv.CreateAppToken(ctx, &cmd)
+	assert.ErrSpec(t, err, common.ErrAppTokenExists)
+
+	res2, err := tokensSrv.CreateAppToken(ctx, &command.CreateAppTokenCmd{UserName: "user1", Name: "laptop"})
+	assert.NoErr(t, err)
+	assert.NotEqual(t, res2.Token, res.Token)
+
+	tokens, err := tokensSrv.GetAppTokens(ctx, &query.GetAppTokensQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(tokens), 2)
+	assert.Equal(t, tokens[0].Name, "laptop")
+	assert.Equal(t, tokens[1].Name, "phone")
+	assert.Equal(t, tokens[1].DeviceName, "dev1")
+	assert.True(t, tokens[1].ReadOnly)
+	assert.True(t, tokens[1].LastUsedAt.IsZero())
+	// only hash is stored
+	assert.NotEqual(t, tokens[1].Token, res.Token)
+
+	token, err := tokensSrv.LoginWithToken(ctx, "user1", res.Token)
+	assert.NoErr(t, err)
+	assert.Equal(t, token.Name, "phone")
+	assert.Equal(t, token.DeviceName, "dev1")
+	assert.True(t, token.ReadOnly)
+
+	tokens, err = tokensSrv.GetAppTokens(ctx, &query.GetAppTokensQuery{UserName: "user1"})
+	assert.NoErr(t, err)
+	assert.True(t, !tokens[1].LastUsedAt.IsZero())
+
+	_, err = tokensSrv.LoginWithToken(ctx, "user2", res.Token)
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+
+	_, err = tokensSrv.LoginWithToken(ctx, "user1", "gpo-invalid")
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+
+	_, err = tokensSrv.LoginWithToken(ctx, "user3", res.Token)
+	assert.ErrSpec(t, err, common.ErrUserNotFound)
+
+	err = tokensSrv.DeleteAppToken(ctx, &command.DeleteAppTokenCmd{UserName: "user1", Name: "phone"})
+	assert.NoErr(t, err)
+
+	_, err = tokensSrv.LoginWithToken(ctx, "user1", res.Token)
+	assert.ErrSpec(t, err, common.ErrUnauthorized)
+
+	err = tokensSrv.DeleteAppToken(ctx, &command.DeleteAppTokenCmd{UserName: "user1", Name: "phone"})
+	assert.ErrSpec(t, err, common.ErrUnknownAppToken)
+
+	_, err = tokensSrv.CreateAppToken(ctx, &command.CreateAppTokenCmd{UserName: "user1", Name: "bad", DeviceName: "d 1"})
+	assert.Err(t, err)
+}
@@ -0,0 +1,310 @@
+//
+// totp.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"image/png"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/db"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/repository"
+)
+
+const (
+	totpIssuer          = "go-gpo"
+	totpRecoveryCodes   = 8
+	totpRecoveryCodeLen = 10
+	totpQRCodeSize      = 200
+	// totpPeriod is validity time of totp code in seconds.
+	totpPeriod = 30
+)
+
+// TOTPSrv manage two-factor authentication by time-based one-time passwords (TOTP).
+type TOTPSrv struct {
+	dbi       repository.Database
+	usersRepo repository.Users
+}
+
+func NewTOTPSrv(i do.Injector) (*TOTPSrv, error) {
+	return &TOTPSrv{
+		dbi:       do.MustInvoke[repository.Database](i),
+		usersRepo: do.MustInvoke[repository.Users](i),
+	}, nil
+}
+
+// GenerateTOTPKey create new random secret for user. Key is not saved; it must be confirmed by EnableTOTP.
+func (t *TOTPSrv) GenerateTOTPKey(username string) (model.TOTPKey, error) {
+	if username == "" {
+		return model.TOTPKey{}, common.ErrEmptyUsername
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: totpIssuer, AccountName: username})
+	if err != nil {
+		return model.TOTPKey{}, aerr.Wrapf(err, "generate totp key failed").WithTag(aerr.InternalError)
+	}
+
+	return model.TOTPKey{Secret: key.Secret(), URL: key.URL()}, nil
+}
+
+// EnableTOTP verify code and save secret for user. Return generated recovery codes.
+func (t *TOTPSrv) EnableTOTP(ctx context.Context, cmd *command.EnableTOTPCmd) (command.EnableTOTPCmdResult, error) {
+	if err := cmd.Validate(); err != nil {
+		return command.EnableTOTPCmdResult{}, aerr.Wrapf(err, "validate command failed")
+	}
+
+	step := totpCodeStep(strings.TrimSpace(cmd.Code), cmd.Secret, time.Now())
+	if step < 0 {
+		return command.EnableTOTPCmdResult{}, common.ErrInvalidTOTPCode
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, t.dbi, func(ctx context.Context) (command.EnableTOTPCmdResult, error) {
+		user, err := t.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return command.EnableTOTPCmdResult{}, err
+		}
+
+		if user.TOTPEnabled() {
+			return command.EnableTOTPCmdResult{}, common.ErrTOTPEnabled
+		}
+
+		codes := generateRecoveryCodes()
+
+		user.TOTPSecret = cmd.Secret
+		user.TOTPRecoveryCodes = make([]string, len(codes))
+
+		for i, c := range codes {
+			user.TOTPRecoveryCodes[i] = hashSecret(c)
+		}
+
+		if _, err := t.usersRepo.SaveUser(ctx, user); err != nil {
+			return command.EnableTOTPCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		// code used to confirm key can't be used to login
+		if _, err := t.usersRepo.UpdateTOTPLastStep(ctx, user.ID, step); err != nil {
+			return command.EnableTOTPCmdResult{}, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, user.UserName).
+			Msgf("TOTPSrv: two-factor authentication enabled user_name=%s", user.UserName)
+
+		return command.EnableTOTPCmdResult{RecoveryCodes: codes}, nil
+	})
+}
+
+// DisableTOTP remove two-factor authentication for user.
+func (t *TOTPSrv) DisableTOTP(ctx context.Context, cmd *command.DisableTOTPCmd) error {
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate command failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, t.dbi, func(ctx context.Context) error {
+		user, err := t.getUser(ctx, cmd.UserName)
+		if err != nil {
+			return err
+		}
+
+		if !user.TOTPEnabled() {
+			return nil
+		}
+
+		if cmd.CheckCode && !checkTOTPCode(user, cmd.Code) {
+			return common.ErrInvalidTOTPCode
+		}
+
+		user.TOTPSecret = ""
+		user.TOTPRecoveryCodes = nil
+
+		if _, err := t.usersRepo.SaveUser(ctx, user); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, user.UserName).
+			Msgf("TOTPSrv: two-factor authentication disabled user_name=%s", user.UserName)
+
+		return nil
+	})
+}
+
+// VerifyTOTP check `code` for user. Code may be current totp code or one of recovery codes; used
+// recovery code is removed.
+func (t *TOTPSrv) VerifyTOTP(ctx context.Context, username, code string) error {
+	ctx, end := common.NewTask(ctx, "VerifyTOTP")
+	defer end()
+
+	if username == "" {
+		return common.ErrEmptyUsername
+	}
+
+	//nolint:wrapcheck
+	return db.InTransaction(ctx, t.dbi, func(ctx context.Context) error {
+		user, err := t.usersRepo.GetUser(ctx, username)
+		if errors.Is(err, common.ErrNoData) {
+			return common.ErrUserNotFound
+		} else if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if user.Locked {
+			return common.ErrUserAccountLocked
+		}
+
+		if !user.TOTPEnabled() {
+			return common.ErrInvalidTOTPCode.WithMsg("totp not enabled")
+		}
+
+		if !checkTOTPCode(user, code) {
+			return common.ErrInvalidTOTPCode
+		}
+
+		if !isTOTPCode(code) {
+			// recovery code is valid only once
+			zerolog.Ctx(ctx).Info().Str(common.LogKeyUserName, username).
+				Msgf("TOTPSrv: recovery code used user_name=%s", username)
+
+			if _, err := t.usersRepo.SaveUser(ctx, user); err != nil {
+				return aerr.ApplyFor(ErrRepositoryError, err)
+			}
+
+			return nil
+		}
+
+		// totp code is valid only once
+		updated, err := t.usersRepo.UpdateTOTPLastStep(ctx, user.ID, user.TOTPLastStep)
+		if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		} else if !updated {
+			return common.ErrInvalidTOTPCode.WithMsg("totp code already used")
+		}
+
+		return nil
+	})
+}
+
+// TOTPQRCode generate png image with qr code for key url.
+func TOTPQRCode(url string) ([]byte, error) {
+	key, err := otp.NewKeyFromURL(url)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "parse totp key url failed").WithTag(aerr.ValidationError)
+	}
+
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return nil, aerr.Wrapf(err, "generate qr code failed").WithTag(aerr.InternalError)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, aerr.Wrapf(err, "encode qr code failed").WithTag(aerr.InternalError)
+	}
+
+	return buf.Bytes(), nil
+}
+
+//------------------------------------------------------------------------------
+
+func (t *TOTPSrv) getUser(ctx context.Context, username string) (*model.User, error) {
+	user, err := t.usersRepo.GetUser(ctx, username)
+	if errors.Is(err, common.ErrNoData) {
+		return nil, common.ErrUnknownUser
+	} else if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return user, nil
+}
+
+// checkTOTPCode check is `code` valid totp or recovery code for user. Matched recovery code is
+// removed from user. Totp code from time step not later than last used is rejected; time step of
+// matched code is set in user.
+func checkTOTPCode(user *model.User, code string) bool {
+	code = strings.TrimSpace(code)
+
+	if isTOTPCode(code) {
+		step := totpCodeStep(code, user.TOTPSecret, time.Now())
+		if step < 0 || step <= user.TOTPLastStep {
+			return false
+		}
+
+		user.TOTPLastStep = step
+
+		return true
+	}
+
+	hashed := hashSecret(normalizeRecoveryCode(code))
+	if idx := slices.Index(user.TOTPRecoveryCodes, hashed); idx >= 0 {
+		user.TOTPRecoveryCodes = slices.Delete(user.TOTPRecoveryCodes, idx, idx+1)
+
+		return true
+	}
+
+	return false
+}
+
+// totpCodeStep return time step in which `code` is valid for `secret`. Codes from previous and next
+// step are accepted (clock skew). Return -1 for invalid code.
+func totpCodeStep(code, secret string, now time.Time) int64 {
+	opts := totp.ValidateOpts{Period: totpPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+	step := now.Unix() / totpPeriod
+
+	for _, s := range []int64{step, step - 1, step + 1} {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(s*totpPeriod, 0), opts)
+		if err == nil && subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return s
+		}
+	}
+
+	return -1
+}
+
+// isTOTPCode check is `code` look like totp code (6 digits).
+func isTOTPCode(code string) bool {
+	code = strings.TrimSpace(code)
+
+	return len(code) == 6 && strings.Trim(code, "0123456789") == "" //nolint:mnd
+}
+
+func generateRecoveryCodes() []string {
+	codes := make([]string, totpRecoveryCodes)
+	for i := range codes {
+		code := rand.Text()[:totpRecoveryCodeLen]
+		codes[i] = code[:totpRecoveryCodeLen/2] + "-" + code[totpRecoveryCodeLen/2:]
+	}
+
+	return codes
+}
+
+// normalizeRecoveryCode remove separators and spaces from recovery code entered by user.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, " ", "")
+	code = strings.ReplaceAll(code, "-", "")
+
+	if len(code) != totpRecoveryCodeLen {
+		return code
+	}
+
+	return code[:totpRecoveryCodeLen/2] + "-" + code[totpRecoveryCodeLen/2:]
+}
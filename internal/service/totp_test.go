@@ -0,0 +1,105 @@
+//nolint:nilaway
+package service
+
+//
+// totp_test.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/assert"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+)
+
+func TestTOTPService(t *testing.T) {
+	ctx, i := prepareTests(t)
+	totpSrv := do.MustInvoke[*TOTPSrv](i)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+
+	key, err := totpSrv.GenerateTOTPKey("user1")
+	assert.NoErr(t, err)
+	assert.True(t, strings.HasPrefix(key.URL, "otpauth://totp/"))
+
+	qrcode, err := TOTPQRCode(key.URL)
+	assert.NoErr(t, err)
+	assert.True(t, len(qrcode) > 0)
+
+	_, err = totpSrv.EnableTOTP(ctx, &command.EnableTOTPCmd{UserName: "user1", Secret: key.Secret, Code: "000000"})
+	assert.ErrSpec(t, err, common.ErrInvalidTOTPCode)
+
+	code, err := totp.GenerateCode(key.Secret, time.Now())
+	assert.NoErr(t, err)
+
+	res, err := totpSrv.EnableTOTP(ctx, &command.EnableTOTPCmd{UserName: "user1", Secret: key.Secret, Code: code})
+	assert.NoErr(t, err)
+	assert.Equal(t, len(res.RecoveryCodes), totpRecoveryCodes)
+
+	user, err := usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.True(t, user.TOTPEnabled())
+	assert.Equal(t, len(user.TOTPRecoveryCodes), totpRecoveryCodes)
+
+	_, err = totpSrv.EnableTOTP(ctx, &command.EnableTOTPCmd{UserName: "user1", Secret: key.Secret, Code: code})
+	assert.ErrSpec(t, err, common.ErrTOTPEnabled)
+
+	// code used to enable totp can't be used again
+	assert.ErrSpec(t, totpSrv.VerifyTOTP(ctx, "user1", code), "invalid totp code")
+	assert.ErrSpec(t, totpSrv.VerifyTOTP(ctx, "user1", "123"), common.ErrInvalidTOTPCode)
+
+	// code from next time step is accepted only once
+	code, err = totp.GenerateCode(key.Secret, time.Now().Add(totpPeriod*time.Second))
+	assert.NoErr(t, err)
+	assert.NoErr(t, totpSrv.VerifyTOTP(ctx, "user1", code))
+	assert.ErrSpec(t, totpSrv.VerifyTOTP(ctx, "user1", code), "invalid totp code")
+
+	// recovery code may be used only once; case and separators are ignored
+	recovery := strings.ToLower(strings.ReplaceAll(res.RecoveryCodes[0], "-", ""))
+	assert.NoErr(t, totpSrv.VerifyTOTP(ctx, "user1", recovery))
+	assert.ErrSpec(t, totpSrv.VerifyTOTP(ctx, "user1", recovery), common.ErrInvalidTOTPCode)
+
+	user, err = usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.Equal(t, len(user.TOTPRecoveryCodes), totpRecoveryCodes-1)
+
+	err = totpSrv.DisableTOTP(ctx, &command.DisableTOTPCmd{UserName: "user1", Code: "000000", CheckCode: true})
+	assert.ErrSpec(t, err, common.ErrInvalidTOTPCode)
+
+	err = totpSrv.DisableTOTP(ctx, &command.DisableTOTPCmd{UserName: "user1", Code: res.RecoveryCodes[1], CheckCode: true})
+	assert.NoErr(t, err)
+
+	user, err = usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.True(t, !user.TOTPEnabled())
+	assert.Equal(t, len(user.TOTPRecoveryCodes), 0)
+}
+
+func TestTOTPServiceReset(t *testing.T) {
+	ctx, i := prepareTests(t)
+	totpSrv := do.MustInvoke[*TOTPSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+
+	key, err := totpSrv.GenerateTOTPKey("user1")
+	assert.NoErr(t, err)
+
+	code, err := totp.GenerateCode(key.Secret, time.Now())
+	assert.NoErr(t, err)
+
+	_, err = totpSrv.EnableTOTP(ctx, &command.EnableTOTPCmd{UserName: "user1", Secret: key.Secret, Code: code})
+	assert.NoErr(t, err)
+
+	// reset by admin - without code
+	assert.NoErr(t, totpSrv.DisableTOTP(ctx, &command.DisableTOTPCmd{UserName: "user1"}))
+	assert.ErrSpec(t, totpSrv.VerifyTOTP(ctx, "user1", code), common.ErrInvalidTOTPCode.WithMsg("totp not enabled"))
+
+	err = totpSrv.DisableTOTP(ctx, &command.DisableTOTPCmd{UserName: "user2"})
+	assert.ErrSpec(t, err, common.ErrUnknownUser)
+}
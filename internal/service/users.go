@@ -9,6 +9,7 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 
 	"github.com/samber/do/v2"
@@ -22,16 +23,22 @@ import (
 )
 
 type UsersSrv struct {
-	dbi        repository.Database
-	usersRepo  repository.Users
-	passHasher PasswordHasher
+	dbi          repository.Database
+	usersRepo    repository.Users
+	sessionsRepo repository.Sessions
+	tokensRepo   repository.RememberTokens
+	auditSrv     *AuditSrv
+	passHasher   PasswordHasher
 }
 
 func NewUsersSrv(i do.Injector) (*UsersSrv, error) {
 	return &UsersSrv{
-		dbi:        do.MustInvoke[repository.Database](i),
-		usersRepo:  do.MustInvoke[repository.Users](i),
-		passHasher: BCryptPasswordHasher{},
+		dbi:          do.MustInvoke[repository.Database](i),
+		usersRepo:    do.MustInvoke[repository.Users](i),
+		sessionsRepo: do.MustInvoke[repository.Sessions](i),
+		tokensRepo:   do.MustInvoke[repository.RememberTokens](i),
+		auditSrv:     do.MustInvoke[*AuditSrv](i),
+		passHasher:   BCryptPasswordHasher{},
 	}, nil
 }
 
@@ -108,40 +115,48 @@ func (u *UsersSrv) AddUser(ctx context.Context, cmd *command.NewUserCmd) (comman
 
 	//nolint:wrapcheck
 	return db.InTransactionR(ctx, u.dbi, func(ctx context.Context) (command.NewUserCmdResult, error) {
-		// is user exists?
-		_, err := u.usersRepo.GetUser(ctx, cmd.UserName)
-		switch {
-		case errors.Is(err, common.ErrNoData):
-			// ok; user not exists
-		case err == nil:
-			// user exists
-			return res, common.ErrUserExists
-		default:
-			// failed to get user
-			return res, aerr.ApplyFor(ErrRepositoryError, err)
-		}
+		return u.addUser(ctx, cmd)
+	})
+}
 
-		hashedPass, err := u.passHasher.HashPassword(cmd.Password)
-		if err != nil {
-			return res, aerr.Wrapf(err, "hash password failed")
-		}
+// addUser create user in current transaction.
+func (u *UsersSrv) addUser(ctx context.Context, cmd *command.NewUserCmd) (command.NewUserCmdResult, error) {
+	res := command.NewUserCmdResult{}
 
-		udb := model.User{
-			UserName: cmd.UserName,
-			Password: hashedPass,
-			Email:    cmd.Email,
-			Name:     cmd.Name,
-		}
+	// is user exists?
+	_, err := u.usersRepo.GetUser(ctx, cmd.UserName)
+	switch {
+	case errors.Is(err, common.ErrNoData):
+		// ok; user not exists
+	case err == nil:
+		// user exists
+		return res, common.ErrUserExists
+	default:
+		// failed to get user
+		return res, aerr.ApplyFor(ErrRepositoryError, err)
+	}
 
-		uid, err := u.usersRepo.SaveUser(ctx, &udb)
-		if err != nil {
-			return res, aerr.ApplyFor(ErrRepositoryError, err)
-		}
+	hashedPass, err := u.passHasher.HashPassword(cmd.Password)
+	if err != nil {
+		return res, aerr.Wrapf(err, "hash password failed")
+	}
+
+	udb := model.User{
+		UserName: cmd.UserName,
+		Password: hashedPass,
+		Email:    cmd.Email,
+		Name:     cmd.Name,
+		Admin:    cmd.Admin,
+	}
 
-		res.UserID = uid
+	uid, err := u.usersRepo.SaveUser(ctx, &udb)
+	if err != nil {
+		return res, aerr.ApplyFor(ErrRepositoryError, err)
+	}
 
-		return res, nil
-	})
+	res.UserID = uid
+
+	return res, nil
 }
 
 func (u *UsersSrv) ChangePassword(ctx context.Context, cmd *command.ChangeUserPasswordCmd) error {
@@ -153,32 +168,45 @@ func (u *UsersSrv) ChangePassword(ctx context.Context, cmd *command.ChangeUserPa
 		return aerr.Wrapf(err, "validate user/password for save failed")
 	}
 
-	//nolint: wrapcheck
-	return db.InTransaction(ctx, u.dbi, func(ctx context.Context) error {
+	// setting new password unlock locked account
+	unlocked, err := db.InTransactionR(ctx, u.dbi, func(ctx context.Context) (bool, error) {
 		// is user exists?
 		user, err := u.usersRepo.GetUser(ctx, cmd.UserName)
 
 		if errors.Is(err, common.ErrNoData) {
-			return common.ErrUnknownUser
+			return false, common.ErrUnknownUser
 		} else if err != nil {
-			return aerr.ApplyFor(ErrRepositoryError, err)
+			return false, aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
 		if cmd.CheckCurrentPass && !u.passHasher.CheckPassword(cmd.CurrentPassword, user.Password) {
-			return command.ErrChangePasswordOldNotMatch
+			return false, command.ErrChangePasswordOldNotMatch
 		}
 
+		locked := user.Password == model.UserLockedPassword
+
 		user.Password, err = u.passHasher.HashPassword(cmd.Password)
 		if err != nil {
-			return aerr.Wrapf(err, "hash password failed")
+			return false, aerr.Wrapf(err, "hash password failed")
 		}
 
 		if _, err = u.usersRepo.SaveUser(ctx, user); err != nil {
-			return aerr.ApplyFor(ErrRepositoryError, err)
+			return false, aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
-		return nil
+		return locked, nil
 	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	u.auditSrv.Record(ctx, model.AuditPasswordChange, cmd.UserName, "")
+
+	if unlocked {
+		u.auditSrv.Record(ctx, model.AuditAccountUnlock, cmd.UserName, "")
+	}
+
+	return nil
 }
 
 func (u *UsersSrv) GetUsers(ctx context.Context, activeOnly bool) ([]model.User, error) {
@@ -192,23 +220,38 @@ func (u *UsersSrv) GetUsers(ctx context.Context, activeOnly bool) ([]model.User,
 	return users, nil
 }
 
-func (u *UsersSrv) LockAccount(ctx context.Context, cmd command.LockAccountCmd) error {
+// GetUsersWithCounts return all users with number of devices and subscribed podcasts.
+func (u *UsersSrv) GetUsersWithCounts(ctx context.Context) ([]model.UserWithCounts, error) {
+	users, err := db.InConnectionR(ctx, u.dbi, u.usersRepo.ListUsersWithCounts)
+	if err != nil {
+		return nil, aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return users, nil
+}
+
+// SetAdmin grant or revoke administrator privileges for user.
+func (u *UsersSrv) SetAdmin(ctx context.Context, cmd *command.SetUserAdminCmd) error {
 	if err := cmd.Validate(); err != nil {
-		return aerr.Wrapf(err, "validate account to lock failed")
+		return aerr.Wrapf(err, "validate cmd failed")
 	}
 
 	//nolint:wrapcheck
 	return db.InTransaction(ctx, u.dbi, func(ctx context.Context) error {
-		udb, err := u.usersRepo.GetUser(ctx, cmd.UserName)
+		user, err := u.usersRepo.GetUser(ctx, cmd.UserName)
 		if errors.Is(err, common.ErrNoData) {
 			return common.ErrUnknownUser
 		} else if err != nil {
 			return aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
-		udb.Password = model.UserLockedPassword
+		if user.Admin == cmd.Admin {
+			return nil
+		}
+
+		user.Admin = cmd.Admin
 
-		if _, err = u.usersRepo.SaveUser(ctx, udb); err != nil {
+		if _, err := u.usersRepo.SaveUser(ctx, user); err != nil {
 			return aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
@@ -216,6 +259,90 @@ func (u *UsersSrv) LockAccount(ctx context.Context, cmd command.LockAccountCmd)
 	})
 }
 
+func (u *UsersSrv) LockAccount(ctx context.Context, cmd command.LockAccountCmd) error {
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate account to lock failed")
+	}
+
+	err := db.InTransaction(ctx, u.dbi, func(ctx context.Context) error {
+		user, err := u.usersRepo.GetUser(ctx, cmd.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return common.ErrUnknownUser
+		} else if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		// password is kept for UnlockAccount
+		if err := u.usersRepo.LockUser(ctx, user.ID); err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return u.revokeUserLogins(ctx, user)
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	u.auditSrv.Record(ctx, model.AuditAccountLock, cmd.UserName, "")
+
+	return nil
+}
+
+// UnlockAccount unlock account locked by LockAccount; user can login with password used before lock.
+func (u *UsersSrv) UnlockAccount(ctx context.Context, cmd command.UnlockAccountCmd) error {
+	if err := cmd.Validate(); err != nil {
+		return aerr.Wrapf(err, "validate account to unlock failed")
+	}
+
+	err := db.InTransaction(ctx, u.dbi, func(ctx context.Context) error {
+		user, err := u.usersRepo.GetUser(ctx, cmd.UserName)
+		if errors.Is(err, common.ErrNoData) {
+			return common.ErrUnknownUser
+		} else if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		if !user.Locked {
+			return aerr.ErrValidation.WithUserMsg("account is not locked")
+		}
+
+		unlocked, err := u.usersRepo.UnlockUser(ctx, user.ID)
+		if err != nil {
+			return aerr.ApplyFor(ErrRepositoryError, err)
+		} else if !unlocked {
+			return aerr.ErrValidation.WithUserMsg("password from before lock is unknown; set new password")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	u.auditSrv.Record(ctx, model.AuditAccountUnlock, cmd.UserName, "")
+
+	return nil
+}
+
+// lockAccount lock user account in current transaction. Password is not kept, so account can be
+// unlocked only by setting new password (i.e. for registrations waiting for approval).
+func (u *UsersSrv) lockAccount(ctx context.Context, username string) error {
+	udb, err := u.usersRepo.GetUser(ctx, username)
+	if errors.Is(err, common.ErrNoData) {
+		return common.ErrUnknownUser
+	} else if err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	udb.Password = model.UserLockedPassword
+
+	if _, err = u.usersRepo.SaveUser(ctx, udb); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return nil
+}
+
 func (u *UsersSrv) DeleteUser(ctx context.Context, cmd *command.DeleteUserCmd) error {
 	if err := cmd.Validate(); err != nil {
 		return aerr.Wrapf(err, "validate cmd failed")
@@ -230,6 +357,10 @@ func (u *UsersSrv) DeleteUser(ctx context.Context, cmd *command.DeleteUserCmd) e
 			return aerr.ApplyFor(ErrRepositoryError, err)
 		}
 
+		if err := u.revokeUserLogins(ctx, user); err != nil {
+			return err
+		}
+
 		if err = u.usersRepo.DeleteUser(ctx, user.ID); err != nil {
 			return aerr.ApplyFor(ErrRepositoryError, err)
 		}
@@ -238,6 +369,60 @@ func (u *UsersSrv) DeleteUser(ctx context.Context, cmd *command.DeleteUserCmd) e
 	})
 }
 
+// revokeUserLogins delete web sessions and remember me tokens of `user` in current transaction.
+func (u *UsersSrv) revokeUserLogins(ctx context.Context, user *model.User) error {
+	if err := u.tokensRepo.DeleteUserRememberTokens(ctx, user.ID); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	if _, err := u.sessionsRepo.DeleteUserSessions(ctx, user.UserName); err != nil {
+		return aerr.ApplyFor(ErrRepositoryError, err)
+	}
+
+	return nil
+}
+
+// ProvisionUser create or update account of user authenticated by external provider (i.e. ldap).
+// New accounts get random password. Email and name are updated only when given.
+func (u *UsersSrv) ProvisionUser(ctx context.Context, cmd *command.ProvisionUserCmd) (*model.User, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, aerr.Wrapf(err, "validate user to provision failed")
+	}
+
+	//nolint:wrapcheck
+	return db.InTransactionR(ctx, u.dbi, func(ctx context.Context) (*model.User, error) {
+		user, err := u.usersRepo.GetUser(ctx, cmd.UserName)
+		switch {
+		case errors.Is(err, common.ErrNoData):
+			hashedPass, err := u.passHasher.HashPassword(rand.Text())
+			if err != nil {
+				return nil, aerr.Wrapf(err, "hash password failed")
+			}
+
+			user = &model.User{UserName: cmd.UserName, Password: hashedPass}
+		case err != nil:
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		case user.Locked:
+			return nil, common.ErrUserAccountLocked
+		case (cmd.Email == "" || cmd.Email == user.Email) && (cmd.Name == "" || cmd.Name == user.Name) &&
+			cmd.Admin == user.Admin:
+			// not changed
+			return user, nil
+		}
+
+		user.Email = common.Coalesce(cmd.Email, user.Email)
+		user.Name = common.Coalesce(cmd.Name, user.Name)
+		user.Admin = cmd.Admin
+
+		user.ID, err = u.usersRepo.SaveUser(ctx, user)
+		if err != nil {
+			return nil, aerr.ApplyFor(ErrRepositoryError, err)
+		}
+
+		return user, nil
+	})
+}
+
 //-------------------------------------------------------------
 
 type PasswordHasher interface {
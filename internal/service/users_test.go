@@ -9,6 +9,7 @@ package service
 //
 import (
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/samber/do/v2"
@@ -16,6 +17,7 @@ import (
 	"gitlab.com/kabes/go-gpo/internal/assert"
 	"gitlab.com/kabes/go-gpo/internal/command"
 	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/repository"
 )
 
 func TestUsers(t *testing.T) {
@@ -129,3 +131,134 @@ func TestDeleteUser(t *testing.T) {
 	assert.NoErr(t, err)
 	assert.Equal(t, len(users), 0)
 }
+
+func TestLockAccountRevokeLogins(t *testing.T) {
+	ctx, i := prepareTests(t)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	rememberSrv := do.MustInvoke[*RememberTokensSrv](i)
+	sessProvider := NewSessionProvider(do.MustInvoke[repository.Database](i),
+		do.MustInvoke[repository.Sessions](i), 60*time.Second)
+
+	remember := make(map[string]string)
+
+	for _, user := range []string{"user1", "user2", "user3"} {
+		_ = prepareTestUser(ctx, t, i, user)
+
+		token, err := rememberSrv.CreateRememberToken(ctx, user)
+		assert.NoErr(t, err)
+
+		remember[user] = token
+
+		store, err := sessProvider.Read("sid-" + user)
+		assert.NoErr(t, err)
+		assert.NoErr(t, store.Set("user", user))
+		assert.NoErr(t, store.Release())
+	}
+
+	err := usersSrv.LockAccount(ctx, command.LockAccountCmd{UserName: "user1"})
+	assert.NoErr(t, err)
+
+	err = usersSrv.DeleteUser(ctx, &command.DeleteUserCmd{UserName: "user2"})
+	assert.NoErr(t, err)
+
+	// sessions and remember me tokens of locked and deleted users are rejected
+	for user, valid := range map[string]bool{"user1": false, "user2": false, "user3": true} {
+		exists, err := sessProvider.Exist("sid-" + user)
+		assert.NoErr(t, err)
+		assert.Equal(t, exists, valid)
+
+		_, err = rememberSrv.LoginWithRememberToken(ctx, remember[user])
+		if valid {
+			assert.NoErr(t, err)
+		} else {
+			assert.Err(t, err)
+		}
+	}
+}
+
+func TestProvisionUser(t *testing.T) {
+	ctx, i := prepareTests(t)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+
+	// create new user
+	cmd := command.ProvisionUserCmd{UserName: "user1", Email: "user1@example.com", Name: "User 1"}
+	user, err := usersSrv.ProvisionUser(ctx, &cmd)
+	assert.NoErr(t, err)
+	assert.True(t, user.ID > 0)
+	assert.Equal(t, user.Email, "user1@example.com")
+	assert.True(t, !user.Admin)
+
+	// update existing user
+	cmd = command.ProvisionUserCmd{UserName: "user1", Name: "User One", Admin: true}
+	user2, err := usersSrv.ProvisionUser(ctx, &cmd)
+	assert.NoErr(t, err)
+	assert.Equal(t, user2.ID, user.ID)
+
+	user2, err = usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.Equal(t, user2.Email, "user1@example.com")
+	assert.Equal(t, user2.Name, "User One")
+	assert.True(t, user2.Admin)
+
+	// locked account can't be provisioned
+	err = usersSrv.LockAccount(ctx, command.LockAccountCmd{UserName: "user1"})
+	assert.NoErr(t, err)
+
+	_, err = usersSrv.ProvisionUser(ctx, &cmd)
+	assert.ErrSpec(t, err, common.ErrUserAccountLocked)
+}
+
+func TestGetUsersWithCounts(t *testing.T) {
+	ctx, i := prepareTests(t)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+	_ = prepareTestUser(ctx, t, i, "user2")
+	prepareTestDevice(ctx, t, i, "user1", "dev1")
+	prepareTestDevice(ctx, t, i, "user1", "dev2")
+	prepareTestSub(
+		ctx, t, i, "user1", "dev1", "http://example.com/p1", "http://example.com/p2", "http://example.com/p3",
+	)
+
+	users, err := usersSrv.GetUsersWithCounts(ctx)
+	assert.NoErr(t, err)
+	assert.Equal(t, len(users), 2)
+	assert.Equal(t, users[0].User.UserName, "user1")
+	assert.Equal(t, users[0].Devices, 2)
+	assert.Equal(t, users[0].Subscriptions, 3)
+	assert.Equal(t, users[1].User.UserName, "user2")
+	assert.Equal(t, users[1].Devices, 0)
+	assert.Equal(t, users[1].Subscriptions, 0)
+}
+
+func TestSetAdmin(t *testing.T) {
+	ctx, i := prepareTests(t)
+	usersSrv := do.MustInvoke[*UsersSrv](i)
+	_ = prepareTestUser(ctx, t, i, "user1")
+
+	res, err := usersSrv.AddUser(ctx, &command.NewUserCmd{
+		UserName: "admin", Password: "admin123", Email: "admin@example.com", Admin: true,
+	})
+	assert.NoErr(t, err)
+	assert.True(t, res.UserID > 0)
+
+	user, err := usersSrv.CheckUser(ctx, "admin")
+	assert.NoErr(t, err)
+	assert.True(t, user.Admin)
+
+	err = usersSrv.SetAdmin(ctx, &command.SetUserAdminCmd{UserName: "user1", Admin: true})
+	assert.NoErr(t, err)
+
+	user, err = usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.True(t, user.Admin)
+
+	err = usersSrv.SetAdmin(ctx, &command.SetUserAdminCmd{UserName: "user1", Admin: false})
+	assert.NoErr(t, err)
+
+	user, err = usersSrv.CheckUser(ctx, "user1")
+	assert.NoErr(t, err)
+	assert.True(t, !user.Admin)
+
+	err = usersSrv.SetAdmin(ctx, &command.SetUserAdminCmd{UserName: "user3", Admin: true})
+	assert.ErrSpec(t, err, common.ErrUnknownUser)
+}
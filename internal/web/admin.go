@@ -0,0 +1,457 @@
+package web
+
+//
+// admin.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+// adminPages serve pages available only for administrators.
+type adminPages struct {
+	usersSrv        *service.UsersSrv
+	guardSrv        *service.LoginGuardSrv
+	registrationSrv *service.RegistrationSrv
+	auditSrv        *service.AuditSrv
+	webroot         string
+	renderer        *nt.Renderer
+}
+
+func newAdminPages(i do.Injector) (adminPages, error) {
+	return adminPages{
+		usersSrv:        do.MustInvoke[*service.UsersSrv](i),
+		guardSrv:        do.MustInvoke[*service.LoginGuardSrv](i),
+		registrationSrv: do.MustInvoke[*service.RegistrationSrv](i),
+		auditSrv:        do.MustInvoke[*service.AuditSrv](i),
+		webroot:         do.MustInvokeNamed[string](i, "server.webroot"),
+		renderer:        do.MustInvoke[*nt.Renderer](i),
+	}, nil
+}
+
+func (a adminPages) Routes() *chi.Mux {
+	r := chi.NewRouter()
+	r.Use(a.adminOnly)
+	r.Get(`/users`, srvsupport.WrapNamed(a.usersPage, "web_admin_users"))
+	r.Post(`/users`, srvsupport.WrapNamed(a.createUser, "web_admin_users_post"))
+	r.Post(`/users/lock`, srvsupport.WrapNamed(a.lockUser, "web_admin_users_lock"))
+	r.Post(`/users/unlock`, srvsupport.WrapNamed(a.unlockUser, "web_admin_users_unlock"))
+	r.Post(`/users/password`, srvsupport.WrapNamed(a.setUserPassword, "web_admin_users_pass"))
+	r.Post(`/users/admin`, srvsupport.WrapNamed(a.setUserAdmin, "web_admin_users_admin"))
+	r.Post(`/users/delete`, srvsupport.WrapNamed(a.deleteUser, "web_admin_users_del_post"))
+	r.Get(`/registrations`, srvsupport.WrapNamed(a.registrationsPage, "web_admin_registrations"))
+	r.Post(`/registrations/approve`, srvsupport.WrapNamed(a.approveRegistration, "web_admin_registrations_approve"))
+	r.Post(`/registrations/reject`, srvsupport.WrapNamed(a.rejectRegistration, "web_admin_registrations_reject"))
+	r.Post(`/registrations/invites`, srvsupport.WrapNamed(a.createInviteCode, "web_admin_invites_post"))
+	r.Post(`/registrations/invites/delete`, srvsupport.WrapNamed(a.deleteInviteCode, "web_admin_invites_del_post"))
+	r.Get(`/audit`, srvsupport.WrapNamed(a.auditPage, "web_admin_audit"))
+
+	return r
+}
+
+// adminOnly reject requests from users without administrator privileges.
+func (a adminPages) adminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		username := common.ContextUser(ctx)
+
+		user, err := a.usersSrv.CheckUser(ctx, username)
+		if err != nil {
+			srvsupport.CheckAndWriteError(w, r, err)
+			zerolog.Ctx(ctx).WithLevel(aerr.LogLevelForError(err)).Err(err).
+				Msgf("web.Admin: get user user_name=%s error=%q", username, err)
+
+			return
+		}
+
+		if !user.Admin {
+			zerolog.Ctx(ctx).Warn().Str(common.LogKeyUserName, username).
+				Msgf("web.Admin: access denied for non-admin user_name=%s url=%q", username, r.URL.Redacted())
+			srvsupport.WriteError(w, r, http.StatusForbidden, "")
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a adminPages) usersPage(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.writeUsersPage(ctx, w, r, logger, &nt.AdminUsersPage{})
+}
+
+func (a adminPages) createUser(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleUserAction(ctx, w, r, logger, "create", true, func(ctx context.Context, username string) error {
+		_, err := a.usersSrv.AddUser(ctx, &command.NewUserCmd{
+			UserName: username,
+			Password: r.FormValue("password"),
+			Email:    r.FormValue("email"),
+			Name:     r.FormValue("name"),
+			Admin:    r.FormValue("admin") != "",
+		})
+
+		return err //nolint:wrapcheck
+	})
+}
+
+func (a adminPages) lockUser(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleUserAction(ctx, w, r, logger, "lock", false, func(ctx context.Context, username string) error {
+		return a.usersSrv.LockAccount(ctx, command.LockAccountCmd{UserName: username}) //nolint:wrapcheck
+	})
+}
+
+// unlockUser unlock account without changing password; failed logins of user are also cleared.
+func (a adminPages) unlockUser(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleUserAction(ctx, w, r, logger, "unlock", false, func(ctx context.Context, username string) error {
+		if err := a.usersSrv.UnlockAccount(ctx, command.UnlockAccountCmd{UserName: username}); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return a.guardSrv.ClearLoginFailures(ctx, model.LoginFailureUser, username) //nolint:wrapcheck
+	})
+}
+
+// setUserPassword set new password for user; locked account is unlocked.
+func (a adminPages) setUserPassword(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleUserAction(ctx, w, r, logger, "set password", true, func(ctx context.Context, username string) error {
+		//nolint:wrapcheck
+		return a.usersSrv.ChangePassword(ctx, &command.ChangeUserPasswordCmd{
+			UserName: username,
+			Password: r.FormValue("password"),
+		})
+	})
+}
+
+func (a adminPages) setUserAdmin(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleUserAction(ctx, w, r, logger, "set admin", false, func(ctx context.Context, username string) error {
+		//nolint:wrapcheck
+		return a.usersSrv.SetAdmin(ctx, &command.SetUserAdminCmd{
+			UserName: username,
+			Admin:    r.FormValue("admin") != "",
+		})
+	})
+}
+
+func (a adminPages) deleteUser(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleUserAction(ctx, w, r, logger, "delete", false, func(ctx context.Context, username string) error {
+		return a.usersSrv.DeleteUser(ctx, &command.DeleteUserCmd{UserName: username}) //nolint:wrapcheck
+	})
+}
+
+// handleUserAction call `action` for user given in form and redirect to users list. When `allowOwn`
+// is false, action can't be performed on account of current user.
+func (a adminPages) handleUserAction(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+	actionName string,
+	allowOwn bool,
+	action func(context.Context, string) error,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.Admin: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	username := r.FormValue("username")
+	admin := common.ContextUser(ctx)
+
+	if !allowOwn && username == admin {
+		a.writeUsersPage(ctx, w, r, logger,
+			&nt.AdminUsersPage{Msg: "Error: action not allowed for own account"})
+
+		return
+	}
+
+	err := action(ctx, username)
+	switch {
+	case err == nil:
+		logger.Info().Str(common.LogKeyUserName, username).
+			Msgf("web.Admin: user %s user_name=%s by=%s", actionName, username, admin)
+		http.Redirect(w, r, a.webroot+"/web/admin/users", http.StatusFound)
+	case aerr.HasTag(err, aerr.ValidationError), errors.Is(err, common.ErrUserExists):
+		a.writeUsersPage(ctx, w, r, logger,
+			&nt.AdminUsersPage{Msg: "Error: " + aerr.GetUserMessageOr(err, err.Error())})
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: user %s user_name=%s error=%q", actionName, username, err)
+	}
+}
+
+func (a adminPages) writeUsersPage(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+	page *nt.AdminUsersPage,
+) {
+	users, err := a.usersSrv.GetUsersWithCounts(ctx)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: list users error=%q", err)
+
+		return
+	}
+
+	page.Users = users
+	page.CurrentUser = common.ContextUser(ctx)
+	a.renderer.WritePage(ctx, w, page)
+}
+
+//------------------------------------------------------------------------------
+
+func (a adminPages) registrationsPage(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.writeRegistrationsPage(ctx, w, r, logger, &nt.AdminRegistrationsPage{})
+}
+
+func (a adminPages) approveRegistration(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleRegistration(ctx, w, r, logger, a.registrationSrv.ApproveRegistration, "approve")
+}
+
+func (a adminPages) rejectRegistration(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	a.handleRegistration(ctx, w, r, logger, a.registrationSrv.RejectRegistration, "reject")
+}
+
+func (a adminPages) handleRegistration(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+	action func(context.Context, string) error,
+	actionName string,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.Admin: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	username := r.FormValue("username")
+
+	err := action(ctx, username)
+	switch {
+	case err == nil:
+		logger.Info().Msgf("web.Admin: registration %s user_name=%s by=%s", actionName, username,
+			common.ContextUser(ctx))
+		http.Redirect(w, r, a.webroot+"/web/admin/registrations", http.StatusFound)
+	case aerr.HasTag(err, aerr.ValidationError):
+		a.writeRegistrationsPage(ctx, w, r, logger,
+			&nt.AdminRegistrationsPage{Msg: "Error: " + aerr.GetUserMessage(err)})
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: registration %s user_name=%s error=%q", actionName, username, err)
+	}
+}
+
+func (a adminPages) createInviteCode(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.Admin: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	page := nt.AdminRegistrationsPage{}
+
+	days, err := strconv.Atoi(r.FormValue("valid_days"))
+	if err != nil || days < 0 {
+		page.Msg = "Error: invalid number of days"
+		a.writeRegistrationsPage(ctx, w, r, logger, &page)
+
+		return
+	}
+
+	cmd := command.CreateInviteCodeCmd{
+		CreatedBy: common.ContextUser(ctx),
+		ValidFor:  time.Duration(days) * 24 * time.Hour, //nolint:mnd
+	}
+
+	res, err := a.registrationSrv.CreateInviteCode(ctx, &cmd)
+	switch {
+	case err == nil:
+		page.NewInviteCode = res.Code
+	case aerr.HasTag(err, aerr.ValidationError):
+		page.Msg = "Error: " + aerr.GetUserMessage(err)
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: create invite code error=%q", err)
+
+		return
+	}
+
+	a.writeRegistrationsPage(ctx, w, r, logger, &page)
+}
+
+func (a adminPages) deleteInviteCode(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.Admin: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	code := r.FormValue("code")
+
+	if err := a.registrationSrv.DeleteInviteCode(ctx, code); err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: delete invite code error=%q", err)
+
+		return
+	}
+
+	http.Redirect(w, r, a.webroot+"/web/admin/registrations", http.StatusFound)
+}
+
+func (a adminPages) writeRegistrationsPage(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+	page *nt.AdminRegistrationsPage,
+) {
+	registrations, err := a.registrationSrv.ListRegistrations(ctx)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: list registrations error=%q", err)
+
+		return
+	}
+
+	codes, err := a.registrationSrv.ListInviteCodes(ctx)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Admin: list invite codes error=%q", err)
+
+		return
+	}
+
+	page.Registrations = registrations
+	page.InviteCodes = codes
+	a.renderer.WritePage(ctx, w, page)
+}
+
+//------------------------------------------------------------------------------
+
+// adminAuditEventsLimit is maximal number of events displayed on audit log page.
+const adminAuditEventsLimit = 200
+
+func (a adminPages) auditPage(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	q := query.GetAuditEventsQuery{
+		UserName: strings.TrimSpace(r.URL.Query().Get("username")),
+		Action:   r.URL.Query().Get("action"),
+		Limit:    adminAuditEventsLimit,
+	}
+	page := nt.AdminAuditPage{UserName: q.UserName, Action: q.Action}
+
+	events, err := a.auditSrv.ListEvents(ctx, &q)
+	switch {
+	case err == nil:
+		page.Events = events
+	case aerr.HasTag(err, aerr.ValidationError):
+		page.Msg = "Error: " + aerr.GetUserMessage(err)
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).Object("query", &q).
+			Msgf("web.Admin: get audit events error=%q", err)
+
+		return
+	}
+
+	a.renderer.WritePage(ctx, w, &page)
+}
@@ -56,7 +56,7 @@ func (d devicePages) list(ctx context.Context, w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	d.renderer.WritePage(w, &nt.DevicesPage{Devices: devices})
+	d.renderer.WritePage(ctx, w, &nt.DevicesPage{Devices: devices})
 }
 
 func (d devicePages) deleteGet(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
@@ -67,7 +67,7 @@ func (d devicePages) deleteGet(ctx context.Context, w http.ResponseWriter, r *ht
 		return
 	}
 
-	d.renderer.WritePage(w, &nt.DeviceDeletePage{DeviceName: devicename})
+	d.renderer.WritePage(ctx, w, &nt.DeviceDeletePage{DeviceName: devicename})
 }
 
 func (d devicePages) deletePost(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
@@ -76,5 +76,5 @@ func (e episodePages) list(ctx context.Context, w http.ResponseWriter, r *http.R
 		return
 	}
 
-	e.renderer.WritePage(w, &nt.EpisodesPage{Episodes: episodes})
+	e.renderer.WritePage(ctx, w, &nt.EpisodesPage{Episodes: episodes})
 }
@@ -0,0 +1,88 @@
+package web
+
+//
+// explore.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+// explorePodcastsNum is number of podcasts shown in toplist and suggestions.
+const explorePodcastsNum = 25
+
+type explorePage struct {
+	podcastsSrv *service.PodcastsSrv
+	renderer    *nt.Renderer
+}
+
+func newExplorePage(i do.Injector) (explorePage, error) {
+	return explorePage{
+		podcastsSrv: do.MustInvoke[*service.PodcastsSrv](i),
+		renderer:    do.MustInvoke[*nt.Renderer](i),
+	}, nil
+}
+
+func (e explorePage) Routes() *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/", srvsupport.WrapNamed(e.explore, "web_explore"))
+
+	return r
+}
+
+func (e explorePage) explore(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	user := common.ContextUser(ctx)
+
+	toplist, err := e.podcastsSrv.GetToplist(ctx, &query.GetToplistQuery{Limit: explorePodcastsNum})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Explore: get toplist error=%q", err)
+
+		return
+	}
+
+	suggestions, err := e.podcastsSrv.GetSuggestions(ctx,
+		&query.GetSuggestionsQuery{UserName: user, Limit: explorePodcastsNum})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Explore: get suggestions user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	subscribed, err := e.podcastsSrv.GetPodcasts(ctx, user)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Explore: get user_name=%s podcasts error=%q", user, err)
+
+		return
+	}
+
+	subscribedurls := make(map[string]struct{}, len(subscribed))
+	for _, p := range subscribed {
+		subscribedurls[p.URL] = struct{}{}
+	}
+
+	e.renderer.WritePage(ctx, w, &nt.ExplorePage{
+		Toplist:     toplist,
+		Suggestions: suggestions,
+		Subscribed:  subscribedurls,
+	})
+}
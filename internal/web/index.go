@@ -60,5 +60,5 @@ func (i indexPage) indexPage(ctx context.Context, writer http.ResponseWriter, r
 		return
 	}
 
-	i.renderer.WritePage(writer, &nt.IndexPage{LastActions: lastactions})
+	i.renderer.WritePage(ctx, writer, &nt.IndexPage{LastActions: lastactions})
 }
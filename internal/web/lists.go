@@ -0,0 +1,129 @@
+package web
+
+//
+// lists.go
+// Copyright (C) 2026 Karol Będkowski <Karol Będkowski@kkomp>
+//
+// Distributed under terms of the GPLv3 license.
+//
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
+	"gitlab.com/kabes/go-gpo/internal/command"
+	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/query"
+	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
+	"gitlab.com/kabes/go-gpo/internal/service"
+	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
+)
+
+type listsPages struct {
+	listsSrv *service.PodcastListsSrv
+	webroot  string
+	renderer *nt.Renderer
+}
+
+func newListsPages(i do.Injector) (listsPages, error) {
+	return listsPages{
+		listsSrv: do.MustInvoke[*service.PodcastListsSrv](i),
+		webroot:  do.MustInvokeNamed[string](i, "server.webroot"),
+		renderer: do.MustInvoke[*nt.Renderer](i),
+	}, nil
+}
+
+func (l listsPages) Routes() *chi.Mux {
+	r := chi.NewRouter()
+	r.Get(`/`, srvsupport.WrapNamed(l.list, "web_lists_index"))
+	r.Post(`/`, srvsupport.WrapNamed(l.create, "web_lists_create"))
+	r.Get(`/{user:[\w+.-]+}/{name:[\w-]+}`, srvsupport.WrapNamed(l.listGet, "web_lists_get"))
+	r.Post(`/{user:[\w+.-]+}/{name:[\w-]+}/delete`, srvsupport.WrapNamed(l.deletePost, "web_lists_del_post"))
+
+	return r
+}
+
+func (l listsPages) list(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	user := common.ContextUser(ctx)
+
+	lists, err := l.listsSrv.GetPodcastLists(ctx, &query.GetPodcastListsQuery{UserName: user})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Lists: get lists user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	l.renderer.WritePage(ctx, w, &nt.ListsPage{Lists: lists, UserName: user})
+}
+
+func (l listsPages) create(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	if err := r.ParseForm(); err != nil {
+		logger.Error().Err(err).Msgf("web.Lists: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	user := common.ContextUser(ctx)
+	cmd := command.CreatePodcastListCmd{
+		UserName: user,
+		Title:    strings.TrimSpace(r.FormValue("title")),
+		Podcasts: strings.Fields(r.FormValue("podcasts")),
+	}
+
+	res, err := l.listsSrv.CreatePodcastList(ctx, &cmd)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Lists: create list user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	http.Redirect(w, r, l.webroot+"/web/lists/"+user+"/"+res.Name, http.StatusFound)
+}
+
+func (l listsPages) listGet(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	user := chi.URLParam(r, "user")
+	name := chi.URLParam(r, "name")
+
+	list, err := l.listsSrv.GetPodcastList(ctx, &query.GetPodcastListQuery{UserName: user, ListName: name})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Lists: get list user_name=%s list_name=%s error=%q", user, name, err)
+
+		return
+	}
+
+	l.renderer.WritePage(ctx, w, &nt.ListPage{List: list, UserName: user, Owner: user == common.ContextUser(ctx)})
+}
+
+func (l listsPages) deletePost(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
+	// only own lists can be deleted
+	user := common.ContextUser(ctx)
+	if chi.URLParam(r, "user") != user {
+		srvsupport.WriteError(w, r, http.StatusForbidden, "")
+
+		return
+	}
+
+	cmd := command.DeletePodcastListCmd{UserName: user, ListName: chi.URLParam(r, "name")}
+
+	if err := l.listsSrv.DeletePodcastList(ctx, &cmd); err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).Object("cmd", &cmd).
+			Msgf("web.Lists: delete list user_name=%s list_name=%s error=%q", user, cmd.ListName, err)
+
+		return
+	}
+
+	http.Redirect(w, r, l.webroot+"/web/lists/", http.StatusFound)
+}
@@ -20,5 +20,8 @@ var Package = do.Package(
 	do.Lazy(newPodcastPages),
 	do.Lazy(newUserPages),
 	do.Lazy(newIndexPage),
+	do.Lazy(newExplorePage),
+	do.Lazy(newListsPages),
+	do.Lazy(newAdminPages),
 	do.Lazy(templates.NewRenderer),
 )
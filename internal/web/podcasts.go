@@ -11,6 +11,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -22,14 +23,19 @@ import (
 	"gitlab.com/kabes/go-gpo/internal/command"
 	"gitlab.com/kabes/go-gpo/internal/common"
 	"gitlab.com/kabes/go-gpo/internal/model"
+	"gitlab.com/kabes/go-gpo/internal/query"
 	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
 	"gitlab.com/kabes/go-gpo/internal/service"
 	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
 )
 
+// podcastPageEpisodes is number of latest episodes shown on podcast page.
+const podcastPageEpisodes = 20
+
 type podcastPages struct {
 	podcastsSrv      *service.PodcastsSrv
 	subscriptionsSrv *service.SubscriptionsSrv
+	episodesSrv      *service.EpisodesSrv
 	webroot          string
 	renderer         *nt.Renderer
 }
@@ -38,6 +44,7 @@ func newPodcastPages(i do.Injector) (podcastPages, error) {
 	return podcastPages{
 		podcastsSrv:      do.MustInvoke[*service.PodcastsSrv](i),
 		subscriptionsSrv: do.MustInvoke[*service.SubscriptionsSrv](i),
+		episodesSrv:      do.MustInvoke[*service.EpisodesSrv](i),
 		webroot:          do.MustInvokeNamed[string](i, "server.webroot"),
 		renderer:         do.MustInvoke[*nt.Renderer](i),
 	}, nil
@@ -59,6 +66,7 @@ func (p podcastPages) Routes() *chi.Mux {
 func (p podcastPages) list(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
 	user := common.ContextUser(ctx)
 	subscribedOnly := !r.URL.Query().Has("showall")
+	tag := r.URL.Query().Get("tag")
 
 	podcasts, err := p.podcastsSrv.GetPodcastsWithLastEpisode(ctx, user, subscribedOnly)
 	if err != nil {
@@ -69,7 +77,13 @@ func (p podcastPages) list(ctx context.Context, w http.ResponseWriter, r *http.R
 		return
 	}
 
-	p.renderer.WritePage(w, &nt.PodcastsPage{Podcasts: podcasts, SubscribedOnly: subscribedOnly})
+	if tag != "" {
+		podcasts = slices.DeleteFunc(podcasts, func(po model.PodcastWithLastEpisode) bool {
+			return !po.Tags.Contains(tag)
+		})
+	}
+
+	p.renderer.WritePage(ctx, w, &nt.PodcastsPage{Podcasts: podcasts, SubscribedOnly: subscribedOnly, Tag: tag})
 }
 
 func (p podcastPages) addPodcast(ctx context.Context, w http.ResponseWriter, r *http.Request, logger *zerolog.Logger) {
@@ -120,7 +134,29 @@ func (p podcastPages) podcastGet(ctx context.Context, w http.ResponseWriter, r *
 		return
 	}
 
-	p.renderer.WritePage(w, &nt.PodcastPage{Podcast: podcast})
+	episodes, err := p.episodesSrv.GetFeedEpisodes(ctx, &query.GetFeedEpisodesQuery{
+		UserName:   common.ContextUser(ctx),
+		PodcastURL: podcast.URL,
+		Limit:      podcastPageEpisodes,
+	})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Podcasts: get podcast_url=%q episodes error=%q", podcast.URL, err)
+
+		return
+	}
+
+	feedState, err := p.podcastsSrv.GetFeedState(ctx, podcast.URL)
+	if err != nil && !errors.Is(err, common.ErrUnknownPodcast) {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.Podcasts: get podcast_url=%q feed state error=%q", podcast.URL, err)
+
+		return
+	}
+
+	p.renderer.WritePage(ctx, w, &nt.PodcastPage{Podcast: podcast, Episodes: episodes, FeedState: feedState})
 }
 
 func (p podcastPages) podcastUnsubscribe(
@@ -224,7 +260,7 @@ func (p podcastPages) podcastDeleteGet(
 		return
 	}
 
-	p.renderer.WritePage(w, &nt.PodcastDeletePage{Podcast: podcast})
+	p.renderer.WritePage(ctx, w, &nt.PodcastDeletePage{Podcast: podcast})
 }
 
 func (p podcastPages) podcastDeletePost(
@@ -0,0 +1,286 @@
+// Code generated by qtc from "admin_audit.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/admin_audit.qtpl:1
+package templates
+
+//line internal/web/templates/admin_audit.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/admin_audit.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/admin_audit.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/admin_audit.qtpl:4
+type AdminAuditPage struct {
+	Events []model.AuditEvent
+	// UserName and Action are current filters.
+	UserName string
+	Action   string
+	Msg      string
+}
+
+//line internal/web/templates/admin_audit.qtpl:13
+func (p *AdminAuditPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/admin_audit.qtpl:13
+	qw422016.N().S(`Audit log`)
+//line internal/web/templates/admin_audit.qtpl:13
+}
+
+//line internal/web/templates/admin_audit.qtpl:13
+func (p *AdminAuditPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/admin_audit.qtpl:13
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_audit.qtpl:13
+	p.StreamTitle(qw422016)
+//line internal/web/templates/admin_audit.qtpl:13
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_audit.qtpl:13
+}
+
+//line internal/web/templates/admin_audit.qtpl:13
+func (p *AdminAuditPage) Title() string {
+//line internal/web/templates/admin_audit.qtpl:13
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_audit.qtpl:13
+	p.WriteTitle(qb422016)
+//line internal/web/templates/admin_audit.qtpl:13
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_audit.qtpl:13
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_audit.qtpl:13
+	return qs422016
+//line internal/web/templates/admin_audit.qtpl:13
+}
+
+//line internal/web/templates/admin_audit.qtpl:15
+func (p *AdminAuditPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/admin_audit.qtpl:15
+	qw422016.N().S(`
+<section>
+	<h2>Audit log</h2>
+	`)
+//line internal/web/templates/admin_audit.qtpl:18
+	if p.Msg != "" {
+//line internal/web/templates/admin_audit.qtpl:18
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/admin_audit.qtpl:19
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/admin_audit.qtpl:19
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/admin_audit.qtpl:20
+	}
+//line internal/web/templates/admin_audit.qtpl:20
+	qw422016.N().S(`
+	<form method="GET" action="`)
+//line internal/web/templates/admin_audit.qtpl:21
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_audit.qtpl:21
+	qw422016.N().S(`/web/admin/audit">
+		<label for="username">User name</label>
+		<input type="text" name="username" id="username" value="`)
+//line internal/web/templates/admin_audit.qtpl:23
+	qw422016.E().S(p.UserName)
+//line internal/web/templates/admin_audit.qtpl:23
+	qw422016.N().S(`" />
+		<label for="action">Action</label>
+		<select name="action" id="action">
+			<option value="">any</option>
+			`)
+//line internal/web/templates/admin_audit.qtpl:27
+	for _, a := range model.AuditActions {
+//line internal/web/templates/admin_audit.qtpl:27
+		qw422016.N().S(`
+			<option value="`)
+//line internal/web/templates/admin_audit.qtpl:28
+		qw422016.E().S(a)
+//line internal/web/templates/admin_audit.qtpl:28
+		qw422016.N().S(`"`)
+//line internal/web/templates/admin_audit.qtpl:28
+		if a == p.Action {
+//line internal/web/templates/admin_audit.qtpl:28
+			qw422016.N().S(` selected`)
+//line internal/web/templates/admin_audit.qtpl:28
+		}
+//line internal/web/templates/admin_audit.qtpl:28
+		qw422016.N().S(`>`)
+//line internal/web/templates/admin_audit.qtpl:28
+		qw422016.E().S(a)
+//line internal/web/templates/admin_audit.qtpl:28
+		qw422016.N().S(`</option>
+			`)
+//line internal/web/templates/admin_audit.qtpl:29
+	}
+//line internal/web/templates/admin_audit.qtpl:29
+	qw422016.N().S(`
+		</select>
+		<button type="submit">Filter</button>
+	</form>
+	`)
+//line internal/web/templates/admin_audit.qtpl:33
+	streamauditEventsTable(qw422016, p.Events, true)
+//line internal/web/templates/admin_audit.qtpl:33
+	qw422016.N().S(`
+</section>
+`)
+//line internal/web/templates/admin_audit.qtpl:35
+}
+
+//line internal/web/templates/admin_audit.qtpl:35
+func (p *AdminAuditPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/admin_audit.qtpl:35
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_audit.qtpl:35
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/admin_audit.qtpl:35
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_audit.qtpl:35
+}
+
+//line internal/web/templates/admin_audit.qtpl:35
+func (p *AdminAuditPage) Body(pctx *PageContext) string {
+//line internal/web/templates/admin_audit.qtpl:35
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_audit.qtpl:35
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/admin_audit.qtpl:35
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_audit.qtpl:35
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_audit.qtpl:35
+	return qs422016
+//line internal/web/templates/admin_audit.qtpl:35
+}
+
+//line internal/web/templates/admin_audit.qtpl:37
+func streamauditEventsTable(qw422016 *qt422016.Writer, events []model.AuditEvent, showUser bool) {
+//line internal/web/templates/admin_audit.qtpl:37
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/admin_audit.qtpl:38
+	if len(events) == 0 {
+//line internal/web/templates/admin_audit.qtpl:38
+		qw422016.N().S(`
+		<p>No events.</p>
+	`)
+//line internal/web/templates/admin_audit.qtpl:40
+	} else {
+//line internal/web/templates/admin_audit.qtpl:40
+		qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>Time</th>
+				`)
+//line internal/web/templates/admin_audit.qtpl:45
+		if showUser {
+//line internal/web/templates/admin_audit.qtpl:45
+			qw422016.N().S(`<th>User name</th>`)
+//line internal/web/templates/admin_audit.qtpl:45
+		}
+//line internal/web/templates/admin_audit.qtpl:45
+		qw422016.N().S(`
+				<th>Action</th>
+				<th>Details</th>
+				<th>IP address</th>
+				<th>User agent</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/admin_audit.qtpl:53
+		for _, e := range events {
+//line internal/web/templates/admin_audit.qtpl:53
+			qw422016.N().S(`
+			<tr>
+				<td>`)
+//line internal/web/templates/admin_audit.qtpl:55
+			qw422016.E().S(e.CreatedAt.Format("2006-01-02 15:04:05"))
+//line internal/web/templates/admin_audit.qtpl:55
+			qw422016.N().S(`</td>
+				`)
+//line internal/web/templates/admin_audit.qtpl:56
+			if showUser {
+//line internal/web/templates/admin_audit.qtpl:56
+				qw422016.N().S(`<td>`)
+//line internal/web/templates/admin_audit.qtpl:56
+				qw422016.E().S(e.UserName)
+//line internal/web/templates/admin_audit.qtpl:56
+				qw422016.N().S(`</td>`)
+//line internal/web/templates/admin_audit.qtpl:56
+			}
+//line internal/web/templates/admin_audit.qtpl:56
+			qw422016.N().S(`
+				<td>`)
+//line internal/web/templates/admin_audit.qtpl:57
+			qw422016.E().S(e.Action)
+//line internal/web/templates/admin_audit.qtpl:57
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_audit.qtpl:58
+			qw422016.E().S(e.Details)
+//line internal/web/templates/admin_audit.qtpl:58
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_audit.qtpl:59
+			qw422016.E().S(e.IP)
+//line internal/web/templates/admin_audit.qtpl:59
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_audit.qtpl:60
+			qw422016.E().S(e.UserAgent)
+//line internal/web/templates/admin_audit.qtpl:60
+			qw422016.N().S(`</td>
+			</tr>
+			`)
+//line internal/web/templates/admin_audit.qtpl:62
+		}
+//line internal/web/templates/admin_audit.qtpl:62
+		qw422016.N().S(`
+		</tbody>
+	</table>
+	`)
+//line internal/web/templates/admin_audit.qtpl:65
+	}
+//line internal/web/templates/admin_audit.qtpl:65
+	qw422016.N().S(`
+`)
+//line internal/web/templates/admin_audit.qtpl:66
+}
+
+//line internal/web/templates/admin_audit.qtpl:66
+func writeauditEventsTable(qq422016 qtio422016.Writer, events []model.AuditEvent, showUser bool) {
+//line internal/web/templates/admin_audit.qtpl:66
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_audit.qtpl:66
+	streamauditEventsTable(qw422016, events, showUser)
+//line internal/web/templates/admin_audit.qtpl:66
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_audit.qtpl:66
+}
+
+//line internal/web/templates/admin_audit.qtpl:66
+func auditEventsTable(events []model.AuditEvent, showUser bool) string {
+//line internal/web/templates/admin_audit.qtpl:66
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_audit.qtpl:66
+	writeauditEventsTable(qb422016, events, showUser)
+//line internal/web/templates/admin_audit.qtpl:66
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_audit.qtpl:66
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_audit.qtpl:66
+	return qs422016
+//line internal/web/templates/admin_audit.qtpl:66
+}
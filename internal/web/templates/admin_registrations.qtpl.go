@@ -0,0 +1,346 @@
+// Code generated by qtc from "admin_registrations.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/admin_registrations.qtpl:1
+package templates
+
+//line internal/web/templates/admin_registrations.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/admin_registrations.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/admin_registrations.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/admin_registrations.qtpl:4
+type AdminRegistrationsPage struct {
+	InviteCodes   []model.InviteCode
+	Registrations []model.UserRegistration
+	// NewInviteCode is just created code.
+	NewInviteCode string
+	Msg           string
+}
+
+//line internal/web/templates/admin_registrations.qtpl:13
+func (p *AdminRegistrationsPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/admin_registrations.qtpl:13
+	qw422016.N().S(`Registrations`)
+//line internal/web/templates/admin_registrations.qtpl:13
+}
+
+//line internal/web/templates/admin_registrations.qtpl:13
+func (p *AdminRegistrationsPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/admin_registrations.qtpl:13
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_registrations.qtpl:13
+	p.StreamTitle(qw422016)
+//line internal/web/templates/admin_registrations.qtpl:13
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_registrations.qtpl:13
+}
+
+//line internal/web/templates/admin_registrations.qtpl:13
+func (p *AdminRegistrationsPage) Title() string {
+//line internal/web/templates/admin_registrations.qtpl:13
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_registrations.qtpl:13
+	p.WriteTitle(qb422016)
+//line internal/web/templates/admin_registrations.qtpl:13
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_registrations.qtpl:13
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_registrations.qtpl:13
+	return qs422016
+//line internal/web/templates/admin_registrations.qtpl:13
+}
+
+//line internal/web/templates/admin_registrations.qtpl:15
+func (p *AdminRegistrationsPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/admin_registrations.qtpl:15
+	qw422016.N().S(`
+<section>
+	<h2>Pending registrations</h2>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:18
+	if p.Msg != "" {
+//line internal/web/templates/admin_registrations.qtpl:18
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/admin_registrations.qtpl:19
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/admin_registrations.qtpl:19
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:20
+	}
+//line internal/web/templates/admin_registrations.qtpl:20
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/admin_registrations.qtpl:21
+	if len(p.Registrations) == 0 {
+//line internal/web/templates/admin_registrations.qtpl:21
+		qw422016.N().S(`
+		<p>No registrations waiting for approval.</p>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:23
+	} else {
+//line internal/web/templates/admin_registrations.qtpl:23
+		qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>User name</th>
+				<th>Name</th>
+				<th>Email</th>
+				<th>Registered</th>
+				<th>&nbsp;</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/admin_registrations.qtpl:35
+		for _, r := range p.Registrations {
+//line internal/web/templates/admin_registrations.qtpl:35
+			qw422016.N().S(`
+			<tr>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:37
+			qw422016.E().S(r.User.UserName)
+//line internal/web/templates/admin_registrations.qtpl:37
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:38
+			qw422016.E().S(r.User.Name)
+//line internal/web/templates/admin_registrations.qtpl:38
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:39
+			qw422016.E().S(r.User.Email)
+//line internal/web/templates/admin_registrations.qtpl:39
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:40
+			qw422016.E().S(formatDateTime(r.CreatedAt))
+//line internal/web/templates/admin_registrations.qtpl:40
+			qw422016.N().S(`</td>
+				<td>
+					<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_registrations.qtpl:42
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_registrations.qtpl:42
+			qw422016.N().S(`/web/admin/registrations/approve">
+						`)
+//line internal/web/templates/admin_registrations.qtpl:43
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_registrations.qtpl:43
+			qw422016.N().S(`
+						<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_registrations.qtpl:44
+			qw422016.E().S(r.User.UserName)
+//line internal/web/templates/admin_registrations.qtpl:44
+			qw422016.N().S(`" />
+						<button type="submit">Approve</button>
+					</form>
+					<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_registrations.qtpl:47
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_registrations.qtpl:47
+			qw422016.N().S(`/web/admin/registrations/reject">
+						`)
+//line internal/web/templates/admin_registrations.qtpl:48
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_registrations.qtpl:48
+			qw422016.N().S(`
+						<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_registrations.qtpl:49
+			qw422016.E().S(r.User.UserName)
+//line internal/web/templates/admin_registrations.qtpl:49
+			qw422016.N().S(`" />
+						<button type="submit">Reject</button>
+					</form>
+				</td>
+			</tr>
+			`)
+//line internal/web/templates/admin_registrations.qtpl:54
+		}
+//line internal/web/templates/admin_registrations.qtpl:54
+		qw422016.N().S(`
+		</tbody>
+	</table>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:57
+	}
+//line internal/web/templates/admin_registrations.qtpl:57
+	qw422016.N().S(`
+</section>
+
+<section>
+	<h2>Invite codes</h2>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:62
+	if p.NewInviteCode != "" {
+//line internal/web/templates/admin_registrations.qtpl:62
+		qw422016.N().S(`
+		<p>New invite code: <code>`)
+//line internal/web/templates/admin_registrations.qtpl:63
+		qw422016.E().S(p.NewInviteCode)
+//line internal/web/templates/admin_registrations.qtpl:63
+		qw422016.N().S(`</code></p>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:64
+	}
+//line internal/web/templates/admin_registrations.qtpl:64
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/admin_registrations.qtpl:65
+	if len(p.InviteCodes) == 0 {
+//line internal/web/templates/admin_registrations.qtpl:65
+		qw422016.N().S(`
+		<p>No invite codes.</p>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:67
+	} else {
+//line internal/web/templates/admin_registrations.qtpl:67
+		qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>Code</th>
+				<th>Created</th>
+				<th>Created by</th>
+				<th>Expires</th>
+				<th>Used by</th>
+				<th>&nbsp;</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/admin_registrations.qtpl:80
+		for _, c := range p.InviteCodes {
+//line internal/web/templates/admin_registrations.qtpl:80
+			qw422016.N().S(`
+			<tr>
+				<td><code>`)
+//line internal/web/templates/admin_registrations.qtpl:82
+			qw422016.E().S(c.Code)
+//line internal/web/templates/admin_registrations.qtpl:82
+			qw422016.N().S(`</code></td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:83
+			qw422016.E().S(formatDateTime(c.CreatedAt))
+//line internal/web/templates/admin_registrations.qtpl:83
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:84
+			qw422016.E().S(c.CreatedBy)
+//line internal/web/templates/admin_registrations.qtpl:84
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:85
+			if !c.ExpiresAt.IsZero() {
+//line internal/web/templates/admin_registrations.qtpl:85
+				qw422016.E().S(formatDateTime(c.ExpiresAt))
+//line internal/web/templates/admin_registrations.qtpl:85
+			}
+//line internal/web/templates/admin_registrations.qtpl:85
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_registrations.qtpl:86
+			if !c.UsedAt.IsZero() {
+//line internal/web/templates/admin_registrations.qtpl:86
+				qw422016.E().S(c.UsedBy)
+//line internal/web/templates/admin_registrations.qtpl:86
+				qw422016.N().S(` (`)
+//line internal/web/templates/admin_registrations.qtpl:86
+				qw422016.E().S(formatDateTime(c.UsedAt))
+//line internal/web/templates/admin_registrations.qtpl:86
+				qw422016.N().S(`)`)
+//line internal/web/templates/admin_registrations.qtpl:86
+			}
+//line internal/web/templates/admin_registrations.qtpl:86
+			qw422016.N().S(`</td>
+				<td>
+					<form method="POST" action="`)
+//line internal/web/templates/admin_registrations.qtpl:88
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_registrations.qtpl:88
+			qw422016.N().S(`/web/admin/registrations/invites/delete">
+						`)
+//line internal/web/templates/admin_registrations.qtpl:89
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_registrations.qtpl:89
+			qw422016.N().S(`
+						<input type="hidden" name="code" value="`)
+//line internal/web/templates/admin_registrations.qtpl:90
+			qw422016.E().S(c.Code)
+//line internal/web/templates/admin_registrations.qtpl:90
+			qw422016.N().S(`" />
+						<button type="submit">Delete</button>
+					</form>
+				</td>
+			</tr>
+			`)
+//line internal/web/templates/admin_registrations.qtpl:95
+		}
+//line internal/web/templates/admin_registrations.qtpl:95
+		qw422016.N().S(`
+		</tbody>
+	</table>
+	`)
+//line internal/web/templates/admin_registrations.qtpl:98
+	}
+//line internal/web/templates/admin_registrations.qtpl:98
+	qw422016.N().S(`
+
+	<form method="POST" action="`)
+//line internal/web/templates/admin_registrations.qtpl:100
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_registrations.qtpl:100
+	qw422016.N().S(`/web/admin/registrations/invites">
+		`)
+//line internal/web/templates/admin_registrations.qtpl:101
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_registrations.qtpl:101
+	qw422016.N().S(`
+		<label for="valid_days">Valid for days (0 - no limit)</label>
+		<input type="number" name="valid_days" id="valid_days" value="7" min="0" />
+		<button type="submit">Create invite code</button>
+	</form>
+</section>
+`)
+//line internal/web/templates/admin_registrations.qtpl:107
+}
+
+//line internal/web/templates/admin_registrations.qtpl:107
+func (p *AdminRegistrationsPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/admin_registrations.qtpl:107
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_registrations.qtpl:107
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/admin_registrations.qtpl:107
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_registrations.qtpl:107
+}
+
+//line internal/web/templates/admin_registrations.qtpl:107
+func (p *AdminRegistrationsPage) Body(pctx *PageContext) string {
+//line internal/web/templates/admin_registrations.qtpl:107
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_registrations.qtpl:107
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/admin_registrations.qtpl:107
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_registrations.qtpl:107
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_registrations.qtpl:107
+	return qs422016
+//line internal/web/templates/admin_registrations.qtpl:107
+}
@@ -0,0 +1,357 @@
+// Code generated by qtc from "admin_users.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/admin_users.qtpl:1
+package templates
+
+//line internal/web/templates/admin_users.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/admin_users.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/admin_users.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/admin_users.qtpl:4
+type AdminUsersPage struct {
+	Users []model.UserWithCounts
+	// CurrentUser is name of logged admin; own account can't be locked or deleted.
+	CurrentUser string
+	Msg         string
+}
+
+//line internal/web/templates/admin_users.qtpl:12
+func (p *AdminUsersPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/admin_users.qtpl:12
+	qw422016.N().S(`Users`)
+//line internal/web/templates/admin_users.qtpl:12
+}
+
+//line internal/web/templates/admin_users.qtpl:12
+func (p *AdminUsersPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/admin_users.qtpl:12
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_users.qtpl:12
+	p.StreamTitle(qw422016)
+//line internal/web/templates/admin_users.qtpl:12
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_users.qtpl:12
+}
+
+//line internal/web/templates/admin_users.qtpl:12
+func (p *AdminUsersPage) Title() string {
+//line internal/web/templates/admin_users.qtpl:12
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_users.qtpl:12
+	p.WriteTitle(qb422016)
+//line internal/web/templates/admin_users.qtpl:12
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_users.qtpl:12
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_users.qtpl:12
+	return qs422016
+//line internal/web/templates/admin_users.qtpl:12
+}
+
+//line internal/web/templates/admin_users.qtpl:14
+func (p *AdminUsersPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/admin_users.qtpl:14
+	qw422016.N().S(`
+<section>
+	<h2>Users</h2>
+	`)
+//line internal/web/templates/admin_users.qtpl:17
+	if p.Msg != "" {
+//line internal/web/templates/admin_users.qtpl:17
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/admin_users.qtpl:18
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/admin_users.qtpl:18
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/admin_users.qtpl:19
+	}
+//line internal/web/templates/admin_users.qtpl:19
+	qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>User name</th>
+				<th>Name</th>
+				<th>Email</th>
+				<th>Status</th>
+				<th>Devices</th>
+				<th>Subscriptions</th>
+				<th>&nbsp;</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/admin_users.qtpl:33
+	for _, u := range p.Users {
+//line internal/web/templates/admin_users.qtpl:33
+		qw422016.N().S(`
+			<tr>
+				<td>`)
+//line internal/web/templates/admin_users.qtpl:35
+		qw422016.E().S(u.User.UserName)
+//line internal/web/templates/admin_users.qtpl:35
+		qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_users.qtpl:36
+		qw422016.E().S(u.User.Name)
+//line internal/web/templates/admin_users.qtpl:36
+		qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_users.qtpl:37
+		qw422016.E().S(u.User.Email)
+//line internal/web/templates/admin_users.qtpl:37
+		qw422016.N().S(`</td>
+				<td>
+					`)
+//line internal/web/templates/admin_users.qtpl:39
+		if u.User.Locked {
+//line internal/web/templates/admin_users.qtpl:39
+			qw422016.N().S(`locked`)
+//line internal/web/templates/admin_users.qtpl:39
+		} else {
+//line internal/web/templates/admin_users.qtpl:39
+			qw422016.N().S(`active`)
+//line internal/web/templates/admin_users.qtpl:39
+		}
+//line internal/web/templates/admin_users.qtpl:39
+		qw422016.N().S(`
+					`)
+//line internal/web/templates/admin_users.qtpl:40
+		if u.User.Admin {
+//line internal/web/templates/admin_users.qtpl:40
+			qw422016.N().S(`, admin`)
+//line internal/web/templates/admin_users.qtpl:40
+		}
+//line internal/web/templates/admin_users.qtpl:40
+		qw422016.N().S(`
+				</td>
+				<td>`)
+//line internal/web/templates/admin_users.qtpl:42
+		qw422016.N().D(u.Devices)
+//line internal/web/templates/admin_users.qtpl:42
+		qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/admin_users.qtpl:43
+		qw422016.N().D(u.Subscriptions)
+//line internal/web/templates/admin_users.qtpl:43
+		qw422016.N().S(`</td>
+				<td>
+					<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_users.qtpl:45
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_users.qtpl:45
+		qw422016.N().S(`/web/admin/users/password">
+						`)
+//line internal/web/templates/admin_users.qtpl:46
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:46
+		qw422016.N().S(`
+						<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_users.qtpl:47
+		qw422016.E().S(u.User.UserName)
+//line internal/web/templates/admin_users.qtpl:47
+		qw422016.N().S(`" />
+						<input type="password" name="password" autocomplete="new-password" placeholder="New password" required />
+						<button type="submit">`)
+//line internal/web/templates/admin_users.qtpl:49
+		if u.User.Locked {
+//line internal/web/templates/admin_users.qtpl:49
+			qw422016.N().S(`Set password and unlock`)
+//line internal/web/templates/admin_users.qtpl:49
+		} else {
+//line internal/web/templates/admin_users.qtpl:49
+			qw422016.N().S(`Reset password`)
+//line internal/web/templates/admin_users.qtpl:49
+		}
+//line internal/web/templates/admin_users.qtpl:49
+		qw422016.N().S(`</button>
+					</form>
+					`)
+//line internal/web/templates/admin_users.qtpl:51
+		if u.User.UserName != p.CurrentUser {
+//line internal/web/templates/admin_users.qtpl:51
+			qw422016.N().S(`
+						`)
+//line internal/web/templates/admin_users.qtpl:52
+			if u.User.Locked {
+//line internal/web/templates/admin_users.qtpl:52
+				qw422016.N().S(`
+						<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_users.qtpl:53
+				qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_users.qtpl:53
+				qw422016.N().S(`/web/admin/users/unlock">
+							`)
+//line internal/web/templates/admin_users.qtpl:54
+				streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:54
+				qw422016.N().S(`
+							<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_users.qtpl:55
+				qw422016.E().S(u.User.UserName)
+//line internal/web/templates/admin_users.qtpl:55
+				qw422016.N().S(`" />
+							<button type="submit">Unlock</button>
+						</form>
+						`)
+//line internal/web/templates/admin_users.qtpl:58
+			} else {
+//line internal/web/templates/admin_users.qtpl:58
+				qw422016.N().S(`
+						<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_users.qtpl:59
+				qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_users.qtpl:59
+				qw422016.N().S(`/web/admin/users/lock">
+							`)
+//line internal/web/templates/admin_users.qtpl:60
+				streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:60
+				qw422016.N().S(`
+							<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_users.qtpl:61
+				qw422016.E().S(u.User.UserName)
+//line internal/web/templates/admin_users.qtpl:61
+				qw422016.N().S(`" />
+							<button type="submit">Lock</button>
+						</form>
+						`)
+//line internal/web/templates/admin_users.qtpl:64
+			}
+//line internal/web/templates/admin_users.qtpl:64
+			qw422016.N().S(`
+						<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_users.qtpl:65
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_users.qtpl:65
+			qw422016.N().S(`/web/admin/users/admin">
+							`)
+//line internal/web/templates/admin_users.qtpl:66
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:66
+			qw422016.N().S(`
+							<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_users.qtpl:67
+			qw422016.E().S(u.User.UserName)
+//line internal/web/templates/admin_users.qtpl:67
+			qw422016.N().S(`" />
+							`)
+//line internal/web/templates/admin_users.qtpl:68
+			if u.User.Admin {
+//line internal/web/templates/admin_users.qtpl:68
+				qw422016.N().S(`
+								<button type="submit">Revoke admin</button>
+							`)
+//line internal/web/templates/admin_users.qtpl:70
+			} else {
+//line internal/web/templates/admin_users.qtpl:70
+				qw422016.N().S(`
+								<input type="hidden" name="admin" value="1" />
+								<button type="submit">Grant admin</button>
+							`)
+//line internal/web/templates/admin_users.qtpl:73
+			}
+//line internal/web/templates/admin_users.qtpl:73
+			qw422016.N().S(`
+						</form>
+						<form class="inline" method="POST" action="`)
+//line internal/web/templates/admin_users.qtpl:75
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_users.qtpl:75
+			qw422016.N().S(`/web/admin/users/delete">
+							`)
+//line internal/web/templates/admin_users.qtpl:76
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:76
+			qw422016.N().S(`
+							<input type="hidden" name="username" value="`)
+//line internal/web/templates/admin_users.qtpl:77
+			qw422016.E().S(u.User.UserName)
+//line internal/web/templates/admin_users.qtpl:77
+			qw422016.N().S(`" />
+							<button type="submit">Delete</button>
+						</form>
+					`)
+//line internal/web/templates/admin_users.qtpl:80
+		}
+//line internal/web/templates/admin_users.qtpl:80
+		qw422016.N().S(`
+				</td>
+			</tr>
+			`)
+//line internal/web/templates/admin_users.qtpl:83
+	}
+//line internal/web/templates/admin_users.qtpl:83
+	qw422016.N().S(`
+		</tbody>
+	</table>
+</section>
+
+<section>
+	<h2>New user</h2>
+	<form method="POST" action="`)
+//line internal/web/templates/admin_users.qtpl:90
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/admin_users.qtpl:90
+	qw422016.N().S(`/web/admin/users">
+		`)
+//line internal/web/templates/admin_users.qtpl:91
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:91
+	qw422016.N().S(`
+		<label for="username">User name</label>
+		<input type="text" name="username" id="username" required />
+		<label for="email">Email</label>
+		<input type="email" name="email" id="email" required />
+		<label for="name">Name</label>
+		<input type="text" name="name" id="name" />
+		<label for="password">Password</label>
+		<input type="password" name="password" id="password" autocomplete="new-password" required />
+		<label><input type="checkbox" name="admin" value="1" /> Administrator</label>
+		<button type="submit">Create</button>
+	</form>
+</section>
+`)
+//line internal/web/templates/admin_users.qtpl:104
+}
+
+//line internal/web/templates/admin_users.qtpl:104
+func (p *AdminUsersPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/admin_users.qtpl:104
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/admin_users.qtpl:104
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/admin_users.qtpl:104
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/admin_users.qtpl:104
+}
+
+//line internal/web/templates/admin_users.qtpl:104
+func (p *AdminUsersPage) Body(pctx *PageContext) string {
+//line internal/web/templates/admin_users.qtpl:104
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/admin_users.qtpl:104
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/admin_users.qtpl:104
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/admin_users.qtpl:104
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/admin_users.qtpl:104
+	return qs422016
+//line internal/web/templates/admin_users.qtpl:104
+}
@@ -94,111 +94,172 @@ func StreamPageTemplate(qw422016 *qt422016.Writer, p Page, pctx *PageContext) {
 //line internal/web/templates/basepage.qtpl:29
 	qw422016.E().S(pctx.Webroot)
 //line internal/web/templates/basepage.qtpl:29
-	qw422016.N().S(`/web/user/">User</a>
+	qw422016.N().S(`/web/explore/">Explore</a> |
+		<a href="`)
+//line internal/web/templates/basepage.qtpl:30
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/basepage.qtpl:30
+	qw422016.N().S(`/web/lists/">Lists</a> |
+		<a href="`)
+//line internal/web/templates/basepage.qtpl:31
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/basepage.qtpl:31
+	qw422016.N().S(`/web/user/">User</a> |
+		<form class="inline" method="post" action="`)
+//line internal/web/templates/basepage.qtpl:32
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/basepage.qtpl:32
+	qw422016.N().S(`/web/logout">
+			`)
+//line internal/web/templates/basepage.qtpl:33
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/basepage.qtpl:33
+	qw422016.N().S(`
+			<button type="submit">Logout</button>
+		</form>
 	</header>
 	<br/>
 	<content>
 	`)
-//line internal/web/templates/basepage.qtpl:33
+//line internal/web/templates/basepage.qtpl:39
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/basepage.qtpl:33
+//line internal/web/templates/basepage.qtpl:39
 	qw422016.N().S(`
 	</content>
 </body>
 </html>
 `)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 }
 
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 func WritePageTemplate(qq422016 qtio422016.Writer, p Page, pctx *PageContext) {
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 	StreamPageTemplate(qw422016, p, pctx)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 }
 
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 func PageTemplate(p Page, pctx *PageContext) string {
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
 	WritePageTemplate(qb422016, p, pctx)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:43
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/basepage.qtpl:43
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/basepage.qtpl:43
+	return qs422016
+//line internal/web/templates/basepage.qtpl:43
+}
+
+//line internal/web/templates/basepage.qtpl:46
+func streamcsrfField(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/basepage.qtpl:46
+	qw422016.N().S(`
+<input type="hidden" name="csrf_token" value="`)
+//line internal/web/templates/basepage.qtpl:47
+	qw422016.E().S(pctx.CSRFToken)
+//line internal/web/templates/basepage.qtpl:47
+	qw422016.N().S(`" />
+`)
+//line internal/web/templates/basepage.qtpl:48
+}
+
+//line internal/web/templates/basepage.qtpl:48
+func writecsrfField(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/basepage.qtpl:48
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/basepage.qtpl:48
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/basepage.qtpl:48
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/basepage.qtpl:48
+}
+
+//line internal/web/templates/basepage.qtpl:48
+func csrfField(pctx *PageContext) string {
+//line internal/web/templates/basepage.qtpl:48
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/basepage.qtpl:48
+	writecsrfField(qb422016, pctx)
+//line internal/web/templates/basepage.qtpl:48
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:48
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:48
 	return qs422016
-//line internal/web/templates/basepage.qtpl:37
+//line internal/web/templates/basepage.qtpl:48
 }
 
-//line internal/web/templates/basepage.qtpl:40
+//line internal/web/templates/basepage.qtpl:51
 type BasePage struct{}
 
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 func (p *BasePage) StreamTitle(qw422016 *qt422016.Writer) {
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 }
 
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 func (p *BasePage) WriteTitle(qq422016 qtio422016.Writer) {
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	p.StreamTitle(qw422016)
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 }
 
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 func (p *BasePage) Title() string {
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	p.WriteTitle(qb422016)
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 	return qs422016
-//line internal/web/templates/basepage.qtpl:41
+//line internal/web/templates/basepage.qtpl:52
 }
 
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 func (p *BasePage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	qw422016.N().S(`body`)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 }
 
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 func (p *BasePage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 }
 
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 func (p *BasePage) Body(pctx *PageContext) string {
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 	return qs422016
-//line internal/web/templates/basepage.qtpl:42
+//line internal/web/templates/basepage.qtpl:53
 }
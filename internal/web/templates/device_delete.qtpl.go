@@ -63,43 +63,48 @@ func (p *DeviceDeletePage) StreamBody(qw422016 *qt422016.Writer, pctx *PageConte
 	<h1>Delete device</h1>
 
 	<form method="POST">
-		<p>Delete device <em>`)
+		`)
 //line internal/web/templates/device_delete.qtpl:14
-	qw422016.E().S(p.DeviceName)
+	streamcsrfField(qw422016, pctx)
 //line internal/web/templates/device_delete.qtpl:14
+	qw422016.N().S(`
+		<p>Delete device <em>`)
+//line internal/web/templates/device_delete.qtpl:15
+	qw422016.E().S(p.DeviceName)
+//line internal/web/templates/device_delete.qtpl:15
 	qw422016.N().S(`</em>?</p>
 		<a href="`)
-//line internal/web/templates/device_delete.qtpl:15
+//line internal/web/templates/device_delete.qtpl:16
 	qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/device_delete.qtpl:15
+//line internal/web/templates/device_delete.qtpl:16
 	qw422016.N().S(`/web/device/">Back</a> <button type="submit">Delete</button>
 	</form>
 `)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 }
 
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 func (p *DeviceDeletePage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 }
 
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 func (p *DeviceDeletePage) Body(pctx *PageContext) string {
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 	return qs422016
-//line internal/web/templates/device_delete.qtpl:17
+//line internal/web/templates/device_delete.qtpl:18
 }
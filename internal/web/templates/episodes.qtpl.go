@@ -67,7 +67,7 @@ func (p *EpisodesPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext)
 
   <table>
     <thead>
-      <tr><th>Episode</th><th>Device</th><th>Action</th><th>Timestamp</th></tr>
+      <tr><th>Episode</th><th>Released</th><th>Duration</th><th>Device</th><th>Action</th><th>Timestamp</th></tr>
     </thead>
     <tbody>
       `)
@@ -92,31 +92,55 @@ func (p *EpisodesPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext)
 //line internal/web/templates/episodes.qtpl:22
 		}
 //line internal/web/templates/episodes.qtpl:22
-		qw422016.N().S(`</a></td>
-        <td>`)
+		qw422016.N().S(`</a>
+          `)
 //line internal/web/templates/episodes.qtpl:23
-		if e.Device != nil {
+		if e.Description != "" {
 //line internal/web/templates/episodes.qtpl:23
-			qw422016.E().S(e.Device.Name)
+			qw422016.N().S(`<br/><small>`)
+//line internal/web/templates/episodes.qtpl:23
+			qw422016.E().S(shortString(e.Description, 200))
+//line internal/web/templates/episodes.qtpl:23
+			qw422016.N().S(`</small>`)
 //line internal/web/templates/episodes.qtpl:23
 		}
 //line internal/web/templates/episodes.qtpl:23
+		qw422016.N().S(`
+        </td>
+        <td>`)
+//line internal/web/templates/episodes.qtpl:25
+		qw422016.E().S(formatDate(e.Released))
+//line internal/web/templates/episodes.qtpl:25
+		qw422016.N().S(`</td>
+        <td>`)
+//line internal/web/templates/episodes.qtpl:26
+		qw422016.E().S(formatSecondsAsDuration(e.Duration))
+//line internal/web/templates/episodes.qtpl:26
+		qw422016.N().S(`</td>
+        <td>`)
+//line internal/web/templates/episodes.qtpl:27
+		if e.Device != nil {
+//line internal/web/templates/episodes.qtpl:27
+			qw422016.E().S(e.Device.Name)
+//line internal/web/templates/episodes.qtpl:27
+		}
+//line internal/web/templates/episodes.qtpl:27
 		qw422016.N().S(`</td>
         <td>`)
-//line internal/web/templates/episodes.qtpl:24
+//line internal/web/templates/episodes.qtpl:28
 		qw422016.E().S(e.Action)
-//line internal/web/templates/episodes.qtpl:24
+//line internal/web/templates/episodes.qtpl:28
 		qw422016.N().S(`</td>
         <td>`)
-//line internal/web/templates/episodes.qtpl:25
+//line internal/web/templates/episodes.qtpl:29
 		qw422016.E().S(formatDateTime(e.Timestamp))
-//line internal/web/templates/episodes.qtpl:25
+//line internal/web/templates/episodes.qtpl:29
 		qw422016.N().S(`</td>
       </tr>
       `)
-//line internal/web/templates/episodes.qtpl:27
+//line internal/web/templates/episodes.qtpl:31
 	}
-//line internal/web/templates/episodes.qtpl:27
+//line internal/web/templates/episodes.qtpl:31
 	qw422016.N().S(`
     </tbody>
   </table>
@@ -124,31 +148,31 @@ func (p *EpisodesPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext)
 </section>
 
 `)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 }
 
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 func (p *EpisodesPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 }
 
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 func (p *EpisodesPage) Body(pctx *PageContext) string {
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 	return qs422016
-//line internal/web/templates/episodes.qtpl:33
+//line internal/web/templates/episodes.qtpl:37
 }
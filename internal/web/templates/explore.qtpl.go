@@ -0,0 +1,264 @@
+// Code generated by qtc from "explore.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/explore.qtpl:1
+package templates
+
+//line internal/web/templates/explore.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/explore.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/explore.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/explore.qtpl:4
+type ExplorePage struct {
+	Toplist     model.Podcasts
+	Suggestions model.Podcasts
+	// Subscribed contains urls of podcasts subscribed by user.
+	Subscribed map[string]struct{}
+}
+
+func (p *ExplorePage) isSubscribed(url string) bool {
+	_, ok := p.Subscribed[url]
+
+	return ok
+}
+
+//line internal/web/templates/explore.qtpl:18
+func (p *ExplorePage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/explore.qtpl:18
+	qw422016.N().S(`Explore`)
+//line internal/web/templates/explore.qtpl:18
+}
+
+//line internal/web/templates/explore.qtpl:18
+func (p *ExplorePage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/explore.qtpl:18
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/explore.qtpl:18
+	p.StreamTitle(qw422016)
+//line internal/web/templates/explore.qtpl:18
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/explore.qtpl:18
+}
+
+//line internal/web/templates/explore.qtpl:18
+func (p *ExplorePage) Title() string {
+//line internal/web/templates/explore.qtpl:18
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/explore.qtpl:18
+	p.WriteTitle(qb422016)
+//line internal/web/templates/explore.qtpl:18
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/explore.qtpl:18
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/explore.qtpl:18
+	return qs422016
+//line internal/web/templates/explore.qtpl:18
+}
+
+//line internal/web/templates/explore.qtpl:20
+func (p *ExplorePage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/explore.qtpl:20
+	qw422016.N().S(`
+<section>
+	<h1>Suggested podcasts</h1>
+	`)
+//line internal/web/templates/explore.qtpl:23
+	if len(p.Suggestions) == 0 {
+//line internal/web/templates/explore.qtpl:23
+		qw422016.N().S(`
+		<p>No suggestions yet.</p>
+	`)
+//line internal/web/templates/explore.qtpl:25
+	} else {
+//line internal/web/templates/explore.qtpl:25
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/explore.qtpl:26
+		p.streampodcastsTable(qw422016, pctx, p.Suggestions)
+//line internal/web/templates/explore.qtpl:26
+		qw422016.N().S(`
+	`)
+//line internal/web/templates/explore.qtpl:27
+	}
+//line internal/web/templates/explore.qtpl:27
+	qw422016.N().S(`
+</section>
+
+<section>
+	<h1>Popular podcasts</h1>
+	`)
+//line internal/web/templates/explore.qtpl:32
+	p.streampodcastsTable(qw422016, pctx, p.Toplist)
+//line internal/web/templates/explore.qtpl:32
+	qw422016.N().S(`
+</section>
+`)
+//line internal/web/templates/explore.qtpl:34
+}
+
+//line internal/web/templates/explore.qtpl:34
+func (p *ExplorePage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/explore.qtpl:34
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/explore.qtpl:34
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/explore.qtpl:34
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/explore.qtpl:34
+}
+
+//line internal/web/templates/explore.qtpl:34
+func (p *ExplorePage) Body(pctx *PageContext) string {
+//line internal/web/templates/explore.qtpl:34
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/explore.qtpl:34
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/explore.qtpl:34
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/explore.qtpl:34
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/explore.qtpl:34
+	return qs422016
+//line internal/web/templates/explore.qtpl:34
+}
+
+//line internal/web/templates/explore.qtpl:36
+func (p *ExplorePage) streampodcastsTable(qw422016 *qt422016.Writer, pctx *PageContext, podcasts model.Podcasts) {
+//line internal/web/templates/explore.qtpl:36
+	qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>Title</th>
+				<th>Description</th>
+				<th>Subscribers</th>
+				<th>&nbsp;</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/explore.qtpl:47
+	for _, po := range podcasts {
+//line internal/web/templates/explore.qtpl:47
+		qw422016.N().S(`
+				<tr>
+					<td>`)
+//line internal/web/templates/explore.qtpl:49
+		if po.Title != "" {
+//line internal/web/templates/explore.qtpl:49
+			qw422016.E().S(po.Title)
+//line internal/web/templates/explore.qtpl:49
+		} else {
+//line internal/web/templates/explore.qtpl:49
+			qw422016.E().S(po.URL)
+//line internal/web/templates/explore.qtpl:49
+		}
+//line internal/web/templates/explore.qtpl:49
+		qw422016.N().S(`</td>
+					<td>`)
+//line internal/web/templates/explore.qtpl:50
+		qw422016.E().S(shortString(po.Description, 200))
+//line internal/web/templates/explore.qtpl:50
+		qw422016.N().S(`</td>
+					<td>`)
+//line internal/web/templates/explore.qtpl:51
+		qw422016.N().D(po.Subscribers)
+//line internal/web/templates/explore.qtpl:51
+		qw422016.N().S(`</td>
+					<td>
+						`)
+//line internal/web/templates/explore.qtpl:53
+		if po.Website != "" {
+//line internal/web/templates/explore.qtpl:53
+			qw422016.N().S(`<a href="`)
+//line internal/web/templates/explore.qtpl:53
+			qw422016.E().S(po.Website)
+//line internal/web/templates/explore.qtpl:53
+			qw422016.N().S(`">Website</a><br/>`)
+//line internal/web/templates/explore.qtpl:53
+		}
+//line internal/web/templates/explore.qtpl:53
+		qw422016.N().S(`
+						`)
+//line internal/web/templates/explore.qtpl:54
+		if p.isSubscribed(po.URL) {
+//line internal/web/templates/explore.qtpl:54
+			qw422016.N().S(`
+							<small>Subscribed</small>
+						`)
+//line internal/web/templates/explore.qtpl:56
+		} else {
+//line internal/web/templates/explore.qtpl:56
+			qw422016.N().S(`
+							<form method="POST" action="`)
+//line internal/web/templates/explore.qtpl:57
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/explore.qtpl:57
+			qw422016.N().S(`/web/podcast/">
+								`)
+//line internal/web/templates/explore.qtpl:58
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/explore.qtpl:58
+			qw422016.N().S(`
+								<input type="hidden" name="url" value="`)
+//line internal/web/templates/explore.qtpl:59
+			qw422016.E().S(po.URL)
+//line internal/web/templates/explore.qtpl:59
+			qw422016.N().S(`" />
+								<button type="submit">Subscribe</button>
+							</form>
+						`)
+//line internal/web/templates/explore.qtpl:62
+		}
+//line internal/web/templates/explore.qtpl:62
+		qw422016.N().S(`
+					</td>
+				</tr>
+			`)
+//line internal/web/templates/explore.qtpl:65
+	}
+//line internal/web/templates/explore.qtpl:65
+	qw422016.N().S(`
+		</tbody>
+	</table>
+`)
+//line internal/web/templates/explore.qtpl:68
+}
+
+//line internal/web/templates/explore.qtpl:68
+func (p *ExplorePage) writepodcastsTable(qq422016 qtio422016.Writer, pctx *PageContext, podcasts model.Podcasts) {
+//line internal/web/templates/explore.qtpl:68
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/explore.qtpl:68
+	p.streampodcastsTable(qw422016, pctx, podcasts)
+//line internal/web/templates/explore.qtpl:68
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/explore.qtpl:68
+}
+
+//line internal/web/templates/explore.qtpl:68
+func (p *ExplorePage) podcastsTable(pctx *PageContext, podcasts model.Podcasts) string {
+//line internal/web/templates/explore.qtpl:68
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/explore.qtpl:68
+	p.writepodcastsTable(qb422016, pctx, podcasts)
+//line internal/web/templates/explore.qtpl:68
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/explore.qtpl:68
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/explore.qtpl:68
+	return qs422016
+//line internal/web/templates/explore.qtpl:68
+}
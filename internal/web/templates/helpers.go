@@ -8,11 +8,14 @@ package templates
 //
 
 import (
+	"context"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/common"
 )
 
 func formatDateTime(t time.Time) string {
@@ -27,24 +30,84 @@ func formatPInt32AsDuration(v *int32) string {
 	return (time.Duration(int(*v)) * time.Second).String()
 }
 
+func formatSecondsAsDuration(v int32) string {
+	if v <= 0 {
+		return ""
+	}
+
+	return (time.Duration(int(v)) * time.Second).String()
+}
+
+// formatInterval return duration without trailing zero units (1h instead of 1h0m0s).
+func formatInterval(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+
+	return s
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.DateOnly)
+}
+
+// formatSize return human readable size.
+func formatSize(size int64) string {
+	const unit = 1024
+
+	if size <= 0 {
+		return ""
+	}
+
+	if size < unit {
+		return strconv.FormatInt(size, 10) + " B"
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return strconv.FormatFloat(float64(size)/float64(div), 'f', 1, 64) + " " + string("KMGT"[exp]) + "iB"
+}
+
 type PageContext struct {
 	Webroot string
+	// CSRFToken must be included in all forms that use POST method.
+	CSRFToken string
 }
 
 type Renderer struct {
-	pageContext *PageContext
+	webroot string
 }
 
 func NewRenderer(i do.Injector) (*Renderer, error) {
 	return &Renderer{
-		&PageContext{
-			Webroot: do.MustInvokeNamed[string](i, "server.webroot"),
-		},
+		webroot: do.MustInvokeNamed[string](i, "server.webroot"),
 	}, nil
 }
 
-func (r *Renderer) WritePage(w io.Writer, p Page) {
-	WritePageTemplate(w, p, r.pageContext)
+func (r *Renderer) WritePage(ctx context.Context, w io.Writer, p Page) {
+	pctx := PageContext{
+		Webroot:   r.webroot,
+		CSRFToken: common.ContextCSRFToken(ctx),
+	}
+
+	WritePageTemplate(w, p, &pctx)
 }
 
 //------------------------------------------------------------------------------
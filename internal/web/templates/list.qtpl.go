@@ -0,0 +1,255 @@
+// Code generated by qtc from "list.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/list.qtpl:1
+package templates
+
+//line internal/web/templates/list.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/list.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/list.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/list.qtpl:4
+type ListPage struct {
+	List     *model.PodcastList
+	UserName string
+	// Owner is true when list belong to current user.
+	Owner bool
+}
+
+//line internal/web/templates/list.qtpl:12
+func (p *ListPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/list.qtpl:12
+	qw422016.N().S(`Podcast list - `)
+//line internal/web/templates/list.qtpl:12
+	qw422016.E().S(p.List.Title)
+//line internal/web/templates/list.qtpl:12
+}
+
+//line internal/web/templates/list.qtpl:12
+func (p *ListPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/list.qtpl:12
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/list.qtpl:12
+	p.StreamTitle(qw422016)
+//line internal/web/templates/list.qtpl:12
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/list.qtpl:12
+}
+
+//line internal/web/templates/list.qtpl:12
+func (p *ListPage) Title() string {
+//line internal/web/templates/list.qtpl:12
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/list.qtpl:12
+	p.WriteTitle(qb422016)
+//line internal/web/templates/list.qtpl:12
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/list.qtpl:12
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/list.qtpl:12
+	return qs422016
+//line internal/web/templates/list.qtpl:12
+}
+
+//line internal/web/templates/list.qtpl:14
+func (p *ListPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/list.qtpl:14
+	qw422016.N().S(`
+<section>
+	<h1>`)
+//line internal/web/templates/list.qtpl:16
+	qw422016.E().S(p.List.Title)
+//line internal/web/templates/list.qtpl:16
+	qw422016.N().S(`</h1>
+	<p>
+		Download:
+		`)
+//line internal/web/templates/list.qtpl:19
+	for _, f := range []string{"opml", "json", "txt", "xml"} {
+//line internal/web/templates/list.qtpl:19
+		qw422016.N().S(`
+			<a href="`)
+//line internal/web/templates/list.qtpl:20
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/list.qtpl:20
+		qw422016.N().S(`/api/2/lists/`)
+//line internal/web/templates/list.qtpl:20
+		qw422016.E().S(p.UserName)
+//line internal/web/templates/list.qtpl:20
+		qw422016.N().S(`/list/`)
+//line internal/web/templates/list.qtpl:20
+		qw422016.E().S(p.List.Name)
+//line internal/web/templates/list.qtpl:20
+		qw422016.N().S(`.`)
+//line internal/web/templates/list.qtpl:20
+		qw422016.E().S(f)
+//line internal/web/templates/list.qtpl:20
+		qw422016.N().S(`">`)
+//line internal/web/templates/list.qtpl:20
+		qw422016.E().S(f)
+//line internal/web/templates/list.qtpl:20
+		qw422016.N().S(`</a>
+		`)
+//line internal/web/templates/list.qtpl:21
+	}
+//line internal/web/templates/list.qtpl:21
+	qw422016.N().S(`
+	</p>
+	<table>
+		<thead>
+			<tr>
+				<th>Title</th>
+				<th>Description</th>
+				<th>Subscribers</th>
+				<th>&nbsp;</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/list.qtpl:33
+	for _, po := range p.List.Podcasts {
+//line internal/web/templates/list.qtpl:33
+		qw422016.N().S(`
+				<tr>
+					<td>`)
+//line internal/web/templates/list.qtpl:35
+		if po.Title != "" {
+//line internal/web/templates/list.qtpl:35
+			qw422016.E().S(po.Title)
+//line internal/web/templates/list.qtpl:35
+		} else {
+//line internal/web/templates/list.qtpl:35
+			qw422016.E().S(po.URL)
+//line internal/web/templates/list.qtpl:35
+		}
+//line internal/web/templates/list.qtpl:35
+		qw422016.N().S(`</td>
+					<td>`)
+//line internal/web/templates/list.qtpl:36
+		qw422016.E().S(shortString(po.Description, 200))
+//line internal/web/templates/list.qtpl:36
+		qw422016.N().S(`</td>
+					<td>`)
+//line internal/web/templates/list.qtpl:37
+		qw422016.N().D(po.Subscribers)
+//line internal/web/templates/list.qtpl:37
+		qw422016.N().S(`</td>
+					<td>
+						`)
+//line internal/web/templates/list.qtpl:39
+		if po.Website != "" {
+//line internal/web/templates/list.qtpl:39
+			qw422016.N().S(`<a href="`)
+//line internal/web/templates/list.qtpl:39
+			qw422016.E().S(po.Website)
+//line internal/web/templates/list.qtpl:39
+			qw422016.N().S(`">Website</a><br/>`)
+//line internal/web/templates/list.qtpl:39
+		}
+//line internal/web/templates/list.qtpl:39
+		qw422016.N().S(`
+						<form method="POST" action="`)
+//line internal/web/templates/list.qtpl:40
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/list.qtpl:40
+		qw422016.N().S(`/web/podcast/">
+							`)
+//line internal/web/templates/list.qtpl:41
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/list.qtpl:41
+		qw422016.N().S(`
+							<input type="hidden" name="url" value="`)
+//line internal/web/templates/list.qtpl:42
+		qw422016.E().S(po.URL)
+//line internal/web/templates/list.qtpl:42
+		qw422016.N().S(`" />
+							<button type="submit">Subscribe</button>
+						</form>
+					</td>
+				</tr>
+			`)
+//line internal/web/templates/list.qtpl:47
+	}
+//line internal/web/templates/list.qtpl:47
+	qw422016.N().S(`
+		</tbody>
+	</table>
+</section>
+
+`)
+//line internal/web/templates/list.qtpl:52
+	if p.Owner {
+//line internal/web/templates/list.qtpl:52
+		qw422016.N().S(`
+<section>
+	<form method="POST" action="`)
+//line internal/web/templates/list.qtpl:54
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/list.qtpl:54
+		qw422016.N().S(`/web/lists/`)
+//line internal/web/templates/list.qtpl:54
+		qw422016.E().S(p.UserName)
+//line internal/web/templates/list.qtpl:54
+		qw422016.N().S(`/`)
+//line internal/web/templates/list.qtpl:54
+		qw422016.E().S(p.List.Name)
+//line internal/web/templates/list.qtpl:54
+		qw422016.N().S(`/delete">
+		`)
+//line internal/web/templates/list.qtpl:55
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/list.qtpl:55
+		qw422016.N().S(`
+		<a href="`)
+//line internal/web/templates/list.qtpl:56
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/list.qtpl:56
+		qw422016.N().S(`/web/lists/">Back</a> <button type="submit">Delete list</button>
+	</form>
+</section>
+`)
+//line internal/web/templates/list.qtpl:59
+	}
+//line internal/web/templates/list.qtpl:59
+	qw422016.N().S(`
+`)
+//line internal/web/templates/list.qtpl:60
+}
+
+//line internal/web/templates/list.qtpl:60
+func (p *ListPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/list.qtpl:60
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/list.qtpl:60
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/list.qtpl:60
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/list.qtpl:60
+}
+
+//line internal/web/templates/list.qtpl:60
+func (p *ListPage) Body(pctx *PageContext) string {
+//line internal/web/templates/list.qtpl:60
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/list.qtpl:60
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/list.qtpl:60
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/list.qtpl:60
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/list.qtpl:60
+	return qs422016
+//line internal/web/templates/list.qtpl:60
+}
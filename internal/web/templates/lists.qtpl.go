@@ -0,0 +1,173 @@
+// Code generated by qtc from "lists.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/lists.qtpl:1
+package templates
+
+//line internal/web/templates/lists.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/lists.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/lists.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/lists.qtpl:4
+type ListsPage struct {
+	Lists    []model.PodcastList
+	UserName string
+}
+
+//line internal/web/templates/lists.qtpl:10
+func (p *ListsPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/lists.qtpl:10
+	qw422016.N().S(`Podcast lists`)
+//line internal/web/templates/lists.qtpl:10
+}
+
+//line internal/web/templates/lists.qtpl:10
+func (p *ListsPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/lists.qtpl:10
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/lists.qtpl:10
+	p.StreamTitle(qw422016)
+//line internal/web/templates/lists.qtpl:10
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/lists.qtpl:10
+}
+
+//line internal/web/templates/lists.qtpl:10
+func (p *ListsPage) Title() string {
+//line internal/web/templates/lists.qtpl:10
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/lists.qtpl:10
+	p.WriteTitle(qb422016)
+//line internal/web/templates/lists.qtpl:10
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/lists.qtpl:10
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/lists.qtpl:10
+	return qs422016
+//line internal/web/templates/lists.qtpl:10
+}
+
+//line internal/web/templates/lists.qtpl:12
+func (p *ListsPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/lists.qtpl:12
+	qw422016.N().S(`
+<section>
+	<h1>Podcast lists</h1>
+	`)
+//line internal/web/templates/lists.qtpl:15
+	if len(p.Lists) == 0 {
+//line internal/web/templates/lists.qtpl:15
+		qw422016.N().S(`
+		<p>No lists yet.</p>
+	`)
+//line internal/web/templates/lists.qtpl:17
+	} else {
+//line internal/web/templates/lists.qtpl:17
+		qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>Title</th>
+				<th>Name</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/lists.qtpl:26
+		for _, l := range p.Lists {
+//line internal/web/templates/lists.qtpl:26
+			qw422016.N().S(`
+			<tr>
+				<td><a href="`)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.N().S(`/web/lists/`)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.E().S(p.UserName)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.N().S(`/`)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.E().S(l.Name)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.N().S(`">`)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.E().S(l.Title)
+//line internal/web/templates/lists.qtpl:28
+			qw422016.N().S(`</a></td>
+				<td>`)
+//line internal/web/templates/lists.qtpl:29
+			qw422016.E().S(l.Name)
+//line internal/web/templates/lists.qtpl:29
+			qw422016.N().S(`</td>
+			</tr>
+			`)
+//line internal/web/templates/lists.qtpl:31
+		}
+//line internal/web/templates/lists.qtpl:31
+		qw422016.N().S(`
+		</tbody>
+	</table>
+	`)
+//line internal/web/templates/lists.qtpl:34
+	}
+//line internal/web/templates/lists.qtpl:34
+	qw422016.N().S(`
+</section>
+
+<section>
+	<h1>New list</h1>
+	<form method="POST">
+		`)
+//line internal/web/templates/lists.qtpl:40
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/lists.qtpl:40
+	qw422016.N().S(`
+		<label for="title">Title</label>
+		<input type="text" name="title" id="title" required />
+		<label for="podcasts">Podcasts URLs (one per line)</label>
+		<textarea name="podcasts" id="podcasts" rows="8"></textarea>
+		<button type="submit">Create</button>
+	</form>
+</section>
+`)
+//line internal/web/templates/lists.qtpl:48
+}
+
+//line internal/web/templates/lists.qtpl:48
+func (p *ListsPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/lists.qtpl:48
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/lists.qtpl:48
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/lists.qtpl:48
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/lists.qtpl:48
+}
+
+//line internal/web/templates/lists.qtpl:48
+func (p *ListsPage) Body(pctx *PageContext) string {
+//line internal/web/templates/lists.qtpl:48
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/lists.qtpl:48
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/lists.qtpl:48
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/lists.qtpl:48
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/lists.qtpl:48
+	return qs422016
+//line internal/web/templates/lists.qtpl:48
+}
@@ -0,0 +1,165 @@
+// Code generated by qtc from "login.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/login.qtpl:1
+package templates
+
+//line internal/web/templates/login.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/login.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/login.qtpl:2
+type LoginPage struct {
+	Msg      string
+	UserName string
+	// RegisterEnabled show link to registration form.
+	RegisterEnabled bool
+	// PasswordResetEnabled show link to forgot password form.
+	PasswordResetEnabled bool
+}
+
+//line internal/web/templates/login.qtpl:12
+func (p *LoginPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/login.qtpl:12
+	qw422016.N().S(`Login`)
+//line internal/web/templates/login.qtpl:12
+}
+
+//line internal/web/templates/login.qtpl:12
+func (p *LoginPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/login.qtpl:12
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/login.qtpl:12
+	p.StreamTitle(qw422016)
+//line internal/web/templates/login.qtpl:12
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/login.qtpl:12
+}
+
+//line internal/web/templates/login.qtpl:12
+func (p *LoginPage) Title() string {
+//line internal/web/templates/login.qtpl:12
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/login.qtpl:12
+	p.WriteTitle(qb422016)
+//line internal/web/templates/login.qtpl:12
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/login.qtpl:12
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/login.qtpl:12
+	return qs422016
+//line internal/web/templates/login.qtpl:12
+}
+
+//line internal/web/templates/login.qtpl:14
+func (p *LoginPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/login.qtpl:14
+	qw422016.N().S(`
+<section>
+	<h1>Login</h1>
+	`)
+//line internal/web/templates/login.qtpl:17
+	if p.Msg != "" {
+//line internal/web/templates/login.qtpl:17
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/login.qtpl:18
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/login.qtpl:18
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/login.qtpl:19
+	}
+//line internal/web/templates/login.qtpl:19
+	qw422016.N().S(`
+
+	<form method="post" action="`)
+//line internal/web/templates/login.qtpl:21
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/login.qtpl:21
+	qw422016.N().S(`/web/login">
+		`)
+//line internal/web/templates/login.qtpl:22
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/login.qtpl:22
+	qw422016.N().S(`
+		<fieldset>
+		<p><label>User name:</label> <input name="username" value="`)
+//line internal/web/templates/login.qtpl:24
+	qw422016.E().S(p.UserName)
+//line internal/web/templates/login.qtpl:24
+	qw422016.N().S(`" autocomplete="username" required autofocus></p>
+		<p><label>Password:</label> <input name="password" type="password" autocomplete="current-password" required></p>
+		<p><label><input name="remember" type="checkbox" value="1"> Remember me</label></p>
+		<p><button type="submit">Login</button></p>
+		</fieldset>
+	</form>
+	`)
+//line internal/web/templates/login.qtpl:30
+	if p.PasswordResetEnabled {
+//line internal/web/templates/login.qtpl:30
+		qw422016.N().S(`
+	<p><a href="`)
+//line internal/web/templates/login.qtpl:31
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/login.qtpl:31
+		qw422016.N().S(`/web/forgot-password">Forgot password?</a></p>
+	`)
+//line internal/web/templates/login.qtpl:32
+	}
+//line internal/web/templates/login.qtpl:32
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/login.qtpl:33
+	if p.RegisterEnabled {
+//line internal/web/templates/login.qtpl:33
+		qw422016.N().S(`
+	<p><a href="`)
+//line internal/web/templates/login.qtpl:34
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/login.qtpl:34
+		qw422016.N().S(`/web/register">Register new account</a></p>
+	`)
+//line internal/web/templates/login.qtpl:35
+	}
+//line internal/web/templates/login.qtpl:35
+	qw422016.N().S(`
+</section>
+`)
+//line internal/web/templates/login.qtpl:37
+}
+
+//line internal/web/templates/login.qtpl:37
+func (p *LoginPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/login.qtpl:37
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/login.qtpl:37
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/login.qtpl:37
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/login.qtpl:37
+}
+
+//line internal/web/templates/login.qtpl:37
+func (p *LoginPage) Body(pctx *PageContext) string {
+//line internal/web/templates/login.qtpl:37
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/login.qtpl:37
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/login.qtpl:37
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/login.qtpl:37
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/login.qtpl:37
+	return qs422016
+//line internal/web/templates/login.qtpl:37
+}
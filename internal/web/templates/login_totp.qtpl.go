@@ -0,0 +1,130 @@
+// Code generated by qtc from "login_totp.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/login_totp.qtpl:1
+package templates
+
+//line internal/web/templates/login_totp.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/login_totp.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/login_totp.qtpl:2
+type LoginTOTPPage struct {
+	Msg string
+}
+
+//line internal/web/templates/login_totp.qtpl:7
+func (p *LoginTOTPPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/login_totp.qtpl:7
+	qw422016.N().S(`Login`)
+//line internal/web/templates/login_totp.qtpl:7
+}
+
+//line internal/web/templates/login_totp.qtpl:7
+func (p *LoginTOTPPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/login_totp.qtpl:7
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/login_totp.qtpl:7
+	p.StreamTitle(qw422016)
+//line internal/web/templates/login_totp.qtpl:7
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/login_totp.qtpl:7
+}
+
+//line internal/web/templates/login_totp.qtpl:7
+func (p *LoginTOTPPage) Title() string {
+//line internal/web/templates/login_totp.qtpl:7
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/login_totp.qtpl:7
+	p.WriteTitle(qb422016)
+//line internal/web/templates/login_totp.qtpl:7
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/login_totp.qtpl:7
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/login_totp.qtpl:7
+	return qs422016
+//line internal/web/templates/login_totp.qtpl:7
+}
+
+//line internal/web/templates/login_totp.qtpl:9
+func (p *LoginTOTPPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/login_totp.qtpl:9
+	qw422016.N().S(`
+<section>
+	<h1>Two-factor authentication</h1>
+	`)
+//line internal/web/templates/login_totp.qtpl:12
+	if p.Msg != "" {
+//line internal/web/templates/login_totp.qtpl:12
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/login_totp.qtpl:13
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/login_totp.qtpl:13
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/login_totp.qtpl:14
+	}
+//line internal/web/templates/login_totp.qtpl:14
+	qw422016.N().S(`
+
+	<form method="post" action="`)
+//line internal/web/templates/login_totp.qtpl:16
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/login_totp.qtpl:16
+	qw422016.N().S(`/web/login/totp">
+		`)
+//line internal/web/templates/login_totp.qtpl:17
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/login_totp.qtpl:17
+	qw422016.N().S(`
+		<fieldset>
+		<p>Enter code from authenticator app or one of recovery codes.</p>
+		<p><label>Code:</label> <input name="code" autocomplete="one-time-code" required autofocus></p>
+		<p><button type="submit">Verify</button></p>
+		</fieldset>
+	</form>
+	<p><a href="`)
+//line internal/web/templates/login_totp.qtpl:24
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/login_totp.qtpl:24
+	qw422016.N().S(`/web/login">Back to login</a></p>
+</section>
+`)
+//line internal/web/templates/login_totp.qtpl:26
+}
+
+//line internal/web/templates/login_totp.qtpl:26
+func (p *LoginTOTPPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/login_totp.qtpl:26
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/login_totp.qtpl:26
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/login_totp.qtpl:26
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/login_totp.qtpl:26
+}
+
+//line internal/web/templates/login_totp.qtpl:26
+func (p *LoginTOTPPage) Body(pctx *PageContext) string {
+//line internal/web/templates/login_totp.qtpl:26
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/login_totp.qtpl:26
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/login_totp.qtpl:26
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/login_totp.qtpl:26
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/login_totp.qtpl:26
+	return qs422016
+//line internal/web/templates/login_totp.qtpl:26
+}
@@ -0,0 +1,309 @@
+// Code generated by qtc from "password_reset.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/password_reset.qtpl:1
+package templates
+
+//line internal/web/templates/password_reset.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/password_reset.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/password_reset.qtpl:2
+type ForgotPasswordPage struct {
+	Msg      string
+	UserName string
+	// Sent is true when request was accepted; page not reveal is account exists.
+	Sent bool
+}
+
+//line internal/web/templates/password_reset.qtpl:10
+func (p *ForgotPasswordPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/password_reset.qtpl:10
+	qw422016.N().S(`Forgot password`)
+//line internal/web/templates/password_reset.qtpl:10
+}
+
+//line internal/web/templates/password_reset.qtpl:10
+func (p *ForgotPasswordPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/password_reset.qtpl:10
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/password_reset.qtpl:10
+	p.StreamTitle(qw422016)
+//line internal/web/templates/password_reset.qtpl:10
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/password_reset.qtpl:10
+}
+
+//line internal/web/templates/password_reset.qtpl:10
+func (p *ForgotPasswordPage) Title() string {
+//line internal/web/templates/password_reset.qtpl:10
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/password_reset.qtpl:10
+	p.WriteTitle(qb422016)
+//line internal/web/templates/password_reset.qtpl:10
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/password_reset.qtpl:10
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/password_reset.qtpl:10
+	return qs422016
+//line internal/web/templates/password_reset.qtpl:10
+}
+
+//line internal/web/templates/password_reset.qtpl:12
+func (p *ForgotPasswordPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/password_reset.qtpl:12
+	qw422016.N().S(`
+<section>
+	<h1>Forgot password</h1>
+	`)
+//line internal/web/templates/password_reset.qtpl:15
+	if p.Sent {
+//line internal/web/templates/password_reset.qtpl:15
+		qw422016.N().S(`
+		<p>If account exists and has email address, link for setting new password was sent.
+		Link is valid for one hour.</p>
+	`)
+//line internal/web/templates/password_reset.qtpl:18
+	} else {
+//line internal/web/templates/password_reset.qtpl:18
+		qw422016.N().S(`
+	`)
+//line internal/web/templates/password_reset.qtpl:19
+		if p.Msg != "" {
+//line internal/web/templates/password_reset.qtpl:19
+			qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/password_reset.qtpl:20
+			qw422016.E().S(p.Msg)
+//line internal/web/templates/password_reset.qtpl:20
+			qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/password_reset.qtpl:21
+		}
+//line internal/web/templates/password_reset.qtpl:21
+		qw422016.N().S(`
+
+	<form method="post" action="`)
+//line internal/web/templates/password_reset.qtpl:23
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/password_reset.qtpl:23
+		qw422016.N().S(`/web/forgot-password">
+		`)
+//line internal/web/templates/password_reset.qtpl:24
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/password_reset.qtpl:24
+		qw422016.N().S(`
+		<fieldset>
+		<p><label>User name:</label> <input name="username" value="`)
+//line internal/web/templates/password_reset.qtpl:26
+		qw422016.E().S(p.UserName)
+//line internal/web/templates/password_reset.qtpl:26
+		qw422016.N().S(`" autocomplete="username" required autofocus></p>
+		<p><button type="submit">Send reset link</button></p>
+		</fieldset>
+	</form>
+	`)
+//line internal/web/templates/password_reset.qtpl:30
+	}
+//line internal/web/templates/password_reset.qtpl:30
+	qw422016.N().S(`
+	<p><a href="`)
+//line internal/web/templates/password_reset.qtpl:31
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/password_reset.qtpl:31
+	qw422016.N().S(`/web/login">Login</a></p>
+</section>
+`)
+//line internal/web/templates/password_reset.qtpl:33
+}
+
+//line internal/web/templates/password_reset.qtpl:33
+func (p *ForgotPasswordPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/password_reset.qtpl:33
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/password_reset.qtpl:33
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/password_reset.qtpl:33
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/password_reset.qtpl:33
+}
+
+//line internal/web/templates/password_reset.qtpl:33
+func (p *ForgotPasswordPage) Body(pctx *PageContext) string {
+//line internal/web/templates/password_reset.qtpl:33
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/password_reset.qtpl:33
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/password_reset.qtpl:33
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/password_reset.qtpl:33
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/password_reset.qtpl:33
+	return qs422016
+//line internal/web/templates/password_reset.qtpl:33
+}
+
+//line internal/web/templates/password_reset.qtpl:36
+type ResetPasswordPage struct {
+	Msg      string
+	Token    string
+	UserName string
+	// Invalid is true when token is invalid or expired.
+	Invalid bool
+	// Done is true when password was changed.
+	Done bool
+}
+
+//line internal/web/templates/password_reset.qtpl:47
+func (p *ResetPasswordPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/password_reset.qtpl:47
+	qw422016.N().S(`Reset password`)
+//line internal/web/templates/password_reset.qtpl:47
+}
+
+//line internal/web/templates/password_reset.qtpl:47
+func (p *ResetPasswordPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/password_reset.qtpl:47
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/password_reset.qtpl:47
+	p.StreamTitle(qw422016)
+//line internal/web/templates/password_reset.qtpl:47
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/password_reset.qtpl:47
+}
+
+//line internal/web/templates/password_reset.qtpl:47
+func (p *ResetPasswordPage) Title() string {
+//line internal/web/templates/password_reset.qtpl:47
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/password_reset.qtpl:47
+	p.WriteTitle(qb422016)
+//line internal/web/templates/password_reset.qtpl:47
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/password_reset.qtpl:47
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/password_reset.qtpl:47
+	return qs422016
+//line internal/web/templates/password_reset.qtpl:47
+}
+
+//line internal/web/templates/password_reset.qtpl:49
+func (p *ResetPasswordPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/password_reset.qtpl:49
+	qw422016.N().S(`
+<section>
+	<h1>Reset password</h1>
+	`)
+//line internal/web/templates/password_reset.qtpl:52
+	switch {
+//line internal/web/templates/password_reset.qtpl:53
+	case p.Done:
+//line internal/web/templates/password_reset.qtpl:53
+		qw422016.N().S(`
+		<p>Password changed. You can <a href="`)
+//line internal/web/templates/password_reset.qtpl:54
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/password_reset.qtpl:54
+		qw422016.N().S(`/web/login">login</a> now.</p>
+	`)
+//line internal/web/templates/password_reset.qtpl:55
+	case p.Invalid:
+//line internal/web/templates/password_reset.qtpl:55
+		qw422016.N().S(`
+		<p><b>Invalid or expired password reset link.</b></p>
+		<p><a href="`)
+//line internal/web/templates/password_reset.qtpl:57
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/password_reset.qtpl:57
+		qw422016.N().S(`/web/forgot-password">Request new link</a></p>
+	`)
+//line internal/web/templates/password_reset.qtpl:58
+	default:
+//line internal/web/templates/password_reset.qtpl:58
+		qw422016.N().S(`
+	`)
+//line internal/web/templates/password_reset.qtpl:59
+		if p.Msg != "" {
+//line internal/web/templates/password_reset.qtpl:59
+			qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/password_reset.qtpl:60
+			qw422016.E().S(p.Msg)
+//line internal/web/templates/password_reset.qtpl:60
+			qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/password_reset.qtpl:61
+		}
+//line internal/web/templates/password_reset.qtpl:61
+		qw422016.N().S(`
+
+	<form method="post" action="`)
+//line internal/web/templates/password_reset.qtpl:63
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/password_reset.qtpl:63
+		qw422016.N().S(`/web/reset-password">
+		`)
+//line internal/web/templates/password_reset.qtpl:64
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/password_reset.qtpl:64
+		qw422016.N().S(`
+		<input type="hidden" name="token" value="`)
+//line internal/web/templates/password_reset.qtpl:65
+		qw422016.E().S(p.Token)
+//line internal/web/templates/password_reset.qtpl:65
+		qw422016.N().S(`">
+		<fieldset>
+		<p><label>User name:</label> <input name="username" value="`)
+//line internal/web/templates/password_reset.qtpl:67
+		qw422016.E().S(p.UserName)
+//line internal/web/templates/password_reset.qtpl:67
+		qw422016.N().S(`" autocomplete="username" readonly></p>
+		<p><label>New password:</label> <input name="password" type="password" autocomplete="new-password" required autofocus></p>
+		<p><label>Repeat password:</label> <input name="password2" type="password" autocomplete="new-password" required></p>
+		<p><button type="submit">Set password</button></p>
+		</fieldset>
+	</form>
+	`)
+//line internal/web/templates/password_reset.qtpl:73
+	}
+//line internal/web/templates/password_reset.qtpl:73
+	qw422016.N().S(`
+</section>
+`)
+//line internal/web/templates/password_reset.qtpl:75
+}
+
+//line internal/web/templates/password_reset.qtpl:75
+func (p *ResetPasswordPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/password_reset.qtpl:75
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/password_reset.qtpl:75
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/password_reset.qtpl:75
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/password_reset.qtpl:75
+}
+
+//line internal/web/templates/password_reset.qtpl:75
+func (p *ResetPasswordPage) Body(pctx *PageContext) string {
+//line internal/web/templates/password_reset.qtpl:75
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/password_reset.qtpl:75
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/password_reset.qtpl:75
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/password_reset.qtpl:75
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/password_reset.qtpl:75
+	return qs422016
+//line internal/web/templates/password_reset.qtpl:75
+}
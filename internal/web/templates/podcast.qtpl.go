@@ -22,164 +22,425 @@ var (
 
 //line internal/web/templates/podcast.qtpl:4
 type PodcastPage struct {
-	Podcast *model.Podcast
+	Podcast   *model.Podcast
+	Episodes  []model.Episode
+	FeedState *model.FeedState
 }
 
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 func (p *PodcastPage) StreamTitle(qw422016 *qt422016.Writer) {
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	qw422016.N().S(`Podcasts`)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 }
 
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 func (p *PodcastPage) WriteTitle(qq422016 qtio422016.Writer) {
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	p.StreamTitle(qw422016)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 }
 
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 func (p *PodcastPage) Title() string {
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	p.WriteTitle(qb422016)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 	return qs422016
-//line internal/web/templates/podcast.qtpl:9
+//line internal/web/templates/podcast.qtpl:11
 }
 
-//line internal/web/templates/podcast.qtpl:11
+//line internal/web/templates/podcast.qtpl:13
 func (p *PodcastPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
-//line internal/web/templates/podcast.qtpl:11
+//line internal/web/templates/podcast.qtpl:13
 	qw422016.N().S(`
 <section>
 	<h1>Podcast</h1>
 
 	`)
-//line internal/web/templates/podcast.qtpl:15
+//line internal/web/templates/podcast.qtpl:17
 	if p.Podcast != nil {
-//line internal/web/templates/podcast.qtpl:15
+//line internal/web/templates/podcast.qtpl:17
 		qw422016.N().S(`
 		<dl>
 			<dt>Title<dt><dd>`)
-//line internal/web/templates/podcast.qtpl:17
+//line internal/web/templates/podcast.qtpl:19
 		qw422016.E().S(p.Podcast.Title)
-//line internal/web/templates/podcast.qtpl:17
+//line internal/web/templates/podcast.qtpl:19
 		qw422016.N().S(`</dd>
 			<dt>URL<dt><dd><a href="`)
-//line internal/web/templates/podcast.qtpl:18
+//line internal/web/templates/podcast.qtpl:20
 		qw422016.E().S(p.Podcast.URL)
-//line internal/web/templates/podcast.qtpl:18
+//line internal/web/templates/podcast.qtpl:20
 		qw422016.N().S(`">`)
-//line internal/web/templates/podcast.qtpl:18
+//line internal/web/templates/podcast.qtpl:20
 		qw422016.E().S(p.Podcast.URL)
-//line internal/web/templates/podcast.qtpl:18
+//line internal/web/templates/podcast.qtpl:20
 		qw422016.N().S(`</a></dd>
 			<dt>Description<dt><dd>`)
-//line internal/web/templates/podcast.qtpl:19
+//line internal/web/templates/podcast.qtpl:21
 		qw422016.E().S(p.Podcast.Description)
-//line internal/web/templates/podcast.qtpl:19
+//line internal/web/templates/podcast.qtpl:21
 		qw422016.N().S(`</dd>
 			<dt>Website<dt>
 			<dd>
 				`)
-//line internal/web/templates/podcast.qtpl:22
+//line internal/web/templates/podcast.qtpl:24
 		if p.Podcast.Website != "" {
-//line internal/web/templates/podcast.qtpl:22
+//line internal/web/templates/podcast.qtpl:24
 			qw422016.N().S(`
 					<a href="`)
-//line internal/web/templates/podcast.qtpl:23
+//line internal/web/templates/podcast.qtpl:25
 			qw422016.E().S(p.Podcast.Website)
-//line internal/web/templates/podcast.qtpl:23
+//line internal/web/templates/podcast.qtpl:25
 			qw422016.N().S(`">`)
-//line internal/web/templates/podcast.qtpl:23
+//line internal/web/templates/podcast.qtpl:25
 			qw422016.E().S(p.Podcast.Website)
-//line internal/web/templates/podcast.qtpl:23
+//line internal/web/templates/podcast.qtpl:25
 			qw422016.N().S(`</a>
 				`)
-//line internal/web/templates/podcast.qtpl:24
+//line internal/web/templates/podcast.qtpl:26
 		}
-//line internal/web/templates/podcast.qtpl:24
+//line internal/web/templates/podcast.qtpl:26
 		qw422016.N().S(`
 			</dd>
 		</dl>
 	`)
-//line internal/web/templates/podcast.qtpl:27
+//line internal/web/templates/podcast.qtpl:29
 	}
-//line internal/web/templates/podcast.qtpl:27
+//line internal/web/templates/podcast.qtpl:29
 	qw422016.N().S(`
 
 	`)
-//line internal/web/templates/podcast.qtpl:29
+//line internal/web/templates/podcast.qtpl:31
 	if p.Podcast.Subscribed {
-//line internal/web/templates/podcast.qtpl:29
+//line internal/web/templates/podcast.qtpl:31
 		qw422016.N().S(`
 		<form method="POST" action="unsubscribe">
+			`)
+//line internal/web/templates/podcast.qtpl:33
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/podcast.qtpl:33
+		qw422016.N().S(`
 			<button type="submit">Unsubscribe</button>
 		</form>
 	`)
-//line internal/web/templates/podcast.qtpl:33
+//line internal/web/templates/podcast.qtpl:36
 	} else {
-//line internal/web/templates/podcast.qtpl:33
+//line internal/web/templates/podcast.qtpl:36
 		qw422016.N().S(`
 		<form method="POST" action="resubscribe">
+			`)
+//line internal/web/templates/podcast.qtpl:38
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/podcast.qtpl:38
+		qw422016.N().S(`
 			<button type="submit">Subscribe again</button>
 		</form>
 	`)
-//line internal/web/templates/podcast.qtpl:37
+//line internal/web/templates/podcast.qtpl:41
 	}
-//line internal/web/templates/podcast.qtpl:37
+//line internal/web/templates/podcast.qtpl:41
 	qw422016.N().S(`
 	<a href="`)
-//line internal/web/templates/podcast.qtpl:38
+//line internal/web/templates/podcast.qtpl:42
 	qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/podcast.qtpl:38
+//line internal/web/templates/podcast.qtpl:42
 	qw422016.N().S(`/web/podcast/`)
-//line internal/web/templates/podcast.qtpl:38
+//line internal/web/templates/podcast.qtpl:42
 	qw422016.N().D(int(p.Podcast.ID))
-//line internal/web/templates/podcast.qtpl:38
+//line internal/web/templates/podcast.qtpl:42
 	qw422016.N().S(`/delete">Delete podcast</a>
 </section>
 
+`)
+//line internal/web/templates/podcast.qtpl:45
+	if p.FeedState != nil {
+//line internal/web/templates/podcast.qtpl:45
+		qw422016.N().S(`
+<section>
+	<h2>Feed health</h2>
+
+	`)
+//line internal/web/templates/podcast.qtpl:49
+		fs := p.FeedState
+
+//line internal/web/templates/podcast.qtpl:49
+		qw422016.N().S(`
+	<dl>
+		<dt>Status<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:51
+		qw422016.E().S(fs.Health())
+//line internal/web/templates/podcast.qtpl:51
+		qw422016.N().S(`</dd>
+		`)
+//line internal/web/templates/podcast.qtpl:52
+		if !fs.LastFetchAt.IsZero() {
+//line internal/web/templates/podcast.qtpl:52
+			qw422016.N().S(`
+			<dt>Last fetch<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:53
+			qw422016.E().S(formatDateTime(fs.LastFetchAt))
+//line internal/web/templates/podcast.qtpl:53
+			qw422016.N().S(` (HTTP status: `)
+//line internal/web/templates/podcast.qtpl:53
+			qw422016.N().D(fs.LastStatus)
+//line internal/web/templates/podcast.qtpl:53
+			qw422016.N().S(`)</dd>
+		`)
+//line internal/web/templates/podcast.qtpl:54
+		}
+//line internal/web/templates/podcast.qtpl:54
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/podcast.qtpl:55
+		if fs.Failures > 0 {
+//line internal/web/templates/podcast.qtpl:55
+			qw422016.N().S(`
+			<dt>Consecutive failures<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:56
+			qw422016.N().D(fs.Failures)
+//line internal/web/templates/podcast.qtpl:56
+			qw422016.N().S(`</dd>
+			<dt>Last error<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:57
+			qw422016.E().S(fs.LastError)
+//line internal/web/templates/podcast.qtpl:57
+			qw422016.N().S(`</dd>
+		`)
+//line internal/web/templates/podcast.qtpl:58
+		}
+//line internal/web/templates/podcast.qtpl:58
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/podcast.qtpl:59
+		if !fs.Dead && !fs.NextRetryAt.IsZero() {
+//line internal/web/templates/podcast.qtpl:59
+			qw422016.N().S(`
+			<dt>Next retry<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:60
+			qw422016.E().S(formatDateTime(fs.NextRetryAt))
+//line internal/web/templates/podcast.qtpl:60
+			qw422016.N().S(`</dd>
+		`)
+//line internal/web/templates/podcast.qtpl:61
+		}
+//line internal/web/templates/podcast.qtpl:61
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/podcast.qtpl:62
+		interval, source := fs.Interval()
+
+//line internal/web/templates/podcast.qtpl:62
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/podcast.qtpl:63
+		if interval > 0 {
+//line internal/web/templates/podcast.qtpl:63
+			qw422016.N().S(`
+			<dt>Check interval<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:64
+			qw422016.E().S(formatInterval(interval))
+//line internal/web/templates/podcast.qtpl:64
+			qw422016.N().S(` (`)
+//line internal/web/templates/podcast.qtpl:64
+			qw422016.E().S(source)
+//line internal/web/templates/podcast.qtpl:64
+			qw422016.N().S(`)</dd>
+		`)
+//line internal/web/templates/podcast.qtpl:65
+		}
+//line internal/web/templates/podcast.qtpl:65
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/podcast.qtpl:66
+		if !fs.Dead && !fs.NextCheckAt.IsZero() {
+//line internal/web/templates/podcast.qtpl:66
+			qw422016.N().S(`
+			<dt>Next check<dt><dd>`)
+//line internal/web/templates/podcast.qtpl:67
+			qw422016.E().S(formatDateTime(fs.NextCheckAt))
+//line internal/web/templates/podcast.qtpl:67
+			qw422016.N().S(`</dd>
+		`)
+//line internal/web/templates/podcast.qtpl:68
+		}
+//line internal/web/templates/podcast.qtpl:68
+		qw422016.N().S(`
+	</dl>
+</section>
+`)
+//line internal/web/templates/podcast.qtpl:71
+	}
+//line internal/web/templates/podcast.qtpl:71
+	qw422016.N().S(`
+
+`)
+//line internal/web/templates/podcast.qtpl:73
+	if len(p.Episodes) > 0 {
+//line internal/web/templates/podcast.qtpl:73
+		qw422016.N().S(`
+<section>
+	<h2>Latest episodes</h2>
+
+	<table>
+		<thead>
+			<tr><th></th><th>Episode</th><th>Released</th><th>Duration</th><th>File</th></tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/podcast.qtpl:82
+		for _, e := range p.Episodes {
+//line internal/web/templates/podcast.qtpl:82
+			qw422016.N().S(`
+			<tr>
+				<td>`)
+//line internal/web/templates/podcast.qtpl:84
+			if e.ImageURL != "" {
+//line internal/web/templates/podcast.qtpl:84
+				qw422016.N().S(`<img src="`)
+//line internal/web/templates/podcast.qtpl:84
+				qw422016.E().S(e.ImageURL)
+//line internal/web/templates/podcast.qtpl:84
+				qw422016.N().S(`" alt="" width="64" loading="lazy"/>`)
+//line internal/web/templates/podcast.qtpl:84
+			}
+//line internal/web/templates/podcast.qtpl:84
+			qw422016.N().S(`</td>
+				<td>
+					`)
+//line internal/web/templates/podcast.qtpl:86
+			if e.Link != "" {
+//line internal/web/templates/podcast.qtpl:86
+				qw422016.N().S(`
+						<a href="`)
+//line internal/web/templates/podcast.qtpl:87
+				qw422016.E().S(e.Link)
+//line internal/web/templates/podcast.qtpl:87
+				qw422016.N().S(`">`)
+//line internal/web/templates/podcast.qtpl:87
+				qw422016.E().S(e.Title)
+//line internal/web/templates/podcast.qtpl:87
+				qw422016.N().S(`</a>
+					`)
+//line internal/web/templates/podcast.qtpl:88
+			} else {
+//line internal/web/templates/podcast.qtpl:88
+				qw422016.N().S(`
+						`)
+//line internal/web/templates/podcast.qtpl:89
+				qw422016.E().S(e.Title)
+//line internal/web/templates/podcast.qtpl:89
+				qw422016.N().S(`
+					`)
+//line internal/web/templates/podcast.qtpl:90
+			}
+//line internal/web/templates/podcast.qtpl:90
+			qw422016.N().S(`
+					`)
+//line internal/web/templates/podcast.qtpl:91
+			if e.Description != "" {
+//line internal/web/templates/podcast.qtpl:91
+				qw422016.N().S(`<br/><small>`)
+//line internal/web/templates/podcast.qtpl:91
+				qw422016.E().S(shortString(e.Description, 300))
+//line internal/web/templates/podcast.qtpl:91
+				qw422016.N().S(`</small>`)
+//line internal/web/templates/podcast.qtpl:91
+			}
+//line internal/web/templates/podcast.qtpl:91
+			qw422016.N().S(`
+				</td>
+				<td>`)
+//line internal/web/templates/podcast.qtpl:93
+			qw422016.E().S(formatDate(e.Released))
+//line internal/web/templates/podcast.qtpl:93
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/podcast.qtpl:94
+			qw422016.E().S(formatSecondsAsDuration(e.Duration))
+//line internal/web/templates/podcast.qtpl:94
+			qw422016.N().S(`</td>
+				<td>
+					<a href="`)
+//line internal/web/templates/podcast.qtpl:96
+			qw422016.E().S(e.URL)
+//line internal/web/templates/podcast.qtpl:96
+			qw422016.N().S(`">download</a>
+					`)
+//line internal/web/templates/podcast.qtpl:97
+			if e.EnclosureType != "" || e.EnclosureSize > 0 {
+//line internal/web/templates/podcast.qtpl:97
+				qw422016.N().S(`
+						<br/><small>`)
+//line internal/web/templates/podcast.qtpl:98
+				qw422016.E().S(e.EnclosureType)
+//line internal/web/templates/podcast.qtpl:98
+				qw422016.N().S(` `)
+//line internal/web/templates/podcast.qtpl:98
+				qw422016.E().S(formatSize(e.EnclosureSize))
+//line internal/web/templates/podcast.qtpl:98
+				qw422016.N().S(`</small>
+					`)
+//line internal/web/templates/podcast.qtpl:99
+			}
+//line internal/web/templates/podcast.qtpl:99
+			qw422016.N().S(`
+				</td>
+			</tr>
+			`)
+//line internal/web/templates/podcast.qtpl:102
+		}
+//line internal/web/templates/podcast.qtpl:102
+		qw422016.N().S(`
+		</tbody>
+	</table>
+</section>
+`)
+//line internal/web/templates/podcast.qtpl:106
+	}
+//line internal/web/templates/podcast.qtpl:106
+	qw422016.N().S(`
+
 
 `)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 }
 
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 func (p *PodcastPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 }
 
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 func (p *PodcastPage) Body(pctx *PageContext) string {
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 	return qs422016
-//line internal/web/templates/podcast.qtpl:42
+//line internal/web/templates/podcast.qtpl:109
 }
 
 // # vim:ft=mako:ts=4:
@@ -66,58 +66,63 @@ func (p *PodcastDeletePage) StreamBody(qw422016 *qt422016.Writer, pctx *PageCont
 	<h1>Delete podcast</h1>
 
 	<form method="POST">
+		`)
+//line internal/web/templates/podcast_delete.qtpl:16
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/podcast_delete.qtpl:16
+	qw422016.N().S(`
 		<p>Delete podcast
 			<em>`)
-//line internal/web/templates/podcast_delete.qtpl:17
+//line internal/web/templates/podcast_delete.qtpl:18
 	if p.Podcast.Title != "" {
-//line internal/web/templates/podcast_delete.qtpl:17
+//line internal/web/templates/podcast_delete.qtpl:18
 		qw422016.E().S(p.Podcast.Title)
-//line internal/web/templates/podcast_delete.qtpl:17
+//line internal/web/templates/podcast_delete.qtpl:18
 	} else {
-//line internal/web/templates/podcast_delete.qtpl:17
+//line internal/web/templates/podcast_delete.qtpl:18
 		qw422016.E().S(p.Podcast.URL)
-//line internal/web/templates/podcast_delete.qtpl:17
+//line internal/web/templates/podcast_delete.qtpl:18
 	}
-//line internal/web/templates/podcast_delete.qtpl:17
+//line internal/web/templates/podcast_delete.qtpl:18
 	qw422016.N().S(`</em>?
 			</p>
 		<a href="`)
-//line internal/web/templates/podcast_delete.qtpl:19
+//line internal/web/templates/podcast_delete.qtpl:20
 	qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/podcast_delete.qtpl:19
+//line internal/web/templates/podcast_delete.qtpl:20
 	qw422016.N().S(`/web/podcast/">Back</a> <button type="submit">Delete</button>
 	</form>
 </section>
 
 
 `)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 }
 
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 func (p *PodcastDeletePage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 }
 
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 func (p *PodcastDeletePage) Body(pctx *PageContext) string {
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 	return qs422016
-//line internal/web/templates/podcast_delete.qtpl:24
+//line internal/web/templates/podcast_delete.qtpl:25
 }
 
 // # vim:ft=mako:ts=4:
@@ -24,47 +24,53 @@ var (
 type PodcastsPage struct {
 	Podcasts       []model.PodcastWithLastEpisode
 	SubscribedOnly bool
+	Tag            string
 }
 
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 func (p *PodcastsPage) StreamTitle(qw422016 *qt422016.Writer) {
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	qw422016.N().S(`Podcasts`)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 }
 
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 func (p *PodcastsPage) WriteTitle(qq422016 qtio422016.Writer) {
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	p.StreamTitle(qw422016)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 }
 
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 func (p *PodcastsPage) Title() string {
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	p.WriteTitle(qb422016)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 	return qs422016
-//line internal/web/templates/podcasts.qtpl:10
+//line internal/web/templates/podcasts.qtpl:11
 }
 
-//line internal/web/templates/podcasts.qtpl:12
+//line internal/web/templates/podcasts.qtpl:13
 func (p *PodcastsPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
-//line internal/web/templates/podcasts.qtpl:12
+//line internal/web/templates/podcasts.qtpl:13
 	qw422016.N().S(`
 <section>
 	<form method="POST">
+		`)
+//line internal/web/templates/podcasts.qtpl:16
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/podcasts.qtpl:16
+	qw422016.N().S(`
 		<fieldset>
 			<legend>Add podcast</legend>
 			<label>URL:</label> <input type="text" name="url" />
@@ -75,31 +81,61 @@ func (p *PodcastsPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext)
 
 <section>
 	`)
-//line internal/web/templates/podcasts.qtpl:24
+//line internal/web/templates/podcasts.qtpl:26
 	if p.SubscribedOnly {
-//line internal/web/templates/podcasts.qtpl:24
+//line internal/web/templates/podcasts.qtpl:26
 		qw422016.N().S(`
 		<h1>Subscribed podcasts</h1>
 		<a href="`)
-//line internal/web/templates/podcasts.qtpl:26
+//line internal/web/templates/podcasts.qtpl:28
 		qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/podcasts.qtpl:26
+//line internal/web/templates/podcasts.qtpl:28
 		qw422016.N().S(`/web/podcast/?showall">Show all podcasts</a>
 	`)
-//line internal/web/templates/podcasts.qtpl:27
+//line internal/web/templates/podcasts.qtpl:29
 	} else {
-//line internal/web/templates/podcasts.qtpl:27
+//line internal/web/templates/podcasts.qtpl:29
 		qw422016.N().S(`
 		<h1>All user podcasts</h1>
 		<a href="`)
-//line internal/web/templates/podcasts.qtpl:29
+//line internal/web/templates/podcasts.qtpl:31
 		qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/podcasts.qtpl:29
+//line internal/web/templates/podcasts.qtpl:31
 		qw422016.N().S(`/web/podcast/">Show subscribed only</a>
 	`)
-//line internal/web/templates/podcasts.qtpl:30
+//line internal/web/templates/podcasts.qtpl:32
+	}
+//line internal/web/templates/podcasts.qtpl:32
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/podcasts.qtpl:33
+	if p.Tag != "" {
+//line internal/web/templates/podcasts.qtpl:33
+		qw422016.N().S(`
+		<p>
+			Podcasts tagged: <b>`)
+//line internal/web/templates/podcasts.qtpl:35
+		qw422016.E().S(p.Tag)
+//line internal/web/templates/podcasts.qtpl:35
+		qw422016.N().S(`</b>
+			<a href="`)
+//line internal/web/templates/podcasts.qtpl:36
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/podcasts.qtpl:36
+		qw422016.N().S(`/web/podcast/`)
+//line internal/web/templates/podcasts.qtpl:36
+		if !p.SubscribedOnly {
+//line internal/web/templates/podcasts.qtpl:36
+			qw422016.N().S(`?showall`)
+//line internal/web/templates/podcasts.qtpl:36
+		}
+//line internal/web/templates/podcasts.qtpl:36
+		qw422016.N().S(`">Clear filter</a>
+		</p>
+	`)
+//line internal/web/templates/podcasts.qtpl:38
 	}
-//line internal/web/templates/podcasts.qtpl:30
+//line internal/web/templates/podcasts.qtpl:38
 	qw422016.N().S(`
 
 	<table>
@@ -113,103 +149,144 @@ func (p *PodcastsPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext)
 		</thead>
 		<tbody>
 			`)
-//line internal/web/templates/podcasts.qtpl:42
+//line internal/web/templates/podcasts.qtpl:50
 	for _, po := range p.Podcasts {
-//line internal/web/templates/podcasts.qtpl:42
+//line internal/web/templates/podcasts.qtpl:50
 		qw422016.N().S(`
 				<tr>
 					<td>
 						<a href="`)
-//line internal/web/templates/podcasts.qtpl:45
+//line internal/web/templates/podcasts.qtpl:53
 		qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/podcasts.qtpl:45
+//line internal/web/templates/podcasts.qtpl:53
 		qw422016.N().S(`/web/podcast/`)
-//line internal/web/templates/podcasts.qtpl:45
+//line internal/web/templates/podcasts.qtpl:53
 		qw422016.N().D(int(po.PodcastID))
-//line internal/web/templates/podcasts.qtpl:45
+//line internal/web/templates/podcasts.qtpl:53
 		qw422016.N().S(`/">
 							`)
-//line internal/web/templates/podcasts.qtpl:46
+//line internal/web/templates/podcasts.qtpl:54
 		if po.Title != "" {
-//line internal/web/templates/podcasts.qtpl:46
+//line internal/web/templates/podcasts.qtpl:54
 			qw422016.E().S(po.Title)
-//line internal/web/templates/podcasts.qtpl:46
+//line internal/web/templates/podcasts.qtpl:54
 		} else {
-//line internal/web/templates/podcasts.qtpl:46
+//line internal/web/templates/podcasts.qtpl:54
 			qw422016.E().S(po.URL)
-//line internal/web/templates/podcasts.qtpl:46
+//line internal/web/templates/podcasts.qtpl:54
 		}
-//line internal/web/templates/podcasts.qtpl:46
+//line internal/web/templates/podcasts.qtpl:54
 		qw422016.N().S(`
 						</a>
 					</td>
 					<td>
 						`)
-//line internal/web/templates/podcasts.qtpl:50
+//line internal/web/templates/podcasts.qtpl:58
 		if !po.Subscribed {
-//line internal/web/templates/podcasts.qtpl:50
+//line internal/web/templates/podcasts.qtpl:58
 			qw422016.N().S(`<small><b>Not subscribed</b></small><br/>`)
-//line internal/web/templates/podcasts.qtpl:50
+//line internal/web/templates/podcasts.qtpl:58
 		}
-//line internal/web/templates/podcasts.qtpl:50
+//line internal/web/templates/podcasts.qtpl:58
 		qw422016.N().S(`
 						`)
-//line internal/web/templates/podcasts.qtpl:51
+//line internal/web/templates/podcasts.qtpl:59
 		qw422016.E().S(shortString(po.Description, 200))
-//line internal/web/templates/podcasts.qtpl:51
+//line internal/web/templates/podcasts.qtpl:59
+		qw422016.N().S(`
+						`)
+//line internal/web/templates/podcasts.qtpl:60
+		if len(po.Tags) > 0 {
+//line internal/web/templates/podcasts.qtpl:60
+			qw422016.N().S(`
+							<br/><small>
+							`)
+//line internal/web/templates/podcasts.qtpl:62
+			for _, t := range po.Tags {
+//line internal/web/templates/podcasts.qtpl:62
+				qw422016.N().S(`
+								<a href="`)
+//line internal/web/templates/podcasts.qtpl:63
+				qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/podcasts.qtpl:63
+				qw422016.N().S(`/web/podcast/?tag=`)
+//line internal/web/templates/podcasts.qtpl:63
+				qw422016.N().U(t.Tag)
+//line internal/web/templates/podcasts.qtpl:63
+				if !p.SubscribedOnly {
+//line internal/web/templates/podcasts.qtpl:63
+					qw422016.N().S(`&amp;showall`)
+//line internal/web/templates/podcasts.qtpl:63
+				}
+//line internal/web/templates/podcasts.qtpl:63
+				qw422016.N().S(`">`)
+//line internal/web/templates/podcasts.qtpl:63
+				qw422016.E().S(t.Title)
+//line internal/web/templates/podcasts.qtpl:63
+				qw422016.N().S(`</a>
+							`)
+//line internal/web/templates/podcasts.qtpl:64
+			}
+//line internal/web/templates/podcasts.qtpl:64
+			qw422016.N().S(`
+							</small>
+						`)
+//line internal/web/templates/podcasts.qtpl:66
+		}
+//line internal/web/templates/podcasts.qtpl:66
 		qw422016.N().S(`
 					</td>
 					<td>
 						`)
-//line internal/web/templates/podcasts.qtpl:54
+//line internal/web/templates/podcasts.qtpl:69
 		if po.LastEpisode != nil {
-//line internal/web/templates/podcasts.qtpl:54
+//line internal/web/templates/podcasts.qtpl:69
 			qw422016.N().S(`
 							`)
-//line internal/web/templates/podcasts.qtpl:55
+//line internal/web/templates/podcasts.qtpl:70
 			qw422016.E().S(formatDateTime(po.LastEpisode.Timestamp))
-//line internal/web/templates/podcasts.qtpl:55
+//line internal/web/templates/podcasts.qtpl:70
 			qw422016.N().S(`
 							(`)
-//line internal/web/templates/podcasts.qtpl:56
+//line internal/web/templates/podcasts.qtpl:71
 			qw422016.E().S(po.LastEpisode.Action)
-//line internal/web/templates/podcasts.qtpl:56
+//line internal/web/templates/podcasts.qtpl:71
 			qw422016.N().S(`)
 						`)
-//line internal/web/templates/podcasts.qtpl:57
+//line internal/web/templates/podcasts.qtpl:72
 		}
-//line internal/web/templates/podcasts.qtpl:57
+//line internal/web/templates/podcasts.qtpl:72
 		qw422016.N().S(`
 					</td>
 					<td>
 						`)
-//line internal/web/templates/podcasts.qtpl:60
+//line internal/web/templates/podcasts.qtpl:75
 		if po.Website != "" {
-//line internal/web/templates/podcasts.qtpl:60
+//line internal/web/templates/podcasts.qtpl:75
 			qw422016.N().S(`<a href="`)
-//line internal/web/templates/podcasts.qtpl:60
+//line internal/web/templates/podcasts.qtpl:75
 			qw422016.E().S(po.Website)
-//line internal/web/templates/podcasts.qtpl:60
+//line internal/web/templates/podcasts.qtpl:75
 			qw422016.N().S(`">Website</a><br/>`)
-//line internal/web/templates/podcasts.qtpl:60
+//line internal/web/templates/podcasts.qtpl:75
 		}
-//line internal/web/templates/podcasts.qtpl:60
+//line internal/web/templates/podcasts.qtpl:75
 		qw422016.N().S(`
 						<a href="`)
-//line internal/web/templates/podcasts.qtpl:61
+//line internal/web/templates/podcasts.qtpl:76
 		qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/podcasts.qtpl:61
+//line internal/web/templates/podcasts.qtpl:76
 		qw422016.N().S(`/web/episode/?podcast=`)
-//line internal/web/templates/podcasts.qtpl:61
+//line internal/web/templates/podcasts.qtpl:76
 		qw422016.N().D(int(po.PodcastID))
-//line internal/web/templates/podcasts.qtpl:61
+//line internal/web/templates/podcasts.qtpl:76
 		qw422016.N().S(`">Episodes</a>
 					</td>
 				</tr>
 			`)
-//line internal/web/templates/podcasts.qtpl:64
+//line internal/web/templates/podcasts.qtpl:79
 	}
-//line internal/web/templates/podcasts.qtpl:64
+//line internal/web/templates/podcasts.qtpl:79
 	qw422016.N().S(`
 		</tbody>
 	</table>
@@ -217,31 +294,31 @@ func (p *PodcastsPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext)
 
 
 `)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 }
 
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 func (p *PodcastsPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 }
 
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 func (p *PodcastsPage) Body(pctx *PageContext) string {
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 	return qs422016
-//line internal/web/templates/podcasts.qtpl:70
+//line internal/web/templates/podcasts.qtpl:85
 }
@@ -0,0 +1,205 @@
+// Code generated by qtc from "register.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/register.qtpl:1
+package templates
+
+//line internal/web/templates/register.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/register.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/register.qtpl:2
+type RegisterPage struct {
+	Msg        string
+	UserName   string
+	Email      string
+	Name       string
+	InviteCode string
+	// RequireInvite show invite code field.
+	RequireInvite bool
+	// Registered is true when account was created.
+	Registered      bool
+	PendingApproval bool
+}
+
+//line internal/web/templates/register.qtpl:16
+func (p *RegisterPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/register.qtpl:16
+	qw422016.N().S(`Register`)
+//line internal/web/templates/register.qtpl:16
+}
+
+//line internal/web/templates/register.qtpl:16
+func (p *RegisterPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/register.qtpl:16
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/register.qtpl:16
+	p.StreamTitle(qw422016)
+//line internal/web/templates/register.qtpl:16
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/register.qtpl:16
+}
+
+//line internal/web/templates/register.qtpl:16
+func (p *RegisterPage) Title() string {
+//line internal/web/templates/register.qtpl:16
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/register.qtpl:16
+	p.WriteTitle(qb422016)
+//line internal/web/templates/register.qtpl:16
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/register.qtpl:16
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/register.qtpl:16
+	return qs422016
+//line internal/web/templates/register.qtpl:16
+}
+
+//line internal/web/templates/register.qtpl:18
+func (p *RegisterPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/register.qtpl:18
+	qw422016.N().S(`
+<section>
+	<h1>Register</h1>
+	`)
+//line internal/web/templates/register.qtpl:21
+	if p.Registered {
+//line internal/web/templates/register.qtpl:21
+		qw422016.N().S(`
+		`)
+//line internal/web/templates/register.qtpl:22
+		if p.PendingApproval {
+//line internal/web/templates/register.qtpl:22
+			qw422016.N().S(`
+		<p>Account created. It will be active after approval by administrator.</p>
+		`)
+//line internal/web/templates/register.qtpl:24
+		} else {
+//line internal/web/templates/register.qtpl:24
+			qw422016.N().S(`
+		<p>Account created. You can <a href="`)
+//line internal/web/templates/register.qtpl:25
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/register.qtpl:25
+			qw422016.N().S(`/web/login">login</a> now.</p>
+		`)
+//line internal/web/templates/register.qtpl:26
+		}
+//line internal/web/templates/register.qtpl:26
+		qw422016.N().S(`
+	`)
+//line internal/web/templates/register.qtpl:27
+	} else {
+//line internal/web/templates/register.qtpl:27
+		qw422016.N().S(`
+	`)
+//line internal/web/templates/register.qtpl:28
+		if p.Msg != "" {
+//line internal/web/templates/register.qtpl:28
+			qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/register.qtpl:29
+			qw422016.E().S(p.Msg)
+//line internal/web/templates/register.qtpl:29
+			qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/register.qtpl:30
+		}
+//line internal/web/templates/register.qtpl:30
+		qw422016.N().S(`
+
+	<form method="post" action="`)
+//line internal/web/templates/register.qtpl:32
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/register.qtpl:32
+		qw422016.N().S(`/web/register">
+		`)
+//line internal/web/templates/register.qtpl:33
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/register.qtpl:33
+		qw422016.N().S(`
+		<fieldset>
+		<p><label>User name:</label> <input name="username" value="`)
+//line internal/web/templates/register.qtpl:35
+		qw422016.E().S(p.UserName)
+//line internal/web/templates/register.qtpl:35
+		qw422016.N().S(`" autocomplete="username" required autofocus></p>
+		<p><label>Email:</label> <input name="email" type="email" value="`)
+//line internal/web/templates/register.qtpl:36
+		qw422016.E().S(p.Email)
+//line internal/web/templates/register.qtpl:36
+		qw422016.N().S(`" autocomplete="email" required></p>
+		<p><label>Name:</label> <input name="name" value="`)
+//line internal/web/templates/register.qtpl:37
+		qw422016.E().S(p.Name)
+//line internal/web/templates/register.qtpl:37
+		qw422016.N().S(`" autocomplete="name"></p>
+		<p><label>Password:</label> <input name="password" type="password" autocomplete="new-password" required></p>
+		<p><label>Repeat password:</label> <input name="password2" type="password" autocomplete="new-password" required></p>
+		`)
+//line internal/web/templates/register.qtpl:40
+		if p.RequireInvite {
+//line internal/web/templates/register.qtpl:40
+			qw422016.N().S(`
+		<p><label>Invite code:</label> <input name="invite" value="`)
+//line internal/web/templates/register.qtpl:41
+			qw422016.E().S(p.InviteCode)
+//line internal/web/templates/register.qtpl:41
+			qw422016.N().S(`" required></p>
+		`)
+//line internal/web/templates/register.qtpl:42
+		}
+//line internal/web/templates/register.qtpl:42
+		qw422016.N().S(`
+		<p><button type="submit">Register</button></p>
+		</fieldset>
+	</form>
+	<p><a href="`)
+//line internal/web/templates/register.qtpl:46
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/register.qtpl:46
+		qw422016.N().S(`/web/login">Login</a></p>
+	`)
+//line internal/web/templates/register.qtpl:47
+	}
+//line internal/web/templates/register.qtpl:47
+	qw422016.N().S(`
+</section>
+`)
+//line internal/web/templates/register.qtpl:49
+}
+
+//line internal/web/templates/register.qtpl:49
+func (p *RegisterPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/register.qtpl:49
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/register.qtpl:49
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/register.qtpl:49
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/register.qtpl:49
+}
+
+//line internal/web/templates/register.qtpl:49
+func (p *RegisterPage) Body(pctx *PageContext) string {
+//line internal/web/templates/register.qtpl:49
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/register.qtpl:49
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/register.qtpl:49
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/register.qtpl:49
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/register.qtpl:49
+	return qs422016
+//line internal/web/templates/register.qtpl:49
+}
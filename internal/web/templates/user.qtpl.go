@@ -5,98 +5,369 @@
 package templates
 
 //line internal/web/templates/user.qtpl:1
+import "gitlab.com/kabes/go-gpo/internal/model"
+
+//line internal/web/templates/user.qtpl:3
 import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
 )
 
-//line internal/web/templates/user.qtpl:1
+//line internal/web/templates/user.qtpl:3
 var (
 	_ = qtio422016.Copy
 	_ = qt422016.AcquireByteBuffer
 )
 
-//line internal/web/templates/user.qtpl:2
+//line internal/web/templates/user.qtpl:4
 type UserPage struct {
+	Tokens []model.AppToken
+	// NewToken is just created token; displayed only once.
+	NewToken string
+	Msg      string
+	// TOTPEnabled is true when user use two-factor authentication.
+	TOTPEnabled bool
+	TOTPMsg     string
+	// Admin show links to administration pages.
+	Admin bool
+	// AuditEvents are recent security events for user.
+	AuditEvents []model.AuditEvent
 }
 
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 func (p *UserPage) StreamTitle(qw422016 *qt422016.Writer) {
-//line internal/web/templates/user.qtpl:6
-	qw422016.N().S(`Change password`)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
+	qw422016.N().S(`User`)
+//line internal/web/templates/user.qtpl:19
 }
 
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 func (p *UserPage) WriteTitle(qq422016 qtio422016.Writer) {
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	p.StreamTitle(qw422016)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 }
 
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 func (p *UserPage) Title() string {
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	p.WriteTitle(qb422016)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 	return qs422016
-//line internal/web/templates/user.qtpl:6
+//line internal/web/templates/user.qtpl:19
 }
 
-//line internal/web/templates/user.qtpl:8
+//line internal/web/templates/user.qtpl:21
 func (p *UserPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
-//line internal/web/templates/user.qtpl:8
+//line internal/web/templates/user.qtpl:21
 	qw422016.N().S(`
 <section>
 	<h2>User</h2>
 
 	<ul>
 		<li><a href="`)
-//line internal/web/templates/user.qtpl:13
+//line internal/web/templates/user.qtpl:26
 	qw422016.E().S(pctx.Webroot)
-//line internal/web/templates/user.qtpl:13
+//line internal/web/templates/user.qtpl:26
 	qw422016.N().S(`/web/user/password">Change user password</a></li>
 	</ul>
 </section>
 
+`)
+//line internal/web/templates/user.qtpl:30
+	if p.Admin {
+//line internal/web/templates/user.qtpl:30
+		qw422016.N().S(`
+<section>
+	<h2>Administration</h2>
+
+	<ul>
+		<li><a href="`)
+//line internal/web/templates/user.qtpl:35
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:35
+		qw422016.N().S(`/web/admin/users">Users</a></li>
+		<li><a href="`)
+//line internal/web/templates/user.qtpl:36
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:36
+		qw422016.N().S(`/web/admin/registrations">Registrations and invite codes</a></li>
+		<li><a href="`)
+//line internal/web/templates/user.qtpl:37
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:37
+		qw422016.N().S(`/web/admin/audit">Audit log</a></li>
+	</ul>
+</section>
+`)
+//line internal/web/templates/user.qtpl:40
+	}
+//line internal/web/templates/user.qtpl:40
+	qw422016.N().S(`
+
+<section>
+	<h2>Two-factor authentication</h2>
+	`)
+//line internal/web/templates/user.qtpl:44
+	if p.TOTPMsg != "" {
+//line internal/web/templates/user.qtpl:44
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/user.qtpl:45
+		qw422016.E().S(p.TOTPMsg)
+//line internal/web/templates/user.qtpl:45
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/user.qtpl:46
+	}
+//line internal/web/templates/user.qtpl:46
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/user.qtpl:47
+	if p.TOTPEnabled {
+//line internal/web/templates/user.qtpl:47
+		qw422016.N().S(`
+		<p>Two-factor authentication is enabled.</p>
+		<form method="POST" action="`)
+//line internal/web/templates/user.qtpl:49
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:49
+		qw422016.N().S(`/web/user/totp/disable">
+			`)
+//line internal/web/templates/user.qtpl:50
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/user.qtpl:50
+		qw422016.N().S(`
+			<label for="code">Verification or recovery code</label>
+			<input type="text" name="code" id="code" autocomplete="one-time-code" required />
+			<button type="submit">Disable</button>
+		</form>
+	`)
+//line internal/web/templates/user.qtpl:55
+	} else {
+//line internal/web/templates/user.qtpl:55
+		qw422016.N().S(`
+		<p>Two-factor authentication is disabled.
+		<a href="`)
+//line internal/web/templates/user.qtpl:57
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:57
+		qw422016.N().S(`/web/user/totp">Enable</a></p>
+	`)
+//line internal/web/templates/user.qtpl:58
+	}
+//line internal/web/templates/user.qtpl:58
+	qw422016.N().S(`
+</section>
+
+<section>
+	<h2>App tokens</h2>
+	<p>App tokens can be used by clients instead of user password.</p>
+	`)
+//line internal/web/templates/user.qtpl:64
+	if p.Msg != "" {
+//line internal/web/templates/user.qtpl:64
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/user.qtpl:65
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/user.qtpl:65
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/user.qtpl:66
+	}
+//line internal/web/templates/user.qtpl:66
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/user.qtpl:67
+	if p.NewToken != "" {
+//line internal/web/templates/user.qtpl:67
+		qw422016.N().S(`
+		<p>New token: <code>`)
+//line internal/web/templates/user.qtpl:68
+		qw422016.E().S(p.NewToken)
+//line internal/web/templates/user.qtpl:68
+		qw422016.N().S(`</code><br/>
+		Copy it now - token can't be displayed again.</p>
+	`)
+//line internal/web/templates/user.qtpl:70
+	}
+//line internal/web/templates/user.qtpl:70
+	qw422016.N().S(`
+	`)
+//line internal/web/templates/user.qtpl:71
+	if len(p.Tokens) == 0 {
+//line internal/web/templates/user.qtpl:71
+		qw422016.N().S(`
+		<p>No tokens yet.</p>
+	`)
+//line internal/web/templates/user.qtpl:73
+	} else {
+//line internal/web/templates/user.qtpl:73
+		qw422016.N().S(`
+	<table>
+		<thead>
+			<tr>
+				<th>Name</th>
+				<th>Device</th>
+				<th>Read-only</th>
+				<th>Created</th>
+				<th>Last used</th>
+				<th>&nbsp;</th>
+			</tr>
+		</thead>
+		<tbody>
+			`)
+//line internal/web/templates/user.qtpl:86
+		for _, t := range p.Tokens {
+//line internal/web/templates/user.qtpl:86
+			qw422016.N().S(`
+			<tr>
+				<td>`)
+//line internal/web/templates/user.qtpl:88
+			qw422016.E().S(t.Name)
+//line internal/web/templates/user.qtpl:88
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/user.qtpl:89
+			if t.DeviceName != "" {
+//line internal/web/templates/user.qtpl:89
+				qw422016.E().S(t.DeviceName)
+//line internal/web/templates/user.qtpl:89
+			} else {
+//line internal/web/templates/user.qtpl:89
+				qw422016.N().S(`any`)
+//line internal/web/templates/user.qtpl:89
+			}
+//line internal/web/templates/user.qtpl:89
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/user.qtpl:90
+			if t.ReadOnly {
+//line internal/web/templates/user.qtpl:90
+				qw422016.N().S(`yes`)
+//line internal/web/templates/user.qtpl:90
+			} else {
+//line internal/web/templates/user.qtpl:90
+				qw422016.N().S(`no`)
+//line internal/web/templates/user.qtpl:90
+			}
+//line internal/web/templates/user.qtpl:90
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/user.qtpl:91
+			qw422016.E().S(t.CreatedAt.Format("2006-01-02 15:04"))
+//line internal/web/templates/user.qtpl:91
+			qw422016.N().S(`</td>
+				<td>`)
+//line internal/web/templates/user.qtpl:92
+			if !t.LastUsedAt.IsZero() {
+//line internal/web/templates/user.qtpl:92
+				qw422016.E().S(t.LastUsedAt.Format("2006-01-02 15:04"))
+//line internal/web/templates/user.qtpl:92
+			}
+//line internal/web/templates/user.qtpl:92
+			qw422016.N().S(`</td>
+				<td>
+					<form method="POST" action="`)
+//line internal/web/templates/user.qtpl:94
+			qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:94
+			qw422016.N().S(`/web/user/tokens/delete">
+						`)
+//line internal/web/templates/user.qtpl:95
+			streamcsrfField(qw422016, pctx)
+//line internal/web/templates/user.qtpl:95
+			qw422016.N().S(`
+						<input type="hidden" name="name" value="`)
+//line internal/web/templates/user.qtpl:96
+			qw422016.E().S(t.Name)
+//line internal/web/templates/user.qtpl:96
+			qw422016.N().S(`" />
+						<button type="submit">Revoke</button>
+					</form>
+				</td>
+			</tr>
+			`)
+//line internal/web/templates/user.qtpl:101
+		}
+//line internal/web/templates/user.qtpl:101
+		qw422016.N().S(`
+		</tbody>
+	</table>
+	`)
+//line internal/web/templates/user.qtpl:104
+	}
+//line internal/web/templates/user.qtpl:104
+	qw422016.N().S(`
+</section>
+
+<section>
+	<h2>New app token</h2>
+	<form method="POST" action="`)
+//line internal/web/templates/user.qtpl:109
+	qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user.qtpl:109
+	qw422016.N().S(`/web/user/tokens">
+		`)
+//line internal/web/templates/user.qtpl:110
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/user.qtpl:110
+	qw422016.N().S(`
+		<label for="name">Name</label>
+		<input type="text" name="name" id="name" required />
+		<label for="device">Device (optional)</label>
+		<input type="text" name="device" id="device" />
+		<label><input type="checkbox" name="readonly" value="1" /> Read-only</label>
+		<button type="submit">Create</button>
+	</form>
+</section>
 
+<section>
+	<h2>Recent security events</h2>
+	`)
+//line internal/web/templates/user.qtpl:122
+	streamauditEventsTable(qw422016, p.AuditEvents, false)
+//line internal/web/templates/user.qtpl:122
+	qw422016.N().S(`
+</section>
 `)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 }
 
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 func (p *UserPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 }
 
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 func (p *UserPage) Body(pctx *PageContext) string {
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 	return qs422016
-//line internal/web/templates/user.qtpl:18
+//line internal/web/templates/user.qtpl:124
 }
@@ -78,6 +78,11 @@ func (p *UserChangePassPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageCon
 	qw422016.N().S(`
 
 	<form method="post">
+		`)
+//line internal/web/templates/user_change_password.qtpl:17
+	streamcsrfField(qw422016, pctx)
+//line internal/web/templates/user_change_password.qtpl:17
+	qw422016.N().S(`
 		<fieldset>
 		<p><label>Current password:</label> <input name="cpass" type="password"></p>
 		<p><label>New password:</label> <input name="npass1" type="password"></p>
@@ -89,31 +94,31 @@ func (p *UserChangePassPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageCon
 
 
 `)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 }
 
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 func (p *UserChangePassPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	p.StreamBody(qw422016, pctx)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	qt422016.ReleaseWriter(qw422016)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 }
 
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 func (p *UserChangePassPage) Body(pctx *PageContext) string {
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	qb422016 := qt422016.AcquireByteBuffer()
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	p.WriteBody(qb422016, pctx)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	qs422016 := string(qb422016.B)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	qt422016.ReleaseByteBuffer(qb422016)
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 	return qs422016
-//line internal/web/templates/user_change_password.qtpl:27
+//line internal/web/templates/user_change_password.qtpl:28
 }
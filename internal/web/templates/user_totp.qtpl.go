@@ -0,0 +1,184 @@
+// Code generated by qtc from "user_totp.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line internal/web/templates/user_totp.qtpl:1
+package templates
+
+//line internal/web/templates/user_totp.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line internal/web/templates/user_totp.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line internal/web/templates/user_totp.qtpl:2
+type UserTOTPPage struct {
+	// Secret is new secret to confirm; displayed for manual entry in authenticator app.
+	Secret string
+	// RecoveryCodes are generated after enabling 2FA; displayed only once.
+	RecoveryCodes []string
+	Msg           string
+}
+
+//line internal/web/templates/user_totp.qtpl:11
+func (p *UserTOTPPage) StreamTitle(qw422016 *qt422016.Writer) {
+//line internal/web/templates/user_totp.qtpl:11
+	qw422016.N().S(`Two-factor authentication`)
+//line internal/web/templates/user_totp.qtpl:11
+}
+
+//line internal/web/templates/user_totp.qtpl:11
+func (p *UserTOTPPage) WriteTitle(qq422016 qtio422016.Writer) {
+//line internal/web/templates/user_totp.qtpl:11
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/user_totp.qtpl:11
+	p.StreamTitle(qw422016)
+//line internal/web/templates/user_totp.qtpl:11
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/user_totp.qtpl:11
+}
+
+//line internal/web/templates/user_totp.qtpl:11
+func (p *UserTOTPPage) Title() string {
+//line internal/web/templates/user_totp.qtpl:11
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/user_totp.qtpl:11
+	p.WriteTitle(qb422016)
+//line internal/web/templates/user_totp.qtpl:11
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/user_totp.qtpl:11
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/user_totp.qtpl:11
+	return qs422016
+//line internal/web/templates/user_totp.qtpl:11
+}
+
+//line internal/web/templates/user_totp.qtpl:13
+func (p *UserTOTPPage) StreamBody(qw422016 *qt422016.Writer, pctx *PageContext) {
+//line internal/web/templates/user_totp.qtpl:13
+	qw422016.N().S(`
+<section>
+	<h1>Two-factor authentication</h1>
+	`)
+//line internal/web/templates/user_totp.qtpl:16
+	if p.Msg != "" {
+//line internal/web/templates/user_totp.qtpl:16
+		qw422016.N().S(`
+		<p><b>`)
+//line internal/web/templates/user_totp.qtpl:17
+		qw422016.E().S(p.Msg)
+//line internal/web/templates/user_totp.qtpl:17
+		qw422016.N().S(`</b></p>
+	`)
+//line internal/web/templates/user_totp.qtpl:18
+	}
+//line internal/web/templates/user_totp.qtpl:18
+	qw422016.N().S(`
+
+	`)
+//line internal/web/templates/user_totp.qtpl:20
+	if len(p.RecoveryCodes) > 0 {
+//line internal/web/templates/user_totp.qtpl:20
+		qw422016.N().S(`
+		<p>Two-factor authentication enabled.</p>
+		<p>Recovery codes (each can be used once instead of verification code):</p>
+		<ul>
+		`)
+//line internal/web/templates/user_totp.qtpl:24
+		for _, c := range p.RecoveryCodes {
+//line internal/web/templates/user_totp.qtpl:24
+			qw422016.N().S(`
+			<li><code>`)
+//line internal/web/templates/user_totp.qtpl:25
+			qw422016.E().S(c)
+//line internal/web/templates/user_totp.qtpl:25
+			qw422016.N().S(`</code></li>
+		`)
+//line internal/web/templates/user_totp.qtpl:26
+		}
+//line internal/web/templates/user_totp.qtpl:26
+		qw422016.N().S(`
+		</ul>
+		<p>Save them now - codes can't be displayed again.</p>
+		<p><a href="`)
+//line internal/web/templates/user_totp.qtpl:29
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user_totp.qtpl:29
+		qw422016.N().S(`/web/user/">Back</a></p>
+	`)
+//line internal/web/templates/user_totp.qtpl:30
+	} else {
+//line internal/web/templates/user_totp.qtpl:30
+		qw422016.N().S(`
+		<p>Scan QR code in authenticator app or enter secret manually.</p>
+		<p><img src="`)
+//line internal/web/templates/user_totp.qtpl:32
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user_totp.qtpl:32
+		qw422016.N().S(`/web/user/totp/qr.png" alt="QR code" width="200" height="200" /></p>
+		<p>Secret: <code>`)
+//line internal/web/templates/user_totp.qtpl:33
+		qw422016.E().S(p.Secret)
+//line internal/web/templates/user_totp.qtpl:33
+		qw422016.N().S(`</code></p>
+
+		<form method="post" action="`)
+//line internal/web/templates/user_totp.qtpl:35
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user_totp.qtpl:35
+		qw422016.N().S(`/web/user/totp">
+			`)
+//line internal/web/templates/user_totp.qtpl:36
+		streamcsrfField(qw422016, pctx)
+//line internal/web/templates/user_totp.qtpl:36
+		qw422016.N().S(`
+			<fieldset>
+			<p><label>Verification code:</label> <input name="code" autocomplete="one-time-code" required></p>
+			<p><a href="`)
+//line internal/web/templates/user_totp.qtpl:39
+		qw422016.E().S(pctx.Webroot)
+//line internal/web/templates/user_totp.qtpl:39
+		qw422016.N().S(`/web/user/">Cancel</a> <button type="submit">Enable</button></p>
+			</fieldset>
+		</form>
+	`)
+//line internal/web/templates/user_totp.qtpl:42
+	}
+//line internal/web/templates/user_totp.qtpl:42
+	qw422016.N().S(`
+</section>
+`)
+//line internal/web/templates/user_totp.qtpl:44
+}
+
+//line internal/web/templates/user_totp.qtpl:44
+func (p *UserTOTPPage) WriteBody(qq422016 qtio422016.Writer, pctx *PageContext) {
+//line internal/web/templates/user_totp.qtpl:44
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line internal/web/templates/user_totp.qtpl:44
+	p.StreamBody(qw422016, pctx)
+//line internal/web/templates/user_totp.qtpl:44
+	qt422016.ReleaseWriter(qw422016)
+//line internal/web/templates/user_totp.qtpl:44
+}
+
+//line internal/web/templates/user_totp.qtpl:44
+func (p *UserTOTPPage) Body(pctx *PageContext) string {
+//line internal/web/templates/user_totp.qtpl:44
+	qb422016 := qt422016.AcquireByteBuffer()
+//line internal/web/templates/user_totp.qtpl:44
+	p.WriteBody(qb422016, pctx)
+//line internal/web/templates/user_totp.qtpl:44
+	qs422016 := string(qb422016.B)
+//line internal/web/templates/user_totp.qtpl:44
+	qt422016.ReleaseByteBuffer(qb422016)
+//line internal/web/templates/user_totp.qtpl:44
+	return qs422016
+//line internal/web/templates/user_totp.qtpl:44
+}
@@ -12,25 +12,39 @@ import (
 	"errors"
 	"net/http"
 
+	"gitea.com/go-chi/session"
 	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 	"github.com/samber/do/v2"
+	"gitlab.com/kabes/go-gpo/internal/aerr"
 	"gitlab.com/kabes/go-gpo/internal/command"
 	"gitlab.com/kabes/go-gpo/internal/common"
+	"gitlab.com/kabes/go-gpo/internal/query"
 	"gitlab.com/kabes/go-gpo/internal/server/srvsupport"
 	"gitlab.com/kabes/go-gpo/internal/service"
 	nt "gitlab.com/kabes/go-gpo/internal/web/templates"
 )
 
+// userAuditEventsLimit is number of recent security events displayed on user page.
+const userAuditEventsLimit = 20
+
 type userPages struct {
-	usersSrv *service.UsersSrv
-	renderer *nt.Renderer
+	usersSrv  *service.UsersSrv
+	tokensSrv *service.AppTokensSrv
+	totpSrv   *service.TOTPSrv
+	auditSrv  *service.AuditSrv
+	webroot   string
+	renderer  *nt.Renderer
 }
 
 func newUserPages(i do.Injector) (userPages, error) {
 	return userPages{
-		usersSrv: do.MustInvoke[*service.UsersSrv](i),
-		renderer: do.MustInvoke[*nt.Renderer](i),
+		usersSrv:  do.MustInvoke[*service.UsersSrv](i),
+		tokensSrv: do.MustInvoke[*service.AppTokensSrv](i),
+		totpSrv:   do.MustInvoke[*service.TOTPSrv](i),
+		auditSrv:  do.MustInvoke[*service.AuditSrv](i),
+		webroot:   do.MustInvokeNamed[string](i, "server.webroot"),
+		renderer:  do.MustInvoke[*nt.Renderer](i),
 	}, nil
 }
 
@@ -39,6 +53,12 @@ func (u userPages) Routes() *chi.Mux {
 	r.Get(`/`, srvsupport.WrapNamed(u.userPage, "web_user_index"))
 	r.Get(`/password`, srvsupport.WrapNamed(u.changePassword, "web_user_pass"))
 	r.Post(`/password`, srvsupport.WrapNamed(u.changePassword, "web_user_pass_post"))
+	r.Post(`/tokens`, srvsupport.WrapNamed(u.createToken, "web_user_token_post"))
+	r.Post(`/tokens/delete`, srvsupport.WrapNamed(u.deleteToken, "web_user_token_del_post"))
+	r.Get(`/totp`, srvsupport.WrapNamed(u.totpEnrol, "web_user_totp"))
+	r.Get(`/totp/qr.png`, srvsupport.WrapNamed(u.totpQRCode, "web_user_totp_qr"))
+	r.Post(`/totp`, srvsupport.WrapNamed(u.totpEnable, "web_user_totp_post"))
+	r.Post(`/totp/disable`, srvsupport.WrapNamed(u.totpDisable, "web_user_totp_del_post"))
 
 	return r
 }
@@ -49,7 +69,244 @@ func (u userPages) userPage(
 	r *http.Request,
 	logger *zerolog.Logger,
 ) {
-	u.renderer.WritePage(w, &nt.UserPage{})
+	u.writeUserPage(ctx, w, r, logger, &nt.UserPage{})
+}
+
+func (u userPages) createToken(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.User: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	user := common.ContextUser(ctx)
+	cmd := command.CreateAppTokenCmd{
+		UserName:   user,
+		Name:       r.FormValue("name"),
+		DeviceName: r.FormValue("device"),
+		ReadOnly:   r.FormValue("readonly") != "",
+	}
+
+	page := nt.UserPage{}
+
+	res, err := u.tokensSrv.CreateAppToken(ctx, &cmd)
+	switch {
+	case err == nil:
+		page.NewToken = res.Token
+	case aerr.HasTag(err, aerr.ValidationError):
+		page.Msg = "Error: " + aerr.GetUserMessage(err)
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).Object("cmd", &cmd).
+			Msgf("web.User: create token user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	u.writeUserPage(ctx, w, r, logger, &page)
+}
+
+func (u userPages) deleteToken(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.User: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	user := common.ContextUser(ctx)
+	cmd := command.DeleteAppTokenCmd{UserName: user, Name: r.FormValue("name")}
+
+	if err := u.tokensSrv.DeleteAppToken(ctx, &cmd); err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).Object("cmd", &cmd).
+			Msgf("web.User: delete token user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	http.Redirect(w, r, u.webroot+"/web/user/", http.StatusFound)
+}
+
+func (u userPages) writeUserPage(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+	page *nt.UserPage,
+) {
+	user := common.ContextUser(ctx)
+
+	tokens, err := u.tokensSrv.GetAppTokens(ctx, &query.GetAppTokensQuery{UserName: user})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.User: get tokens user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	userinfo, err := u.usersSrv.CheckUser(ctx, user)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.User: get user user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	events, err := u.auditSrv.ListEvents(ctx, &query.GetAuditEventsQuery{UserName: user, Limit: userAuditEventsLimit})
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.User: get audit events user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	page.Tokens = tokens
+	page.AuditEvents = events
+	page.TOTPEnabled = userinfo.TOTPEnabled()
+	page.Admin = userinfo.Admin
+	u.renderer.WritePage(ctx, w, page)
+}
+
+// totpEnrol start enabling two-factor authentication. New key is kept in session until confirmed
+// by valid code.
+func (u userPages) totpEnrol(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	user := common.ContextUser(ctx)
+
+	key, err := u.totpSrv.GenerateTOTPKey(user)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.User: generate totp key user_name=%s error=%q", user, err)
+
+		return
+	}
+
+	sess := session.GetSession(r)
+	_ = sess.Set("totp_enrol_secret", key.Secret)
+	_ = sess.Set("totp_enrol_url", key.URL)
+
+	u.renderer.WritePage(ctx, w, &nt.UserTOTPPage{Secret: key.Secret})
+}
+
+func (u userPages) totpQRCode(
+	_ context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	url, _ := session.GetSession(r).Get("totp_enrol_url").(string)
+	if url == "" {
+		srvsupport.WriteError(w, r, http.StatusNotFound, "")
+
+		return
+	}
+
+	img, err := service.TOTPQRCode(url)
+	if err != nil {
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).Msgf("web.User: generate qr code error=%q", err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(img)
+}
+
+func (u userPages) totpEnable(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.User: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	sess := session.GetSession(r)
+
+	secret, _ := sess.Get("totp_enrol_secret").(string)
+	if secret == "" {
+		http.Redirect(w, r, u.webroot+"/web/user/totp", http.StatusFound)
+
+		return
+	}
+
+	user := common.ContextUser(ctx)
+	cmd := command.EnableTOTPCmd{UserName: user, Secret: secret, Code: r.FormValue("code")}
+
+	res, err := u.totpSrv.EnableTOTP(ctx, &cmd)
+
+	switch {
+	case err == nil:
+		_ = sess.Delete("totp_enrol_secret")
+		_ = sess.Delete("totp_enrol_url")
+
+		u.renderer.WritePage(ctx, w, &nt.UserTOTPPage{RecoveryCodes: res.RecoveryCodes})
+	case errors.Is(err, common.ErrInvalidTOTPCode) || aerr.HasTag(err, aerr.ValidationError):
+		u.renderer.WritePage(ctx, w, &nt.UserTOTPPage{
+			Secret: secret,
+			Msg:    "Error: " + aerr.GetUserMessage(err),
+		})
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.User: enable totp user_name=%s error=%q", user, err)
+	}
+}
+
+func (u userPages) totpDisable(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *zerolog.Logger,
+) {
+	if err := r.ParseForm(); err != nil {
+		logger.Info().Err(err).Msgf("web.User: bad request - parse form error=%q", err)
+		srvsupport.WriteError(w, r, http.StatusBadRequest, "")
+
+		return
+	}
+
+	user := common.ContextUser(ctx)
+	cmd := command.DisableTOTPCmd{UserName: user, Code: r.FormValue("code"), CheckCode: true}
+
+	err := u.totpSrv.DisableTOTP(ctx, &cmd)
+
+	switch {
+	case err == nil:
+		u.writeUserPage(ctx, w, r, logger, &nt.UserPage{TOTPMsg: "Two-factor authentication disabled"})
+	case errors.Is(err, common.ErrInvalidTOTPCode) || aerr.HasTag(err, aerr.ValidationError):
+		u.writeUserPage(ctx, w, r, logger, &nt.UserPage{TOTPMsg: "Error: " + aerr.GetUserMessage(err)})
+	default:
+		srvsupport.CheckAndWriteError(w, r, err)
+		logger.WithLevel(aerr.LogLevelForError(err)).Err(err).
+			Msgf("web.User: disable totp user_name=%s error=%q", user, err)
+	}
 }
 
 func (u userPages) changePassword(
@@ -71,7 +328,7 @@ func (u userPages) changePassword(
 		msg = u.doChangePassword(ctx, r, logger)
 	}
 
-	u.renderer.WritePage(w, &nt.UserChangePassPage{Msg: msg})
+	u.renderer.WritePage(ctx, w, &nt.UserChangePassPage{Msg: msg})
 }
 
 func (u userPages) doChangePassword(ctx context.Context, r *http.Request, logger *zerolog.Logger) string {
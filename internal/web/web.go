@@ -28,6 +28,9 @@ func New(i do.Injector) (WEB, error) {
 	userPages := do.MustInvoke[userPages](i)
 	episodePages := do.MustInvoke[episodePages](i)
 	podcastPages := do.MustInvoke[podcastPages](i)
+	explorePage := do.MustInvoke[explorePage](i)
+	listsPages := do.MustInvoke[listsPages](i)
+	adminPages := do.MustInvoke[adminPages](i)
 
 	router := chi.NewRouter()
 
@@ -36,6 +39,9 @@ func New(i do.Injector) (WEB, error) {
 	router.Mount("/podcast", podcastPages.Routes())
 	router.Mount("/episode", episodePages.Routes())
 	router.Mount("/user", userPages.Routes())
+	router.Mount("/explore", explorePage.Routes())
+	router.Mount("/lists", listsPages.Routes())
+	router.Mount("/admin", adminPages.Routes())
 
 	fs := http.FileServerFS(staticFS)
 	router.Method("GET", "/static/*", http.StripPrefix("/web/", fs))